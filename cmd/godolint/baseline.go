@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/farcloser/godolint/internal/baseline"
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+// errIncompleteBaseline reports a baseline not written because rules could
+// not check some instructions.
+var errIncompleteBaseline = errors.New("rules could not check some instructions, baseline not written")
+
+// defaultBaselineFile is where `baseline create` writes unless told otherwise.
+const defaultBaselineFile = ".godolint-baseline.json"
+
+// baselineCommand manages baseline files, which record existing failures so
+// that --baseline only reports new ones.
+func baselineCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "baseline",
+		Usage: "Manage baseline files of known failures",
+		Commands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "Record the current failures of the given Dockerfiles",
+				ArgsUsage: "Dockerfile...",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "output",
+						Value: defaultBaselineFile,
+						Usage: "Baseline `FILE` to write",
+					},
+				},
+				Action: createBaseline,
+			},
+		},
+	}
+}
+
+// createBaseline lints the Dockerfiles like the root command does, and
+// records every reported failure instead of printing it. With
+// --fail-on-rule-error, rule errors abort it: the baseline would miss the
+// failures of the unchecked instructions.
+func createBaseline(_ context.Context, cmd *cli.Command) error {
+	base := baseline.New()
+
+	_, ruleErrs, err := runLint(cmd, func(file linted) []rule.CheckFailure {
+		for _, failure := range file.failures {
+			base.Add(toFinding(file, failure))
+		}
+
+		return file.failures
+	})
+	if err != nil {
+		return err
+	}
+
+	if ruleErrs && cmd.Bool("fail-on-rule-error") {
+		return errIncompleteBaseline
+	}
+
+	output := cmd.String("output")
+
+	//nolint:gosec // G304: writing the user-supplied baseline path is the purpose.
+	out, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create baseline: %w", err)
+	}
+	defer out.Close()
+
+	if err := base.Write(out); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	log.Info().Str("file", output).Int("findings", len(base.Findings)).Msg("Baseline created")
+
+	return nil
+}
+
+// dropBaselined filters out the failures recorded in the baseline.
+func dropBaselined(base *baseline.Baseline) fileFilter {
+	// One matcher for the whole run: each record absorbs a single failure.
+	matcher := base.Matcher()
+
+	return func(file linted) []rule.CheckFailure {
+		kept := []rule.CheckFailure{}
+
+		for _, failure := range file.failures {
+			if !matcher.Match(toFinding(file, failure)) {
+				kept = append(kept, failure)
+			}
+		}
+
+		return kept
+	}
+}
+
+func toFinding(file linted, failure rule.CheckFailure) baseline.Finding {
+	return baseline.Finding{
+		File:        file.path,
+		Code:        string(failure.Code),
+		Fingerprint: baseline.Fingerprint(file.source, file.instructions, failure.Line),
+		Line:        failure.Line,
+		Message:     failure.Message,
+	}
+}
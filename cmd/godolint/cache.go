@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/farcloser/godolint/internal/cache"
+)
+
+// openCache returns the results cache, or nil unless --cache enables it.
+// The cache is an optimization: a missing cache directory only warns.
+func openCache(cmd *cli.Command) cache.Cache {
+	if !cmd.Bool("cache") {
+		return nil
+	}
+
+	dir := cmd.String("cache-dir")
+	if dir == "" {
+		var err error
+
+		dir, err = cache.DefaultDir()
+		if err != nil {
+			log.Warn().Err(err).Msg("Results cache disabled")
+
+			return nil
+		}
+	}
+
+	log.Debug().Str("dir", dir).Msg("Using results cache")
+
+	return cache.NewDir(dir)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/farcloser/godolint/internal/changes"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/sdk"
+)
+
+// onlyChanged filters out the failures whose instruction, or stage for
+// stage-scoped rules, the diff does not touch. A file the diff does not have
+// keeps none, with a warning, as its path may not resolve to the diff's.
+func onlyChanged(set *changes.Set) fileFilter {
+	stageScoped := sdk.StageScoped()
+
+	return func(file linted) []rule.CheckFailure {
+		kept := []rule.CheckFailure{}
+
+		if !set.Has(file.path) {
+			log.Warn().Str("file", file.path).
+				Msg("file not in the --changed-lines-from diff, none of its failures are reported")
+
+			return kept
+		}
+
+		for _, failure := range file.failures {
+			if set.Touches(file.path, changes.Scope(file.instructions, failure, stageScoped[string(failure.Code)])) {
+				kept = append(kept, failure)
+			}
+		}
+
+		return kept
+	}
+}
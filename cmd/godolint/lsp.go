@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/farcloser/godolint/internal/lsp"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/sdk"
+)
+
+// lspCommand serves the Language Server Protocol over stdio, so editors get
+// diagnostics as they type. The root flags (--config, --without-shellcheck,
+// --shellcheck-rcfile, ...) apply.
+func lspCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lsp",
+		Usage: "Run a Language Server Protocol server over stdio",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			opts := lsp.Options{
+				ConfigPath:   cmd.String("config"),
+				LabelPresets: cmd.StringSlice("label-preset"),
+				RuleFamilies: cmd.StringSlice("rule-family"),
+			}
+
+			if cmd.Bool("disable-ignore-pragma") {
+				opts.LinterOptions = append(opts.LinterOptions, sdk.WithDisableIgnorePragmas(true))
+			}
+
+			if !cmd.Bool("without-shellcheck") {
+				settings, err := shellcheckSettings(cmd)
+				if err != nil {
+					return err
+				}
+
+				// Same checks and fallback as the lint command, reported once
+				// at startup rather than on every document.
+				_, native, err := shell.NewShellchecker(settings)
+				if err != nil {
+					return fmt.Errorf("cannot use shellcheck: %w", err)
+				}
+
+				if native {
+					warnNativeShellchecker(settings)
+				}
+
+				opts.LinterOptions = append(opts.LinterOptions, sdk.WithShellcheck(
+					sdk.WithShellcheckRCFile(settings.RCFile),
+					sdk.WithShellcheckSeverity(sdk.Severity(settings.Severity)),
+					sdk.WithShellcheckInclude(settings.Include...),
+					sdk.WithShellcheckExclude(settings.Exclude...),
+					sdk.WithShellcheckEnable(settings.Enable...),
+					sdk.WithShellcheckExternalSources(settings.ExternalSources),
+				))
+			}
+
+			err := lsp.NewServer(opts).Serve(ctx, os.Stdin, os.Stdout)
+			if errors.Is(err, lsp.ErrExitWithoutShutdown) {
+				os.Exit(1)
+			}
+
+			if err != nil {
+				return fmt.Errorf("language server failed: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
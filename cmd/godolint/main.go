@@ -7,86 +7,279 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"runtime"
 	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 
+	"github.com/farcloser/godolint/internal/baseline"
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/changes"
+	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/parser"
 	"github.com/farcloser/godolint/internal/process"
 	"github.com/farcloser/godolint/internal/rule"
 	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
 	"github.com/farcloser/godolint/sdk"
 )
 
 // errUsage reports an invocation without any Dockerfile argument.
 var errUsage = errors.New("at least one argument required: path to Dockerfile(s)")
 
+// loadConfig reads the --config file, or returns the default configuration
+// when none was given, along with the --ignore'd rules, the --label-preset
+// schemas, the --rule-family families and the --dockerignore patterns.
+func loadConfig(cmd *cli.Command) (*config.Config, error) {
+	cfg := config.Default()
+
+	if path := cmd.String("config"); path != "" {
+		loaded, err := config.Load(path)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // config.Load already names the file in its errors.
+		}
+
+		cfg = loaded
+	}
+
+	if err := cfg.ApplyLabelPresets(cmd.StringSlice("label-preset")...); err != nil {
+		return nil, err //nolint:wrapcheck // config.ApplyLabelPresets already names the preset in its errors.
+	}
+
+	cfg.Ignored = append(cfg.Ignored, cmd.StringSlice("ignore")...)
+
+	if err := cfg.EnableFamilies(cmd.StringSlice("rule-family")...); err != nil {
+		return nil, err //nolint:wrapcheck // config.EnableFamilies already names the family in its errors.
+	}
+
+	if path := cmd.String("dockerignore"); path != "" {
+		patterns, err := config.LoadDockerIgnore(path)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // config.LoadDockerIgnore already names the file in its errors.
+		}
+
+		cfg.DockerIgnore = patterns
+	}
+
+	return cfg, nil
+}
+
 // buildRules assembles the rule set, wiring in the shellcheck integration
-// unless it is disabled or the binary is missing from PATH.
-func buildRules(cmd *cli.Command) ([]rule.Rule, error) {
-	rules := sdk.AllRules()
+// unless it is disabled. Without the shellcheck binary on PATH, the built-in
+// analysis covers the most common SC checks. With a store, shellcheck results
+// are cached per script.
+func buildRules(cmd *cli.Command, cfg *config.Config, store cache.Cache) ([]rule.Rule, error) {
+	rules := sdk.AllRulesWithConfig(cfg)
 
 	if cmd.Bool("without-shellcheck") {
 		return rules, nil
 	}
 
-	// Missing shellcheck degrades gracefully (matching hadolint): warn and
-	// lint without the integration rather than failing the run.
-	//nolint:nilerr // intentional, see above.
-	if _, err := exec.LookPath("shellcheck"); err != nil {
-		log.Warn().Msg("shellcheck binary not found in PATH, shellcheck integration disabled")
+	settings, err := shellcheckSettings(cmd)
+	if err != nil {
+		return nil, err
+	}
 
-		return rules, nil
+	checker, native, err := shell.NewShellchecker(settings)
+	if err != nil {
+		return nil, fmt.Errorf("cannot use shellcheck: %w", err)
+	}
+
+	if native {
+		warnNativeShellchecker(settings)
+	}
+
+	if store != nil {
+		checker = shell.NewCachingShellchecker(checker, store)
+	}
+
+	return append(rules, shell.NewShellcheckRule(checker)), nil
+}
+
+// shellcheckSettings reads the --shellcheck-* flags.
+func shellcheckSettings(cmd *cli.Command) (shell.Settings, error) {
+	settings := shell.Settings{
+		RCFile:          cmd.String("shellcheck-rcfile"),
+		Severity:        cmd.String("shellcheck-severity"),
+		Include:         cmd.StringSlice("shellcheck-include"),
+		Exclude:         cmd.StringSlice("shellcheck-exclude"),
+		Enable:          cmd.StringSlice("shellcheck-enable"),
+		ExternalSources: cmd.Bool("shellcheck-external-sources"),
 	}
 
-	checker := shell.NewBinaryShellchecker()
 	// Fail fast on an unreadable rcfile: shellcheck errors are non-fatal per
 	// rule (matching hadolint), so a bad path would otherwise silently
 	// disable every SC check.
-	if rcfile := cmd.String("shellcheck-rcfile"); rcfile != "" {
-		if _, err := os.Stat(rcfile); err != nil {
-			return nil, fmt.Errorf("cannot read shellcheck rcfile: %w", err)
+	if settings.RCFile != "" {
+		if _, err := os.Stat(settings.RCFile); err != nil {
+			return settings, fmt.Errorf("cannot read shellcheck rcfile: %w", err)
 		}
+	}
+
+	return settings, nil
+}
+
+// warnNativeShellchecker tells that the built-in analysis replaces the
+// missing shellcheck binary, and which settings it ignores.
+func warnNativeShellchecker(settings shell.Settings) {
+	log.Warn().Msg("shellcheck binary not found in PATH, falling back to the built-in subset of shellcheck checks")
 
-		checker.RCFile = rcfile
+	if settings.RCFile != "" {
+		log.Warn().Str("rcfile", settings.RCFile).Msg("shellcheck rcfile ignored by the built-in checks")
 	}
 
-	return append(rules, shell.NewShellcheckRule(checker)), nil
+	if len(settings.Enable) > 0 {
+		log.Warn().Strs("enable", settings.Enable).Msg("shellcheck optional checks ignored by the built-in checks")
+	}
+
+	if settings.ExternalSources {
+		log.Warn().Msg("shellcheck external sources ignored by the built-in checks")
+	}
+}
+
+// linted is one Dockerfile after linting.
+type linted struct {
+	path         string
+	source       []byte
+	instructions []syntax.InstructionPos
+	failures     []rule.CheckFailure
+	errors       []rule.ExecError
 }
 
+// fileFilter narrows down the failures of a linted Dockerfile.
+type fileFilter func(file linted) []rule.CheckFailure
+
 // lintFiles runs the processor over each Dockerfile and returns the collected
-// failures, each tagged with the file it came from.
-func lintFiles(processor *process.Processor, paths []string) ([]rule.CheckFailure, error) {
+// failures, each tagged with the file it came from, after passing them
+// through the filters in order, and the rule execution errors, which no
+// filter applies to. Files are linted concurrently, sharing the rules (and
+// so the shellcheck worker pool); filters run sequentially, in argument
+// order, as they may be stateful.
+func lintFiles(
+	processor *process.Processor,
+	results *cache.Results,
+	paths []string,
+	filters ...fileFilter,
+) ([]rule.CheckFailure, []error, error) {
+	files := make([]linted, len(paths))
+	errs := make([]error, len(paths))
+	next := make(chan int)
+
+	var workers sync.WaitGroup
+
+	for range min(runtime.NumCPU(), len(paths)) {
+		workers.Go(func() {
+			for i := range next {
+				files[i], errs[i] = lintFile(processor, results, paths[i])
+			}
+		})
+	}
+
+	for i := range paths {
+		next <- i
+	}
+
+	close(next)
+	workers.Wait()
+
 	// Non-nil so an all-clean run still encodes as JSON [] rather than null.
 	allFailures := []rule.CheckFailure{}
 
-	for _, dockerfilePath := range paths {
-		//nolint:gosec // G304: reading user-supplied Dockerfile paths is this tool's purpose.
-		dockerfileContent, err := os.ReadFile(dockerfilePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read %s: %w", dockerfilePath, err)
+	var ruleErrs []error
+
+	for i, file := range files {
+		if errs[i] != nil {
+			return nil, nil, errs[i]
 		}
 
-		instructions, err := parser.NewBuildkitParser().Parse(dockerfileContent)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse %s: %w", dockerfilePath, err)
+		for _, filter := range filters {
+			file.failures = filter(file)
 		}
 
-		log.Debug().Str("file", dockerfilePath).Int("instructions", len(instructions)).Msg("Parsed Dockerfile")
+		allFailures = append(allFailures, file.failures...)
 
-		failures := processor.Run(instructions)
-		for i := range failures {
-			failures[i].File = dockerfilePath
+		for _, execErr := range file.errors {
+			ruleErrs = append(ruleErrs, fmt.Errorf("%s: %w", file.path, execErr))
 		}
+	}
+
+	return allFailures, ruleErrs, nil
+}
 
-		allFailures = append(allFailures, failures...)
+// lintFile reads, parses and lints one Dockerfile, or serves its failures
+// from the results cache.
+func lintFile(processor *process.Processor, results *cache.Results, dockerfilePath string) (linted, error) {
+	//nolint:gosec // G304: reading user-supplied Dockerfile paths is this tool's purpose.
+	dockerfileContent, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return linted{}, fmt.Errorf("failed to read %s: %w", dockerfilePath, err)
+	}
+
+	instructions, err := parser.NewBuildkitParser().Parse(dockerfileContent)
+	if err != nil {
+		return linted{}, fmt.Errorf("failed to parse %s: %w", dockerfilePath, err)
 	}
 
-	return allFailures, nil
+	log.Debug().Str("file", dockerfilePath).Int("instructions", len(instructions)).Msg("Parsed Dockerfile")
+
+	failures, execErrs := results.Run(processor, dockerfileContent, instructions)
+	for i := range failures {
+		failures[i].File = dockerfilePath
+	}
+
+	return linted{
+		path:         dockerfilePath,
+		source:       dockerfileContent,
+		instructions: instructions,
+		failures:     failures,
+		errors:       execErrs,
+	}, nil
+}
+
+// runLint lints the Dockerfiles given as arguments with the configured rules,
+// minus the ignored ones, then applies the filters. Rule execution errors
+// are logged; ruleErrs is true when there were any.
+func runLint(cmd *cli.Command, filters ...fileFilter) (failures []rule.CheckFailure, ruleErrs bool, err error) {
+	if cmd.Args().Len() == 0 {
+		return nil, false, errUsage
+	}
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+
+	store := openCache(cmd)
+
+	rules, err := buildRules(cmd, cfg, store)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Create processor with all rules (reuse for all files)
+	disableIgnorePragmas := cmd.Bool("disable-ignore-pragma") || cfg.DisableIgnorePragma
+	processor := process.NewProcessor(rules).WithDisableIgnorePragmas(disableIgnorePragmas)
+	results := cache.NewResults(store, cache.RulesKey(rules), cfg.Hash(), strconv.FormatBool(disableIgnorePragmas))
+
+	filters = append([]fileFilter{func(file linted) []rule.CheckFailure {
+		return dropIgnored(file.failures, cfg.Ignored)
+	}}, filters...)
+
+	failures, errs, err := lintFiles(processor, results, cmd.Args().Slice(), filters...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, ruleErr := range errs {
+		log.Error().Err(ruleErr).Msg("rule could not check an instruction, its findings are incomplete")
+	}
+
+	return failures, len(errs) > 0, nil
 }
 
 // dropIgnored filters out failures whose rule code was --ignore'd.
@@ -153,39 +346,108 @@ func main() {
 				Name:  "ignore",
 				Usage: "Rule code to ignore (can be specified multiple times, e.g., --ignore DL3006 --ignore SC2050)",
 			},
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "Configuration `FILE` in hadolint's YAML format (ignored, trustedRegistries, label-schema, ...)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "label-preset",
+				Usage: "Add a built-in label schema: `PRESET` oci or label-schema.org (repeatable, label-schema keys take precedence)",
+			},
+			&cli.StringSliceFlag{
+				Name: "rule-family",
+				Usage: "Enable a `FAMILY` of godolint's own rules (dialects, windows, layers, pinning, secrets, " +
+					"downloads, privileges, variables, images, labels) or the BuildKit checks (buildkit), " +
+					"or all of them (all); repeatable",
+			},
+			&cli.StringFlag{
+				Name:  "dockerignore",
+				Usage: "The build context's .dockerignore `FILE`, to report COPY and ADD sources it excludes (BK1015)",
+			},
+			&cli.StringFlag{
+				Name:  "baseline",
+				Usage: "Only report failures not recorded in the baseline `FILE` (see `godolint baseline create`)",
+			},
+			&cli.BoolFlag{
+				Name:  "cache",
+				Usage: "Cache lint and shellcheck results on disk, keyed on content, rules and configuration",
+			},
+			&cli.StringFlag{
+				Name:  "cache-dir",
+				Usage: "Results cache `DIR` (default: $XDG_CACHE_HOME/godolint)",
+			},
+			&cli.StringFlag{
+				Name:  "changed-lines-from",
+				Usage: "Only report failures on instructions touched by the unified diff `FILE` (- for stdin), e.g. the output of `git diff`",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-on-rule-error",
+				Usage: "Exit with code 1 when a rule could not check an instruction (e.g. shellcheck crashed or timed out), rather than only logging it",
+			},
 			&cli.StringFlag{
 				Name:  "shellcheck-rcfile",
 				Usage: "Shellcheckrc `FILE` forwarded to shellcheck (--rcfile) when validating RUN instructions (requires shellcheck >= 0.10.0)",
 			},
+			&cli.StringFlag{
+				Name:  "shellcheck-severity",
+				Usage: "Minimum `SEVERITY` reported by shellcheck: error, warning, info or style (default: style)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "shellcheck-include",
+				Usage: "Only report these shellcheck `CODES` (e.g. SC2086, repeatable or comma-separated, requires shellcheck >= 0.7.0)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "shellcheck-exclude",
+				Usage: "Never report these shellcheck `CODES` (e.g. SC2086, repeatable or comma-separated)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "shellcheck-enable",
+				Usage: "Enable optional shellcheck `CHECKS` (e.g. require-variable-braces or all, see `shellcheck --list-optional`, requires shellcheck >= 0.7.0)",
+			},
+			&cli.BoolFlag{
+				Name:  "shellcheck-external-sources",
+				Usage: "Let shellcheck follow sourced files (--external-sources); only absolute paths can be resolved",
+			},
+		},
+		Commands: []*cli.Command{
+			baselineCommand(),
+			pinCommand(),
+			lspCommand(),
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
-			if cmd.Args().Len() == 0 {
-				return errUsage
-			}
+			var filters []fileFilter
 
-			rules, err := buildRules(cmd)
-			if err != nil {
-				return err
+			if path := cmd.String("baseline"); path != "" {
+				base, err := baseline.Load(path)
+				if err != nil {
+					return err //nolint:wrapcheck // baseline.Load already names the file in its errors.
+				}
+
+				filters = append(filters, dropBaselined(base))
 			}
 
-			// Create processor with all rules (reuse for all files)
-			processor := process.NewProcessor(rules).
-				WithDisableIgnorePragmas(cmd.Bool("disable-ignore-pragma"))
+			if path := cmd.String("changed-lines-from"); path != "" {
+				set, err := changes.Load(path)
+				if err != nil {
+					return err //nolint:wrapcheck // changes.Load already names the file in its errors.
+				}
 
-			allFailures, err := lintFiles(processor, cmd.Args().Slice())
+				filters = append(filters, onlyChanged(set))
+			}
+
+			allFailures, ruleErrs, err := runLint(cmd, filters...)
 			if err != nil {
 				return err
 			}
 
-			allFailures = dropIgnored(allFailures, cmd.StringSlice("ignore"))
-
 			// Output failures as JSON
-			if err := json.NewEncoder(os.Stdout).Encode(allFailures); err != nil {
+			if err := json.NewEncoder(os.Stdout).Encode(report(allFailures)); err != nil {
 				return fmt.Errorf("failed to encode failures: %w", err)
 			}
 
-			// Exit with code 1 if any failures found
-			if len(allFailures) > 0 {
+			// Exit with code 1 if any failures found, or on rule errors
+			// when asked to
+			if len(allFailures) > 0 || (ruleErrs && cmd.Bool("fail-on-rule-error")) {
 				os.Exit(1)
 			}
 
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/sdk"
+)
+
+// reported is a failure as printed: hadolint's JSON fields, then the
+// guidelines its rule cites, for the rules citing any.
+type reported struct {
+	rule.CheckFailure
+
+	References []string `json:"references,omitempty"`
+}
+
+// report attaches to each failure the references of its rule.
+func report(failures []rule.CheckFailure) []reported {
+	references := sdk.References()
+
+	// Non-nil so an all-clean run still encodes as JSON [] rather than null.
+	output := make([]reported, 0, len(failures))
+	for _, failure := range failures {
+		output = append(output, reported{CheckFailure: failure, References: references[string(failure.Code)]})
+	}
+
+	return output
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/pin"
+)
+
+var (
+	// errDigestMismatch reports images whose digest differs from the lock's.
+	errDigestMismatch = errors.New("image digests do not match the lock")
+	// errUnpinned reports images `pin --check` would have pinned.
+	errUnpinned = errors.New("images are not pinned by digest")
+)
+
+// defaultLockFile is where `pin` reads digests unless told otherwise.
+const defaultLockFile = "godolint.lock"
+
+// pinCommand pins the images of Dockerfiles to the digests of a lock file,
+// and verifies the digests already there.
+func pinCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "pin",
+		Usage:     "Pin the images of the given Dockerfiles by digest, from a lock file",
+		ArgsUsage: "Dockerfile...",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "lock",
+				Value: defaultLockFile,
+				Usage: "Lock `FILE` mapping image references to digests, one `reference digest` per line",
+			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Do not rewrite the Dockerfiles, fail if images would be pinned",
+			},
+		},
+		Action: pinDockerfiles,
+	}
+}
+
+// pinDockerfiles rewrites each Dockerfile in place, keeping its mode. It
+// fails on digests that do not match the lock, after going through every
+// file, so that one run reports them all.
+func pinDockerfiles(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return errUsage
+	}
+
+	lock, err := image.LoadLock(cmd.String("lock"))
+	if err != nil {
+		return err //nolint:wrapcheck // image.LoadLock already names the file in its errors.
+	}
+
+	var mismatch, unpinned bool
+
+	for _, path := range cmd.Args().Slice() {
+		//nolint:gosec // G304: reading user-supplied Dockerfiles is the purpose.
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		pinned, results, err := pin.Pin(content, lock)
+		if err != nil {
+			return fmt.Errorf("failed to pin %s: %w", path, err)
+		}
+
+		for _, result := range results {
+			event := log.Info()
+
+			switch result.Status {
+			case pin.StatusPinned:
+				unpinned = true
+			case pin.StatusMismatch:
+				event = log.Error()
+				mismatch = true
+			case pin.StatusUnlocked:
+				event = log.Warn()
+			case pin.StatusVerified:
+			}
+
+			event.Str("file", path).
+				Int("line", result.Line).
+				Str("image", result.Reference).
+				Str("digest", result.Digest).
+				Msg(string(result.Status))
+		}
+
+		if cmd.Bool("check") || bytes.Equal(pinned, content) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		if err := os.WriteFile(path, pinned, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	if mismatch {
+		return errDigestMismatch
+	}
+
+	if unpinned && cmd.Bool("check") {
+		return errUnpinned
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ require (
 	github.com/moby/buildkit v0.31.1
 	github.com/rs/zerolog v1.35.1
 	github.com/urfave/cli/v3 v3.10.1
+	gopkg.in/yaml.v3 v3.0.1
 	mvdan.cc/sh/v3 v3.13.1
 )
 
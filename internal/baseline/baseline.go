@@ -0,0 +1,186 @@
+// Package baseline records known findings so that only new ones are reported,
+// letting godolint be adopted on existing Dockerfiles incrementally.
+//
+// Findings are matched on file, rule code and a fingerprint of the offending
+// instruction's text rather than on line numbers, so edits elsewhere in a
+// Dockerfile do not invalidate the baseline.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// Version is the baseline file format version written by Write.
+const Version = 1
+
+// fingerprintLength is the number of hex digits kept from the instruction
+// hash: 64 bits are plenty to tell the instructions of a Dockerfile apart.
+const fingerprintLength = 16
+
+// ErrUnsupportedVersion reports a baseline file written by an incompatible
+// version of the format.
+var ErrUnsupportedVersion = errors.New("unsupported baseline version")
+
+// Finding is a recorded failure.
+type Finding struct {
+	File        string `json:"file"`
+	Code        string `json:"code"`
+	Fingerprint string `json:"fingerprint"`
+	// Line and Message are informational only: they help reviewing the
+	// baseline, but play no part in matching.
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// key identifies the findings a baseline entry matches.
+type key struct {
+	file        string
+	code        string
+	fingerprint string
+}
+
+func (f Finding) key() key {
+	return key{file: NormalizePath(f.File), code: f.Code, fingerprint: f.Fingerprint}
+}
+
+// Baseline is a set of recorded findings.
+type Baseline struct {
+	Version  int       `json:"version"`
+	Findings []Finding `json:"findings"`
+}
+
+// New creates an empty baseline.
+func New() *Baseline {
+	return &Baseline{
+		Version:  Version,
+		Findings: []Finding{},
+	}
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	//nolint:gosec // G304: reading a user-supplied baseline path is the purpose.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open baseline: %w", err)
+	}
+	defer file.Close()
+
+	base, err := Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+
+	return base, nil
+}
+
+// Read decodes a baseline.
+func Read(reader io.Reader) (*Baseline, error) {
+	base := &Baseline{}
+	if err := json.NewDecoder(reader).Decode(base); err != nil {
+		return nil, fmt.Errorf("failed to decode baseline: %w", err)
+	}
+
+	if base.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, base.Version)
+	}
+
+	return base, nil
+}
+
+// Write encodes the baseline as indented JSON, friendly to code review.
+func (b *Baseline) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(b); err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Add records a finding.
+func (b *Baseline) Add(finding Finding) {
+	finding.File = NormalizePath(finding.File)
+	b.Findings = append(b.Findings, finding)
+}
+
+// Matcher tells recorded findings from new ones. Each recorded finding
+// absorbs a single match: if an instruction gains a second identical
+// finding, the extra one is new.
+type Matcher struct {
+	remaining map[key]int
+}
+
+// Matcher returns a fresh matcher over the baseline's findings.
+func (b *Baseline) Matcher() *Matcher {
+	remaining := make(map[key]int)
+	for _, finding := range b.Findings {
+		remaining[finding.key()]++
+	}
+
+	return &Matcher{remaining: remaining}
+}
+
+// Match reports whether the finding is recorded, consuming the record.
+func (m *Matcher) Match(finding Finding) bool {
+	findingKey := finding.key()
+	if m.remaining[findingKey] == 0 {
+		return false
+	}
+
+	m.remaining[findingKey]--
+
+	return true
+}
+
+// NormalizePath makes file keys comparable across platforms and spellings
+// (./Dockerfile vs Dockerfile).
+func NormalizePath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// Fingerprint identifies the instruction covering line in the Dockerfile
+// source, by hashing its text with whitespace normalized. File-level
+// findings (line 0) share the fingerprint of the empty text.
+func Fingerprint(source []byte, instructions []syntax.InstructionPos, line int) string {
+	lines := strings.Split(string(source), "\n")
+	start, end := line, line
+
+	for _, instr := range instructions {
+		if _, isComment := instr.Instruction.(*syntax.Comment); isComment {
+			continue
+		}
+
+		if first, last := instr.Span(); first <= line && line <= last {
+			start, end = first, last
+
+			break
+		}
+	}
+
+	var text string
+	if start >= 1 && start <= len(lines) {
+		text = strings.Join(lines[start-1:min(end, len(lines))], "\n")
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
@@ -0,0 +1,82 @@
+package baseline_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/baseline"
+	"github.com/farcloser/godolint/internal/parser"
+)
+
+func fingerprint(t *testing.T, dockerfile string, line int) string {
+	t.Helper()
+
+	instructions, err := parser.NewBuildkitParser().Parse([]byte(dockerfile))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	return baseline.Fingerprint([]byte(dockerfile), instructions, line)
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	original := "FROM debian:12\nRUN apt-get update && \\\n    apt-get install -y curl\n"
+	shifted := "# comment\nFROM debian:12\n\nRUN apt-get update &&  \\\n  apt-get install -y curl\n"
+
+	// Any line of a multi-line instruction identifies the whole instruction,
+	// regardless of its position and indentation.
+	if fingerprint(t, original, 2) != fingerprint(t, shifted, 5) {
+		t.Error("fingerprint changed with the instruction's position or indentation")
+	}
+
+	if fingerprint(t, original, 2) != fingerprint(t, original, 3) {
+		t.Error("continuation lines do not share the instruction's fingerprint")
+	}
+
+	edited := "FROM debian:12\nRUN apt-get update && \\\n    apt-get install -y wget\n"
+	if fingerprint(t, original, 2) == fingerprint(t, edited, 2) {
+		t.Error("fingerprint unchanged although the instruction changed")
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	base := baseline.New()
+	base.Add(baseline.Finding{File: "./Dockerfile", Code: "DL3008", Fingerprint: "abc", Line: 2})
+
+	var buf bytes.Buffer
+	if err := base.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	loaded, err := baseline.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	matcher := loaded.Matcher()
+	finding := baseline.Finding{File: "Dockerfile", Code: "DL3008", Fingerprint: "abc", Line: 40}
+
+	if !matcher.Match(finding) {
+		t.Error("recorded finding at a different line did not match")
+	}
+
+	if matcher.Match(finding) {
+		t.Error("a single record absorbed two findings")
+	}
+
+	if loaded.Matcher().Match(baseline.Finding{File: "Dockerfile", Code: "DL3009", Fingerprint: "abc"}) {
+		t.Error("finding with another rule code matched")
+	}
+}
+
+func TestRead_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	if _, err := baseline.Read(bytes.NewBufferString(`{"version": 99, "findings": []}`)); err == nil {
+		t.Error("Read() error = nil, want unsupported version")
+	}
+}
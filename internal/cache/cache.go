@@ -0,0 +1,165 @@
+// Package cache stores lint and shellcheck results on disk, keyed on the
+// hash of everything that determines them, so that re-linting an unchanged
+// Dockerfile costs a lookup.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+// formatVersion is bumped whenever the layout of cached values changes.
+const formatVersion = "1"
+
+// Cache is a content-addressed store. Implementations must be safe for
+// concurrent use. A cache is best-effort: failing to store a value only
+// costs recomputing it.
+type Cache interface {
+	// Get returns the value stored under key, if any.
+	Get(key string) ([]byte, bool)
+	// Put stores value under key.
+	Put(key string, value []byte) error
+}
+
+// Key hashes its parts into a cache key. Parts are length-prefixed, so that
+// ("ab", "c") and ("a", "bc") differ.
+func Key(parts ...string) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, formatVersion)
+
+	for _, part := range parts {
+		_ = binary.Write(hash, binary.LittleEndian, uint64(len(part)))
+		_, _ = io.WriteString(hash, part)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// RulesKey identifies a rule set by its codes, plus the identity of the
+// rules implementing `Identity() string` (the shellcheck rule reports its
+// checker's version and rcfile). The configuration of configurable rules is
+// not visible here: callers add it to their keys.
+func RulesKey(rules []rule.Rule) string {
+	var key strings.Builder
+
+	for _, current := range rules {
+		_, _ = key.WriteString(string(current.Code()))
+
+		if identifier, ok := current.(interface{ Identity() string }); ok {
+			_, _ = key.WriteString(" " + identifier.Identity())
+		}
+
+		_, _ = key.WriteString("\n")
+	}
+
+	return key.String()
+}
+
+// Dir is a Cache storing each value in its own file under a directory.
+type Dir struct {
+	dir string
+}
+
+// NewDir creates a cache under dir, which is created on first write.
+func NewDir(dir string) *Dir {
+	return &Dir{dir: dir}
+}
+
+// DefaultDir returns godolint's directory in the user cache directory:
+// $XDG_CACHE_HOME/godolint, or the platform's equivalent.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user cache directory: %w", err)
+	}
+
+	return filepath.Join(base, "godolint"), nil
+}
+
+// path spreads entries over subdirectories named after the key's first two
+// digits, keeping directories small.
+func (d *Dir) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(d.dir, key)
+	}
+
+	return filepath.Join(d.dir, key[:2], key)
+}
+
+// Get reads the value stored under key.
+func (d *Dir) Get(key string) ([]byte, bool) {
+	value, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Put writes the value under key atomically: concurrent runs sharing the
+// directory never observe a partial entry.
+func (d *Dir) Put(key string, value []byte) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.Write(value); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // computed once per process, see BuildID.
+var buildID = sync.OnceValue(func() string {
+	// The executable's hash changes with any change to the rules, including
+	// in development builds, whose module version is always (devel).
+	if executable, err := os.Executable(); err == nil {
+		if file, err := os.Open(executable); err == nil {
+			defer file.Close()
+
+			hash := sha256.New()
+			if _, err := io.Copy(hash, file); err == nil {
+				return hex.EncodeToString(hash.Sum(nil))
+			}
+		}
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.String()
+	}
+
+	return "unknown"
+})
+
+// BuildID identifies the running build, so that upgrading godolint (or any
+// program embedding it) invalidates the cached results.
+func BuildID() string {
+	return buildID()
+}
@@ -0,0 +1,135 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/parser"
+	"github.com/farcloser/godolint/internal/process"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	if cache.Key("ab", "c") == cache.Key("a", "bc") {
+		t.Error("Key() does not separate its parts")
+	}
+
+	if cache.Key("a", "b") != cache.Key("a", "b") {
+		t.Error("Key() is not deterministic")
+	}
+}
+
+func TestDir(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewDir(t.TempDir())
+	key := cache.Key("entry")
+
+	if _, ok := store.Get(key); ok {
+		t.Fatal("Get() hit on an empty cache")
+	}
+
+	if err := store.Put(key, []byte("value")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if value, ok := store.Get(key); !ok || string(value) != "value" {
+		t.Errorf("Get() = %q, %v, want the stored value", value, ok)
+	}
+}
+
+func TestResults(t *testing.T) {
+	t.Parallel()
+
+	runs := 0
+	counting := rule.NewSimpleRule("DL9999", rule.Warning, "counted",
+		func(syntax.Instruction) bool {
+			runs++
+
+			return false
+		})
+	processor := process.NewProcessor([]rule.Rule{counting})
+	store := cache.NewDir(t.TempDir())
+
+	content := []byte("FROM debian:12\n")
+
+	instructions, err := parser.NewBuildkitParser().Parse(content)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	first, _ := cache.NewResults(store, "setup").Run(processor, content, instructions)
+	second, _ := cache.NewResults(store, "setup").Run(processor, content, instructions)
+
+	if len(first) != 1 || len(second) != 1 || first[0] != second[0] {
+		t.Fatalf("cached failures = %+v, want %+v", second, first)
+	}
+
+	if runs != 1 {
+		t.Errorf("rules ran %d times, want 1", runs)
+	}
+
+	cache.NewResults(store, "other setup").Run(processor, content, instructions)
+
+	if runs != 2 {
+		t.Error("results served across different setups")
+	}
+
+	var uncached *cache.Results
+	uncached.Run(processor, content, instructions)
+
+	if runs != 3 {
+		t.Error("nil Results did not run the processor")
+	}
+}
+
+var errCrashed = errors.New("checker crashed")
+
+// failingRule cannot check any instruction.
+type failingRule struct {
+	runs *int
+}
+
+func (failingRule) Code() rule.Code         { return "DL9998" }
+func (failingRule) Severity() rule.Severity { return rule.Warning }
+func (failingRule) Message() string         { return "failing" }
+func (failingRule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+func (r failingRule) Check(line int, state rule.State, _ syntax.Instruction) rule.State {
+	*r.runs++
+
+	return state.AddError(line, errCrashed)
+}
+
+func (failingRule) Finalize(state rule.State) rule.State { return state }
+
+func TestResults_ErrorsNotCached(t *testing.T) {
+	t.Parallel()
+
+	runs := 0
+	processor := process.NewProcessor([]rule.Rule{failingRule{runs: &runs}})
+	store := cache.NewDir(t.TempDir())
+	content := []byte("FROM debian:12\n")
+
+	instructions, err := parser.NewBuildkitParser().Parse(content)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	for range 2 {
+		_, errs := cache.NewResults(store, "setup").Run(processor, content, instructions)
+		if len(errs) != 1 || errs[0].Code != "DL9998" || errs[0].Line != 1 {
+			t.Fatalf("Run() errors = %+v, want one DL9998 error on line 1", errs)
+		}
+	}
+
+	if runs != 2 {
+		t.Errorf("rules ran %d times, want 2: a run with errors must not be cached", runs)
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"encoding/json"
+
+	"github.com/farcloser/godolint/internal/process"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// Results serves the failures of Dockerfiles linted before with the same
+// setup. A nil Results always runs the processor.
+type Results struct {
+	store Cache
+	// prefix keys everything but the Dockerfile content.
+	prefix string
+}
+
+// NewResults keys results on the build, plus the parts describing the lint
+// setup: the rule set (see RulesKey), the configuration, the pragma
+// setting... It returns nil without a store.
+func NewResults(store Cache, setup ...string) *Results {
+	if store == nil {
+		return nil
+	}
+
+	return &Results{
+		store:  store,
+		prefix: Key(append([]string{"lint", BuildID()}, setup...)...),
+	}
+}
+
+// Run returns the cached failures for the Dockerfile content, or runs the
+// processor over its instructions and caches the failures. A run with
+// execution errors is not cached: its failures are incomplete, and the
+// next run may succeed.
+func (r *Results) Run(
+	processor *process.Processor,
+	content []byte,
+	instructions []syntax.InstructionPos,
+) ([]rule.CheckFailure, []rule.ExecError) {
+	if r == nil {
+		return processor.RunWithErrors(instructions)
+	}
+
+	key := Key(r.prefix, string(content))
+
+	failures := []rule.CheckFailure{}
+	if cached, ok := r.store.Get(key); ok && json.Unmarshal(cached, &failures) == nil {
+		return failures, nil
+	}
+
+	failures, errs := processor.RunWithErrors(instructions)
+	if len(errs) > 0 {
+		return failures, errs
+	}
+
+	if encoded, err := json.Marshal(failures); err == nil {
+		// Best effort: a failed write only costs a later rerun.
+		_ = r.store.Put(key, encoded)
+	}
+
+	return failures, nil
+}
@@ -0,0 +1,342 @@
+// Package changes restricts failures to the parts of Dockerfiles touched by a
+// unified diff, so that pull request pipelines only fail on the findings a
+// change introduces.
+//
+// A failure counts as changed when the diff touches its scope: the
+// instruction it is reported on, the whole stage for stage-level rules, or
+// the whole file for findings without a line.
+package changes
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// ErrMalformedHunk reports a hunk header that cannot be parsed.
+var ErrMalformedHunk = errors.New("malformed hunk header")
+
+// hunkHeader matches `@@ -a[,b] +c[,d] @@`, capturing b, c and d.
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// fileChanges holds the changes to one file, in new-file line numbers.
+type fileChanges struct {
+	// lines are added or modified lines.
+	lines map[int]bool
+	// gaps are deletions: a gap n means lines were removed between lines n
+	// and n+1.
+	gaps map[int]bool
+}
+
+// touches reports whether the changes affect the span.
+func (c *fileChanges) touches(span Span) bool {
+	for line := range c.lines {
+		if span.Start <= line && line <= span.End {
+			return true
+		}
+	}
+
+	for gap := range c.gaps {
+		// Removing lines right after an instruction leaves it untouched,
+		// whereas removing the last instructions of a stage changes it.
+		if span.Start <= gap && (gap < span.End || span.Stage && gap == span.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Span is the lines of a Dockerfile a failure is about.
+type Span struct {
+	Start int
+	End   int
+	// Stage marks the span of a whole stage.
+	Stage bool
+}
+
+// Set is the changes of a diff, by file.
+type Set struct {
+	files map[string]*fileChanges
+	// root is the directory the paths of the diff are relative to; empty
+	// for the working directory.
+	root string
+}
+
+// Load reads a unified diff file, or standard input for "-". Its paths are
+// relative to Root.
+func Load(path string) (*Set, error) {
+	if path == "-" {
+		set, err := Parse(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+
+		return set.WithRoot(Root(path)), nil
+	}
+
+	//nolint:gosec // G304: reading a user-supplied diff path is the purpose.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open diff: %w", err)
+	}
+	defer file.Close()
+
+	set, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid diff %s: %w", path, err)
+	}
+
+	return set.WithRoot(Root(path)), nil
+}
+
+// Root returns the directory the paths of a diff are relative to: the top
+// level of the git repository of the working directory, as `git diff`
+// writes them, else the directory of the diff file, or the working
+// directory for standard input ("-").
+func Root(diffPath string) string {
+	if out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output(); err == nil {
+		if top := strings.TrimSpace(string(out)); top != "" {
+			return top
+		}
+	}
+
+	if diffPath == "-" {
+		return ""
+	}
+
+	return filepath.Dir(diffPath)
+}
+
+// WithRoot sets the directory the paths of the diff are relative to.
+func (s *Set) WithRoot(root string) *Set {
+	s.root = root
+
+	return s
+}
+
+// Parse reads a unified diff, as produced by `git diff` or `diff -u`.
+// Only the new side of each file is recorded: deleted files have nothing
+// left to lint.
+func Parse(reader io.Reader) (*Set, error) {
+	set := &Set{files: make(map[string]*fileChanges)}
+
+	var (
+		current *fileChanges
+		// next is the new-file number of the next line in the hunk.
+		next int
+		// oldLeft and newLeft count the hunk lines still expected on each
+		// side; the hunk ends when both reach zero.
+		oldLeft, newLeft int
+	)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, math.MaxInt32)
+
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				current.lines[next] = true
+				next++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				current.gaps[next-1] = true
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// "\ No newline at end of file".
+			default:
+				// Context line; some tools strip the space of empty ones.
+				next++
+				oldLeft--
+				newLeft--
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			current = set.file(newFileName(text))
+		case strings.HasPrefix(text, "@@"):
+			match := hunkHeader.FindStringSubmatch(text)
+			if match == nil || current == nil {
+				return nil, fmt.Errorf("%w: %q", ErrMalformedHunk, text)
+			}
+
+			oldLeft = count(match[1])
+			next, _ = strconv.Atoi(match[2])
+			newLeft = count(match[3])
+
+			// An empty new side is numbered after the line preceding it.
+			if newLeft == 0 {
+				next++
+			}
+		default:
+			// File headers and other preamble.
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diff: %w", err)
+	}
+
+	return set, nil
+}
+
+// count parses an optional hunk line count, which defaults to 1.
+func count(text string) int {
+	if text == "" {
+		return 1
+	}
+
+	n, _ := strconv.Atoi(text)
+
+	return n
+}
+
+func (s *Set) file(name string) *fileChanges {
+	if name == "" {
+		// Deleted file: its hunks are parsed, then discarded.
+		return &fileChanges{lines: make(map[int]bool), gaps: make(map[int]bool)}
+	}
+
+	changes, ok := s.files[name]
+	if !ok {
+		changes = &fileChanges{lines: make(map[int]bool), gaps: make(map[int]bool)}
+		s.files[name] = changes
+	}
+
+	return changes
+}
+
+// newFileName extracts the path from a `+++ b/path` header, or returns
+// empty for a deleted file.
+func newFileName(header string) string {
+	name := strings.TrimPrefix(header, "+++ ")
+	// diff -u appends a tab and the modification time.
+	name, _, _ = strings.Cut(name, "\t")
+
+	if name == "/dev/null" {
+		return ""
+	}
+
+	name = strings.TrimPrefix(name, "b/")
+
+	return normalize(name)
+}
+
+func normalize(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// lookup finds the changes of a linted file. Diff paths are relative to the
+// root while linted paths are relative to the working directory, which may
+// be anywhere below it, or absolute: both are resolved to whole paths, so
+// that a file outside the root, or sharing the name of a changed file
+// elsewhere, matches nothing.
+func (s *Set) lookup(path string) *fileChanges {
+	rel, err := filepath.Rel(resolve(s.root), resolve(path))
+	if err != nil {
+		return nil
+	}
+
+	rel = normalize(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return nil
+	}
+
+	return s.files[rel]
+}
+
+// resolve returns the absolute path, following symbolic links where the
+// path exists so that both sides of lookup agree.
+func resolve(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real
+	}
+
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs))
+	}
+
+	return abs
+}
+
+// Has reports whether the diff adds or modifies the file. The failures of a
+// file it does not have are never reported as changed.
+func (s *Set) Has(path string) bool {
+	return s.lookup(path) != nil
+}
+
+// Touches reports whether the diff changes the span of the file.
+func (s *Set) Touches(path string, span Span) bool {
+	changes := s.lookup(path)
+
+	return changes != nil && changes.touches(span)
+}
+
+// Scope returns the span a failure is about: the instruction spanning its
+// line, the enclosing stage when its rule is stage-scoped (see
+// rule.StageScoped), or the whole file for findings reported on line 0.
+func Scope(instructions []syntax.InstructionPos, failure rule.CheckFailure, stageScoped bool) Span {
+	if failure.Line <= 0 {
+		return Span{Start: 0, End: math.MaxInt, Stage: true}
+	}
+
+	if stageScoped {
+		return stageSpan(instructions, failure.Line)
+	}
+
+	for _, instr := range instructions {
+		if _, isComment := instr.Instruction.(*syntax.Comment); isComment {
+			continue
+		}
+
+		if first, last := instr.Span(); first <= failure.Line && failure.Line <= last {
+			return Span{Start: first, End: last, Stage: false}
+		}
+	}
+
+	return Span{Start: failure.Line, End: failure.Line, Stage: false}
+}
+
+// stageSpan returns the stage containing line: from its FROM to the line
+// before the next FROM, or the end of the file.
+func stageSpan(instructions []syntax.InstructionPos, line int) Span {
+	span := Span{Start: 1, End: math.MaxInt, Stage: true}
+
+	for _, instr := range instructions {
+		if _, isFrom := instr.Instruction.(*syntax.From); !isFrom {
+			continue
+		}
+
+		if instr.LineNumber <= line {
+			span.Start = instr.LineNumber
+		} else {
+			span.End = instr.LineNumber - 1
+
+			break
+		}
+	}
+
+	return span
+}
@@ -0,0 +1,157 @@
+package changes_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/changes"
+	"github.com/farcloser/godolint/internal/parser"
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+const dockerfile = `FROM debian:12 AS build
+RUN apt-get update && \
+    apt-get install -y curl
+WORKDIR /src
+
+FROM debian:12
+COPY --from=build /src /src
+`
+
+// diff edits the continuation of the RUN (line 3) and removes an instruction
+// after the final COPY (line 7).
+const diff = `diff --git a/docker/Dockerfile b/docker/Dockerfile
+index 1111111..2222222 100644
+--- a/docker/Dockerfile
++++ b/docker/Dockerfile
+@@ -1,4 +1,4 @@
+ FROM debian:12 AS build
+ RUN apt-get update && \
+-    apt-get install -y wget
++    apt-get install -y curl
+ WORKDIR /src
+@@ -7,2 +7,1 @@ FROM debian:12
+ COPY --from=build /src /src
+-HEALTHCHECK CMD true
+diff --git a/old/Dockerfile b/old/Dockerfile
+deleted file mode 100644
+--- a/old/Dockerfile
++++ /dev/null
+@@ -1 +0,0 @@
+-FROM scratch
+`
+
+func TestTouches(t *testing.T) {
+	t.Parallel()
+
+	set, err := changes.Parse(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := filepath.FromSlash("/repo")
+	set = set.WithRoot(root)
+
+	instructions, err := parser.NewBuildkitParser().Parse([]byte(dockerfile))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		failure rule.CheckFailure
+		stage   bool
+		want    bool
+	}{
+		{"changed continuation line", "docker/Dockerfile", rule.CheckFailure{Code: "DL3008", Line: 2}, false, true},
+		{"untouched instruction", "docker/Dockerfile", rule.CheckFailure{Code: "DL3000", Line: 4}, false, false},
+		{"lines removed after the instruction", "docker/Dockerfile", rule.CheckFailure{Code: "DL3022", Line: 7}, false, false},
+		{"stage with a removed instruction", "docker/Dockerfile", rule.CheckFailure{Code: "DL3057", Line: 6}, true, true},
+		{"stage-level finding in a changed stage", "docker/Dockerfile", rule.CheckFailure{Code: "DL3057", Line: 1}, true, true},
+		{"file-level finding", "docker/Dockerfile", rule.CheckFailure{Code: "DL3049", Line: 0}, false, true},
+		{"same name in another directory", "Dockerfile", rule.CheckFailure{Code: "DL3008", Line: 2}, false, false},
+		{"file absent from the diff", "other/Dockerfile.dev", rule.CheckFailure{Code: "DL3008", Line: 2}, false, false},
+		{"deleted file", "old/Dockerfile", rule.CheckFailure{Code: "DL3006", Line: 0}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(root, filepath.FromSlash(tt.path))
+			if got := set.Touches(path, changes.Scope(instructions, tt.failure, tt.stage)); got != tt.want {
+				t.Errorf("Touches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHas(t *testing.T) {
+	t.Parallel()
+
+	set, err := changes.Parse(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := filepath.FromSlash("/repo")
+	set = set.WithRoot(root)
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"absolute path", filepath.Join(root, "docker", "Dockerfile"), true},
+		{"unnormalized absolute path", filepath.Join(root, "docker", "..", "docker", "Dockerfile"), true},
+		{"unchanged file sharing the name", filepath.Join(root, "Dockerfile"), false},
+		{"outside the root", filepath.FromSlash("/elsewhere/docker/Dockerfile"), false},
+		{"relative to another working directory", "docker/Dockerfile", false},
+		{"deleted file", filepath.Join(root, "old", "Dockerfile"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := set.Has(tt.path); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// INTENTION: a path relative to the working directory resolves against it,
+// as it does for the linter.
+func TestHas_RelativePath(t *testing.T) {
+	t.Parallel()
+
+	set, err := changes.Parse(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !set.WithRoot(wd).Has("./docker/Dockerfile") {
+		t.Error("Has() = false for a path relative to the root, want true")
+	}
+
+	if set.WithRoot(filepath.Dir(wd)).Has("docker/Dockerfile") {
+		t.Error("Has() = true for a path relative to a subdirectory of the root, want false")
+	}
+}
+
+func TestParse_MalformedHunk(t *testing.T) {
+	t.Parallel()
+
+	if _, err := changes.Parse(strings.NewReader("+++ b/Dockerfile\n@@ bogus @@\n")); err == nil {
+		t.Error("Parse() error = nil, want malformed hunk")
+	}
+}
@@ -1,6 +1,14 @@
 // Package config defines configuration types and defaults for godolint.
 package config
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/farcloser/godolint/internal/image"
+)
+
 // LabelType defines the validation type for a label.
 type LabelType string
 
@@ -29,6 +37,81 @@ type Config struct {
 	// StrictLabels when true, only labels in the schema are allowed.
 	// When false, any labels are allowed (but schema labels are still validated).
 	StrictLabels bool
+
+	// Ignored lists rule codes whose failures are never reported.
+	Ignored []string
+
+	// DisableIgnorePragma when true, inline `# hadolint ignore=` pragmas are
+	// not honored.
+	DisableIgnorePragma bool
+
+	// Secrets extends the heuristics of the secret detection rules.
+	Secrets Secrets
+
+	// DockerIgnore holds the patterns of the build context's .dockerignore,
+	// as read by LoadDockerIgnore, for the rules reporting ignored sources.
+	DockerIgnore []string
+
+	// Images is the base image policy.
+	Images ImagePolicy
+
+	// Families lists the enabled families of godolint's own rules and of
+	// the BuildKit checks, which hadolint's rules run without.
+	Families []RuleFamily
+}
+
+// ImagePolicy configures the base image policy rules (GD8xxx). Image globs
+// match the image name as written (python, ghcr.io/acme/app) or in full
+// (docker.io/library/python), and its tag too when they name one
+// (python:3.7*): * and ? stay within a path component, ** crosses them.
+type ImagePolicy struct {
+	// Allow lists the images FROM may use; empty allows all.
+	Allow []string
+
+	// Deny lists the images FROM may not use, allowed or not.
+	Deny []string
+
+	// RequireDigest lists the registries, as globs (ghcr.io,
+	// *.dkr.ecr.*.amazonaws.com), whose images must be pinned by digest.
+	// Docker Hub is docker.io.
+	RequireDigest []string
+
+	// TagPatterns maps image globs to a regular expression the tags of the
+	// matching images must match.
+	TagPatterns map[string]string
+
+	// EOL extends the built-in end-of-life table of base images, by image
+	// name; a cycle it lists replaces the built-in one.
+	EOL map[string][]EOLCycle
+
+	// AllowUnpinned lists the images that may be used without a digest.
+	AllowUnpinned []string
+
+	// Lock maps tagged image references to the digests they must be pinned
+	// to, as read by image.LoadLock.
+	Lock image.Lock
+}
+
+// Secrets configures the secret detection rules (GD4xxx), on top of their
+// built-in heuristics. Expressions use Go's regexp syntax and globs
+// path.Match's; Parse rejects invalid ones, rules skip them.
+type Secrets struct {
+	// Keys are regular expressions matching more ARG and ENV names that hold
+	// secrets.
+	Keys []string
+
+	// AllowedKeys are regular expressions matching names never considered
+	// secrets, such as a project's SIGNING_KEY_ID.
+	AllowedKeys []string
+
+	// Patterns are regular expressions matching more secret values, such as
+	// an internal token format.
+	Patterns []string
+
+	// Files are globs matching more secret files among COPY and ADD sources:
+	// a glob without a slash matches the base name, one with slashes the
+	// trailing path elements (".aws/credentials").
+	Files []string
 }
 
 // Default returns a default configuration (all rules permissive).
@@ -37,5 +120,16 @@ func Default() *Config {
 		AllowedRegistries: []string{}, // Empty = all allowed
 		LabelSchema:       make(map[string]LabelType),
 		StrictLabels:      false,
+		Ignored:           []string{},
 	}
 }
+
+// Hash identifies the configuration, e.g. to key cached lint results on it.
+func (c *Config) Hash() string {
+	// Marshaling plain data cannot fail; map keys are sorted, so equal
+	// configurations hash equally.
+	encoded, _ := json.Marshal(c)
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:])
+}
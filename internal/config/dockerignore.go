@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadDockerIgnore reads the patterns of a .dockerignore file, skipping
+// blank lines and comments.
+func LoadDockerIgnore(path string) ([]string, error) {
+	//nolint:gosec // G304: reading a user-supplied .dockerignore path is the purpose.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dockerignore %s: %w", path, err)
+	}
+
+	return ParseDockerIgnore(content), nil
+}
+
+// ParseDockerIgnore returns the patterns of .dockerignore content.
+func ParseDockerIgnore(content []byte) []string {
+	var patterns []string
+
+	for line := range strings.SplitSeq(string(content), "\n") {
+		pattern := strings.TrimSpace(line)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
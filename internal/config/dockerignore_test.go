@@ -0,0 +1,19 @@
+package config_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+)
+
+func TestParseDockerIgnore(t *testing.T) {
+	t.Parallel()
+
+	got := config.ParseDockerIgnore([]byte("# build outputs\ndist\n\n  *.log  \n!keep.log\r\n"))
+
+	want := []string{"dist", "*.log", "!keep.log"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseDockerIgnore() = %q, want %q", got, want)
+	}
+}
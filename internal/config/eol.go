@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EOLCycle is a release cycle of a base image, and the day its support
+// ends.
+type EOLCycle struct {
+	// Cycle is the version of the release cycle, matched against tags as a
+	// prefix ending at a dot or dash: 3.7 matches 3.7, 3.7.17 and 3.7-slim.
+	Cycle string
+
+	// Aliases are other tag prefixes naming the cycle, such as the bionic
+	// codename of ubuntu 18.04.
+	Aliases []string
+
+	// EOL is the end of life day, past which the cycle gets no fixes.
+	EOL time.Time
+}
+
+// fileEOLCycle mirrors a release cycle of an end-of-life file.
+type fileEOLCycle struct {
+	Cycle   string   `yaml:"cycle"`
+	Aliases []string `yaml:"aliases"`
+	EOL     string   `yaml:"eol"`
+}
+
+// LoadEOL reads an end-of-life file, mapping image names to their release
+// cycles:
+//
+//	python:
+//	  - cycle: "3.7"
+//	    eol: 2023-06-27
+//	ubuntu:
+//	  - cycle: "18.04"
+//	    aliases: [bionic]
+//	    eol: 2023-05-31
+func LoadEOL(path string) (map[string][]EOLCycle, error) {
+	//nolint:gosec // G304: reading a user-supplied end-of-life file is the purpose.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eol file %s: %w", path, err)
+	}
+
+	table, err := ParseEOL(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eol file %s: %w", path, err)
+	}
+
+	return table, nil
+}
+
+// ParseEOL decodes end-of-life file content.
+func ParseEOL(content []byte) (map[string][]EOLCycle, error) {
+	var raw map[string][]fileEOLCycle
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode eol table: %w", err)
+	}
+
+	table := make(map[string][]EOLCycle, len(raw))
+
+	for image, cycles := range raw {
+		for _, cycle := range cycles {
+			eol, err := time.Parse(time.DateOnly, cycle.EOL)
+			if err != nil || cycle.Cycle == "" {
+				return nil, fmt.Errorf("%w: %s %q: want a cycle and an eol day (YYYY-MM-DD)",
+					ErrInvalidImagePolicy, image, cycle.Cycle)
+			}
+
+			table[image] = append(table[image], EOLCycle{Cycle: cycle.Cycle, Aliases: cycle.Aliases, EOL: eol})
+		}
+	}
+
+	return table, nil
+}
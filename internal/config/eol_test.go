@@ -0,0 +1,38 @@
+package config_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/farcloser/godolint/internal/config"
+)
+
+func TestParseEOL(t *testing.T) {
+	t.Parallel()
+
+	table, err := config.ParseEOL([]byte(`
+ubuntu:
+  - cycle: "18.04"
+    aliases: [bionic]
+    eol: 2023-05-31
+  - cycle: "20.04"
+    eol: "2025-05-31"
+`))
+	if err != nil {
+		t.Fatalf("ParseEOL() error = %v", err)
+	}
+
+	cycles := table["ubuntu"]
+	if len(cycles) != 2 || cycles[0].Cycle != "18.04" || !slices.Equal(cycles[0].Aliases, []string{"bionic"}) ||
+		!cycles[1].EOL.Equal(time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseEOL() = %+v", table)
+	}
+
+	for _, content := range []string{"python:\n  - cycle: '3.7'\n", "python:\n  - eol: 2023-06-27\n"} {
+		if _, err := config.ParseEOL([]byte(content)); !errors.Is(err, config.ErrInvalidImagePolicy) {
+			t.Errorf("ParseEOL(%q) error = %v, want ErrInvalidImagePolicy", content, err)
+		}
+	}
+}
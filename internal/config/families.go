@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrUnknownRuleFamily reports a rule family godolint does not have.
+var ErrUnknownRuleFamily = errors.New("unknown rule family")
+
+// RuleFamily names a group of godolint's own rules, or the ports of
+// BuildKit's checks. godolint's own rules use the GD prefix (GD####), as they
+// are not part of hadolint; the BK rules (BK####) port BuildKit's built-in
+// checks, with their descriptions. Unlike hadolint's rules, they only run
+// once their family is enabled, so that godolint reports what hadolint does
+// unless asked for more.
+type RuleFamily string
+
+// Rule families, by the rules they enable.
+const (
+	// FamilyDialects enables GD1xxx, the shell dialect rules.
+	FamilyDialects RuleFamily = "dialects"
+	// FamilyWindows enables GD11xx, the PowerShell and Chocolatey rules.
+	FamilyWindows RuleFamily = "windows"
+	// FamilyLayers enables GD2xxx and GD21xx, the layer and image size rules.
+	FamilyLayers RuleFamily = "layers"
+	// FamilyPinning enables GD3xxx, the version pinning rules.
+	FamilyPinning RuleFamily = "pinning"
+	// FamilySecrets enables GD4xxx, the secret detection rules.
+	FamilySecrets RuleFamily = "secrets"
+	// FamilyDownloads enables GD5xxx, the remote code and download rules.
+	FamilyDownloads RuleFamily = "downloads"
+	// FamilyPrivileges enables GD6xxx, the privilege hardening rules.
+	FamilyPrivileges RuleFamily = "privileges"
+	// FamilyVariables enables GD7xxx, the variable scope rules.
+	FamilyVariables RuleFamily = "variables"
+	// FamilyImages enables GD8xxx, the base image policy rules. Configuring
+	// an image policy enables it too.
+	FamilyImages RuleFamily = "images"
+	// FamilyLabels enables GD9xxx, the label rules.
+	FamilyLabels RuleFamily = "labels"
+	// FamilyBuildKit enables BKxxxx, the ports of BuildKit's checks.
+	FamilyBuildKit RuleFamily = "buildkit"
+)
+
+// familyAll enables every rule family.
+const familyAll = "all"
+
+// RuleFamilies returns every rule family, in rule code order.
+func RuleFamilies() []RuleFamily {
+	return []RuleFamily{
+		FamilyDialects, FamilyWindows, FamilyLayers, FamilyPinning, FamilySecrets, FamilyDownloads,
+		FamilyPrivileges, FamilyVariables, FamilyImages, FamilyLabels, FamilyBuildKit,
+	}
+}
+
+// EnableFamilies enables the named rule families; "all" enables every one.
+func (c *Config) EnableFamilies(names ...string) error {
+	for _, name := range names {
+		if name == familyAll {
+			c.Families = RuleFamilies()
+
+			continue
+		}
+
+		family := RuleFamily(name)
+		if !slices.Contains(RuleFamilies(), family) {
+			return fmt.Errorf("%w: %s (known: %v, %s)", ErrUnknownRuleFamily, name, RuleFamilies(), familyAll)
+		}
+
+		if !slices.Contains(c.Families, family) {
+			c.Families = append(c.Families, family)
+		}
+	}
+
+	return nil
+}
+
+// FamilyEnabled reports whether the rules of a family run: when it is
+// enabled, or for the image policy rules, when a policy is configured.
+func (c *Config) FamilyEnabled(family RuleFamily) bool {
+	if slices.Contains(c.Families, family) {
+		return true
+	}
+
+	return family == FamilyImages && c.Images.configured()
+}
+
+// configured reports whether any image policy is set.
+func (p *ImagePolicy) configured() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0 || len(p.RequireDigest) > 0 || len(p.TagPatterns) > 0 ||
+		len(p.EOL) > 0 || len(p.AllowUnpinned) > 0 || len(p.Lock) > 0
+}
@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+)
+
+func TestParse_RuleFamilies(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte("rule-families: [dialects, dialects]\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if want := []config.RuleFamily{config.FamilyDialects}; !slices.Equal(cfg.Families, want) {
+		t.Errorf("Families = %v, want %v", cfg.Families, want)
+	}
+
+	cfg, err = config.Parse([]byte("rule-families: all\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if !slices.Equal(cfg.Families, config.RuleFamilies()) {
+		t.Errorf("Families = %v, want %v", cfg.Families, config.RuleFamilies())
+	}
+
+	if _, err := config.Parse([]byte("rule-families: [dialects, style]\n")); !errors.Is(err, config.ErrUnknownRuleFamily) {
+		t.Errorf("Parse() error = %v, want ErrUnknownRuleFamily", err)
+	}
+}
+
+func TestConfig_FamilyEnabled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		for _, family := range config.RuleFamilies() {
+			if cfg.FamilyEnabled(family) {
+				t.Errorf("FamilyEnabled(%s) = true, want false", family)
+			}
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		if err := cfg.EnableFamilies("dialects"); err != nil {
+			t.Fatalf("EnableFamilies() error = %v", err)
+		}
+
+		if !cfg.FamilyEnabled(config.FamilyDialects) {
+			t.Errorf("Families = %v, want dialects", cfg.Families)
+		}
+	})
+
+	t.Run("image policy", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Images.Deny = []string{"centos"}
+
+		if !cfg.FamilyEnabled(config.FamilyImages) {
+			t.Error("FamilyEnabled(images) = false with a policy, want true")
+		}
+	})
+}
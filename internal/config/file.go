@@ -0,0 +1,262 @@
+// This file loads hadolint-compatible YAML configuration files. The package
+// godoc lives in config.go.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/farcloser/godolint/internal/image"
+)
+
+// ErrUnknownLabelType reports a label-schema entry whose type is not one of
+// the supported LabelType values (or its hadolint spelling).
+var ErrUnknownLabelType = errors.New("unknown label type")
+
+// ErrInvalidSecretPattern reports a secrets entry that is not a valid
+// regular expression or glob.
+var ErrInvalidSecretPattern = errors.New("invalid secret pattern")
+
+// ErrInvalidImagePolicy reports an images entry that is not a valid regular
+// expression, or an invalid end-of-life table.
+var ErrInvalidImagePolicy = errors.New("invalid image policy")
+
+// FileNames lists the configuration file names looked up by Find, in order of
+// precedence. They match hadolint's, so an existing hadolint setup is picked
+// up unchanged.
+func FileNames() []string {
+	return []string{".hadolint.yaml", ".hadolint.yml", "hadolint.yaml", "hadolint.yml"}
+}
+
+// fileConfig mirrors the on-disk layout of a hadolint configuration file.
+// Keys godolint does not act upon (format, no-color, ...) are ignored, and
+// label-presets, secrets, images and rule-families are godolint's own.
+type fileConfig struct {
+	Ignored             []string          `yaml:"ignored"`
+	TrustedRegistries   stringList        `yaml:"trustedRegistries"`
+	LabelSchema         map[string]string `yaml:"label-schema"`
+	LabelPresets        stringList        `yaml:"label-presets"`
+	StrictLabels        bool              `yaml:"strict-labels"`
+	DisableIgnorePragma bool              `yaml:"disable-ignore-pragma"`
+	Secrets             fileSecrets       `yaml:"secrets"`
+	Images              fileImages        `yaml:"images"`
+	RuleFamilies        stringList        `yaml:"rule-families"`
+}
+
+// fileImages mirrors the images section of a configuration file.
+type fileImages struct {
+	Allow         []string          `yaml:"allow"`
+	Deny          []string          `yaml:"deny"`
+	RequireDigest []string          `yaml:"require-digest"`
+	TagPatterns   map[string]string `yaml:"tag-patterns"`
+	EOLFile       string            `yaml:"eol-file"`
+	AllowUnpinned []string          `yaml:"allow-unpinned"`
+	LockFile      string            `yaml:"lock-file"`
+}
+
+// fileSecrets mirrors the secrets section of a configuration file.
+type fileSecrets struct {
+	Keys        []string `yaml:"keys"`
+	AllowedKeys []string `yaml:"allowed-keys"`
+	Patterns    []string `yaml:"patterns"`
+	Files       []string `yaml:"files"`
+}
+
+// stringList accepts either a single string or a list of strings, as
+// hadolint does for trustedRegistries.
+type stringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = []string{value.Value}
+
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return fmt.Errorf("expected a string or a list of strings: %w", err)
+	}
+
+	*l = list
+
+	return nil
+}
+
+// Load reads the configuration file at path, and the end-of-life and lock
+// files its images section names, relative to it. Unset keys keep their
+// Default values.
+func Load(path string) (*Config, error) {
+	//nolint:gosec // G304: reading a user-supplied configuration path is the purpose.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	raw, err := decode(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	cfg, err := raw.config()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	if eolFile := raw.Images.EOLFile; eolFile != "" {
+		if cfg.Images.EOL, err = LoadEOL(relativeTo(path, eolFile)); err != nil {
+			return nil, err
+		}
+	}
+
+	if lockFile := raw.Images.LockFile; lockFile != "" {
+		if cfg.Images.Lock, err = image.LoadLock(relativeTo(path, lockFile)); err != nil {
+			return nil, err //nolint:wrapcheck // image.LoadLock already names the file in its errors.
+		}
+	}
+
+	return cfg, nil
+}
+
+// relativeTo resolves a path a configuration file names, relative to it.
+func relativeTo(configPath, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
+	return filepath.Join(filepath.Dir(configPath), name)
+}
+
+// Parse decodes configuration file content. The end-of-life and lock files
+// of the images section are left to Load, which knows where to find them.
+func Parse(content []byte) (*Config, error) {
+	raw, err := decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw.config()
+}
+
+// decode unmarshals configuration file content.
+func decode(content []byte) (fileConfig, error) {
+	var raw fileConfig
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return fileConfig{}, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return raw, nil
+}
+
+// config converts the file layout to a Config.
+func (raw fileConfig) config() (*Config, error) {
+	cfg := Default()
+	cfg.Ignored = raw.Ignored
+	cfg.AllowedRegistries = append(cfg.AllowedRegistries, raw.TrustedRegistries...)
+	cfg.StrictLabels = raw.StrictLabels
+	cfg.DisableIgnorePragma = raw.DisableIgnorePragma
+
+	for key, name := range raw.LabelSchema {
+		labelType, err := parseLabelType(name)
+		if err != nil {
+			return nil, fmt.Errorf("label %q: %w", key, err)
+		}
+
+		cfg.LabelSchema[key] = labelType
+	}
+
+	if err := cfg.ApplyLabelPresets(raw.LabelPresets...); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.EnableFamilies(raw.RuleFamilies...); err != nil {
+		return nil, err
+	}
+
+	cfg.Secrets = Secrets(raw.Secrets)
+	if err := validateSecrets(cfg.Secrets); err != nil {
+		return nil, err
+	}
+
+	cfg.Images = ImagePolicy{
+		Allow:         raw.Images.Allow,
+		Deny:          raw.Images.Deny,
+		RequireDigest: raw.Images.RequireDigest,
+		TagPatterns:   raw.Images.TagPatterns,
+		AllowUnpinned: raw.Images.AllowUnpinned,
+	}
+	for glob, expr := range cfg.Images.TagPatterns {
+		if _, err := regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("%w: tag pattern of %s: %w", ErrInvalidImagePolicy, glob, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// validateSecrets checks the secrets expressions and globs compile.
+func validateSecrets(secrets Secrets) error {
+	for _, expr := range slices.Concat(secrets.Keys, secrets.AllowedKeys, secrets.Patterns) {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSecretPattern, err)
+		}
+	}
+
+	for _, glob := range secrets.Files {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrInvalidSecretPattern, glob, err)
+		}
+	}
+
+	return nil
+}
+
+// Find looks for a configuration file in dir and its parents, returning the
+// first match. ok is false when none exists up to the filesystem root.
+func Find(dir string) (path string, ok bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, name := range FileNames() {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// parseLabelType accepts both the LabelType values and hadolint's spelling
+// of them ("text", "hash").
+func parseLabelType(name string) (LabelType, error) {
+	switch name {
+	case "text", string(LabelTypeRawText):
+		return LabelTypeRawText, nil
+	case "hash", string(LabelTypeGitHash):
+		return LabelTypeGitHash, nil
+	case string(LabelTypeEmail), string(LabelTypeRFC3339), string(LabelTypeSemVer),
+		string(LabelTypeSPDX), string(LabelTypeURL):
+		return LabelType(name), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownLabelType, name)
+	}
+}
@@ -0,0 +1,204 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`
+ignored:
+  - DL3007
+  - SC2086
+trustedRegistries: docker.io
+label-schema:
+  author: text
+  commit: hash
+  contact: email
+strict-labels: true
+disable-ignore-pragma: true
+format: json
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if !slices.Equal(cfg.Ignored, []string{"DL3007", "SC2086"}) {
+		t.Errorf("Ignored = %v", cfg.Ignored)
+	}
+
+	if !slices.Equal(cfg.AllowedRegistries, []string{"docker.io"}) {
+		t.Errorf("AllowedRegistries = %v, want the single string as a list", cfg.AllowedRegistries)
+	}
+
+	want := map[string]config.LabelType{
+		"author":  config.LabelTypeRawText,
+		"commit":  config.LabelTypeGitHash,
+		"contact": config.LabelTypeEmail,
+	}
+	for key, labelType := range want {
+		if cfg.LabelSchema[key] != labelType {
+			t.Errorf("LabelSchema[%s] = %q, want %q", key, cfg.LabelSchema[key], labelType)
+		}
+	}
+
+	if !cfg.StrictLabels || !cfg.DisableIgnorePragma {
+		t.Errorf("StrictLabels = %v, DisableIgnorePragma = %v, want both true",
+			cfg.StrictLabels, cfg.DisableIgnorePragma)
+	}
+}
+
+func TestParse_UnknownLabelType(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Parse([]byte("label-schema:\n  author: colour\n"))
+	if err == nil {
+		t.Fatal("Parse() error = nil, want an unknown label type error")
+	}
+}
+
+func TestParse_Secrets(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`
+secrets:
+  keys: ['(?i)passphrase']
+  allowed-keys: ['^SIGNING_KEY_ID$']
+  patterns: ['\bacme_[0-9a-f]{32}\b']
+  files: [vault-token]
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := config.Secrets{
+		Keys:        []string{"(?i)passphrase"},
+		AllowedKeys: []string{"^SIGNING_KEY_ID$"},
+		Patterns:    []string{`\bacme_[0-9a-f]{32}\b`},
+		Files:       []string{"vault-token"},
+	}
+	if !slices.Equal(cfg.Secrets.Keys, want.Keys) || !slices.Equal(cfg.Secrets.AllowedKeys, want.AllowedKeys) ||
+		!slices.Equal(cfg.Secrets.Patterns, want.Patterns) || !slices.Equal(cfg.Secrets.Files, want.Files) {
+		t.Errorf("Secrets = %+v, want %+v", cfg.Secrets, want)
+	}
+
+	for _, content := range []string{"secrets:\n  patterns: ['(']\n", "secrets:\n  files: ['[']\n"} {
+		if _, err := config.Parse([]byte(content)); !errors.Is(err, config.ErrInvalidSecretPattern) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidSecretPattern", content, err)
+		}
+	}
+}
+
+func TestParse_Images(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`
+images:
+  allow: ['docker.io/library/*']
+  deny: ['node:16*']
+  require-digest: [ghcr.io]
+  allow-unpinned: ['localhost:5000/**']
+  tag-patterns:
+    '*': '^\d+\.\d+\.\d+-'
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	images := cfg.Images
+	if !slices.Equal(images.Allow, []string{"docker.io/library/*"}) || !slices.Equal(images.Deny, []string{"node:16*"}) ||
+		!slices.Equal(images.RequireDigest, []string{"ghcr.io"}) || images.TagPatterns["*"] != `^\d+\.\d+\.\d+-` ||
+		!slices.Equal(images.AllowUnpinned, []string{"localhost:5000/**"}) {
+		t.Errorf("Images = %+v", images)
+	}
+
+	content := "images:\n  tag-patterns:\n    '*': '('\n"
+	if _, err := config.Parse([]byte(content)); !errors.Is(err, config.ErrInvalidImagePolicy) {
+		t.Errorf("Parse(%q) error = %v, want ErrInvalidImagePolicy", content, err)
+	}
+}
+
+func TestLoad_EOLFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".hadolint.yaml": "images:\n  eol-file: eol.yaml\n",
+		"eol.yaml":       "python:\n  - cycle: '3.7'\n    eol: 2023-06-27\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+
+	cfg, err := config.Load(filepath.Join(dir, ".hadolint.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cycles := cfg.Images.EOL["python"]; len(cycles) != 1 || cycles[0].Cycle != "3.7" {
+		t.Errorf("Images.EOL = %+v, want the python 3.7 cycle of eol.yaml", cfg.Images.EOL)
+	}
+}
+
+func TestLoad_LockFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	files := map[string]string{
+		".hadolint.yaml": "images:\n  lock-file: godolint.lock\n",
+		"godolint.lock":  "python:3.12 " + digest + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+
+	cfg, err := config.Load(filepath.Join(dir, ".hadolint.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got, ok := cfg.Images.Lock.Digest(image.Parse("python:3.12")); !ok || got != digest {
+		t.Errorf("Images.Lock = %v, want python:3.12 locked to %s", cfg.Images.Lock, digest)
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+
+	if err := os.MkdirAll(nested, 0o750); err != nil {
+		t.Fatalf("creating directories: %v", err)
+	}
+
+	if _, ok := config.Find(nested); ok {
+		t.Skip("a configuration file exists above the temp dir")
+	}
+
+	want := filepath.Join(root, ".hadolint.yaml")
+	if err := os.WriteFile(want, []byte("ignored: [DL3007]\n"), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	got, ok := config.Find(nested)
+	if !ok || got != want {
+		t.Errorf("Find() = %q, %v, want %q, true", got, ok, want)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrUnknownLabelPreset reports a label preset godolint does not ship.
+var ErrUnknownLabelPreset = errors.New("unknown label preset")
+
+// labelPresets are the built-in label schemas, by name: the OCI image
+// annotations, and the label-schema.org convention they superseded.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var labelPresets = map[string]map[string]LabelType{
+	"oci": {
+		"org.opencontainers.image.source":   LabelTypeURL,
+		"org.opencontainers.image.revision": LabelTypeGitHash,
+		"org.opencontainers.image.created":  LabelTypeRFC3339,
+		"org.opencontainers.image.licenses": LabelTypeSPDX,
+		"org.opencontainers.image.version":  LabelTypeSemVer,
+		"org.opencontainers.image.url":      LabelTypeURL,
+	},
+	"label-schema.org": {
+		"org.label-schema.schema-version": LabelTypeRawText,
+		"org.label-schema.build-date":     LabelTypeRFC3339,
+		"org.label-schema.name":           LabelTypeRawText,
+		"org.label-schema.vcs-ref":        LabelTypeGitHash,
+		"org.label-schema.vcs-url":        LabelTypeURL,
+		"org.label-schema.url":            LabelTypeURL,
+		"org.label-schema.version":        LabelTypeSemVer,
+	},
+}
+
+// LabelPresetNames returns the names of the built-in label presets, sorted.
+func LabelPresetNames() []string {
+	return slices.Sorted(maps.Keys(labelPresets))
+}
+
+// LabelPreset returns the label schema of a built-in preset.
+func LabelPreset(name string) (map[string]LabelType, bool) {
+	preset, ok := labelPresets[name]
+
+	return maps.Clone(preset), ok
+}
+
+// ApplyLabelPresets adds the labels of the named presets to the label
+// schema. Labels the schema already has keep their type, so that custom
+// keys refine a preset rather than the other way around.
+func (c *Config) ApplyLabelPresets(names ...string) error {
+	for _, name := range names {
+		preset, ok := labelPresets[name]
+		if !ok {
+			return fmt.Errorf("%w: %s (known: %v)", ErrUnknownLabelPreset, name, LabelPresetNames())
+		}
+
+		if c.LabelSchema == nil {
+			c.LabelSchema = make(map[string]LabelType, len(preset))
+		}
+
+		for key, labelType := range preset {
+			if _, set := c.LabelSchema[key]; !set {
+				c.LabelSchema[key] = labelType
+			}
+		}
+	}
+
+	return nil
+}
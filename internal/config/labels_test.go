@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+)
+
+func TestLabelPresetNames(t *testing.T) {
+	t.Parallel()
+
+	if got, want := config.LabelPresetNames(), []string{"label-schema.org", "oci"}; !slices.Equal(got, want) {
+		t.Errorf("LabelPresetNames() = %v, want %v", got, want)
+	}
+}
+
+func TestParse_LabelPresets(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`
+label-presets: oci
+label-schema:
+  org.opencontainers.image.version: text
+  maintainer: email
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := map[string]config.LabelType{
+		"org.opencontainers.image.source":   config.LabelTypeURL,
+		"org.opencontainers.image.revision": config.LabelTypeGitHash,
+		"org.opencontainers.image.created":  config.LabelTypeRFC3339,
+		"org.opencontainers.image.licenses": config.LabelTypeSPDX,
+		"org.opencontainers.image.version":  config.LabelTypeRawText, // the custom key wins
+		"org.opencontainers.image.url":      config.LabelTypeURL,
+		"maintainer":                        config.LabelTypeEmail,
+	}
+	if len(cfg.LabelSchema) != len(want) {
+		t.Errorf("LabelSchema = %v, want %v", cfg.LabelSchema, want)
+	}
+
+	for key, labelType := range want {
+		if cfg.LabelSchema[key] != labelType {
+			t.Errorf("LabelSchema[%s] = %q, want %q", key, cfg.LabelSchema[key], labelType)
+		}
+	}
+
+	if _, err := config.Parse([]byte("label-presets: [oci, dublin-core]\n")); !errors.Is(err, config.ErrUnknownLabelPreset) {
+		t.Errorf("Parse() error = %v, want ErrUnknownLabelPreset", err)
+	}
+}
+
+func TestConfig_ApplyLabelPresets(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.LabelSchema["org.label-schema.version"] = config.LabelTypeRawText
+
+	if err := cfg.ApplyLabelPresets("oci", "label-schema.org"); err != nil {
+		t.Fatalf("ApplyLabelPresets() error = %v", err)
+	}
+
+	if cfg.LabelSchema["org.label-schema.version"] != config.LabelTypeRawText ||
+		cfg.LabelSchema["org.label-schema.vcs-ref"] != config.LabelTypeGitHash ||
+		cfg.LabelSchema["org.opencontainers.image.created"] != config.LabelTypeRFC3339 {
+		t.Errorf("LabelSchema = %v, want both presets under the existing keys", cfg.LabelSchema)
+	}
+
+	preset, _ := config.LabelPreset("oci")
+	preset["org.opencontainers.image.url"] = config.LabelTypeEmail
+
+	if again, _ := config.LabelPreset("oci"); again["org.opencontainers.image.url"] != config.LabelTypeURL {
+		t.Error("LabelPreset() returned the built-in table, want a copy")
+	}
+}
@@ -0,0 +1,137 @@
+// Package image parses the image references of Dockerfiles, and the lock
+// files pinning them to digests.
+package image
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Reference is an image reference, such as FROM names it.
+type Reference struct {
+	Name   string // as written, e.g. python or ghcr.io/acme/app
+	Tag    string // latest when neither tag nor digest is set
+	Digest string // "" when unset
+}
+
+// digestPattern matches the digests of the distribution reference grammar.
+var digestPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}$`)
+
+// Parse splits [registry[:port]/]name[:tag][@digest]. Like BuildKit, it
+// defaults the tag to latest when neither is set.
+func Parse(ref string) Reference {
+	var parsed Reference
+
+	ref, parsed.Digest, _ = strings.Cut(ref, "@")
+
+	if colon := strings.LastIndex(ref, ":"); colon > strings.LastIndex(ref, "/") {
+		ref, parsed.Tag = ref[:colon], ref[colon+1:]
+	}
+
+	parsed.Name = ref
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = "latest"
+	}
+
+	return parsed
+}
+
+// IsDigest reports whether a string is a valid digest (sha256:...).
+func IsDigest(digest string) bool {
+	return digestPattern.MatchString(digest)
+}
+
+// String returns the reference as name[:tag][@digest].
+func (r Reference) String() string {
+	ref := r.Name
+	if r.Tag != "" {
+		ref += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		ref += "@" + r.Digest
+	}
+
+	return ref
+}
+
+// Registry returns the registry of the image, docker.io for Docker Hub.
+func (r Reference) Registry() string {
+	if first, _, ok := strings.Cut(r.Name, "/"); ok &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first
+	}
+
+	return "docker.io"
+}
+
+// FullName returns the name of the image with its registry and, for Docker
+// Hub's official images, its library/ namespace.
+func (r Reference) FullName() string {
+	if r.Registry() != "docker.io" {
+		return r.Name
+	}
+
+	name := strings.TrimPrefix(r.Name, "docker.io/")
+	if !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	return "docker.io/" + name
+}
+
+// OfficialName returns the name of a Docker Hub official image (python for
+// docker.io/library/python), or "" for other images.
+func (r Reference) OfficialName() string {
+	name, _ := strings.CutPrefix(r.FullName(), "docker.io/library/")
+	if strings.Contains(name, "/") {
+		return ""
+	}
+
+	return name
+}
+
+// Matches reports whether an image glob matches the image: its name as
+// written or in full, and its tag when the glob names one. * and ? stay
+// within a path component, ** crosses them.
+func (r Reference) Matches(glob string) bool {
+	name := glob
+
+	// A colon before the last slash is a registry port.
+	if colon := strings.LastIndex(glob, ":"); colon > strings.LastIndex(glob, "/") {
+		if !MatchGlob(glob[colon+1:], r.Tag) {
+			return false
+		}
+
+		name = glob[:colon]
+	}
+
+	return MatchGlob(name, r.Name) || MatchGlob(name, r.FullName())
+}
+
+// MatchGlob matches a glob where * and ? stay within a path component, and
+// ** crosses them.
+func MatchGlob(glob, value string) bool {
+	var pattern strings.Builder
+
+	pattern.WriteString("^")
+
+	for index := 0; index < len(glob); index++ {
+		switch {
+		case strings.HasPrefix(glob[index:], "**"):
+			pattern.WriteString(".*")
+
+			index++
+		case glob[index] == '*':
+			pattern.WriteString("[^/]*")
+		case glob[index] == '?':
+			pattern.WriteString("[^/]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(glob[index : index+1]))
+		}
+	}
+
+	pattern.WriteString("$")
+
+	return regexp.MustCompile(pattern.String()).MatchString(value)
+}
@@ -0,0 +1,66 @@
+package image_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/image"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		ref      string
+		want     image.Reference
+		fullName string
+	}{
+		{"python", image.Reference{Name: "python", Tag: "latest"}, "docker.io/library/python"},
+		{"python:3.12-slim", image.Reference{Name: "python", Tag: "3.12-slim"}, "docker.io/library/python"},
+		{"bitnami/redis:7", image.Reference{Name: "bitnami/redis", Tag: "7"}, "docker.io/bitnami/redis"},
+		{"docker.io/node:22", image.Reference{Name: "docker.io/node", Tag: "22"}, "docker.io/library/node"},
+		{"localhost:5000/app", image.Reference{Name: "localhost:5000/app", Tag: "latest"}, "localhost:5000/app"},
+		{"ghcr.io/acme/app@" + digest, image.Reference{Name: "ghcr.io/acme/app", Digest: digest}, "ghcr.io/acme/app"},
+		{"alpine:3.20@" + digest, image.Reference{Name: "alpine", Tag: "3.20", Digest: digest}, "docker.io/library/alpine"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			t.Parallel()
+
+			got := image.Parse(tt.ref)
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.ref, got, tt.want)
+			}
+
+			if got.FullName() != tt.fullName {
+				t.Errorf("FullName() = %q, want %q", got.FullName(), tt.fullName)
+			}
+		})
+	}
+}
+
+func TestReference_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref   string
+		glob  string
+		match bool
+	}{
+		{"python:3.12", "python", true},
+		{"python:3.12", "docker.io/library/*", true},
+		{"python:3.12", "python:3.*", true},
+		{"python:3.12", "python:2.*", false},
+		{"ghcr.io/acme/app:1", "ghcr.io/*", false},
+		{"ghcr.io/acme/app:1", "ghcr.io/**", true},
+		{"localhost:5000/app:dev", "localhost:5000/*", true},
+	}
+
+	for _, tt := range tests {
+		if got := image.Parse(tt.ref).Matches(tt.glob); got != tt.match {
+			t.Errorf("Parse(%q).Matches(%q) = %v, want %v", tt.ref, tt.glob, got, tt.match)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidLock reports a lock file line that is not a tagged reference
+// followed by a digest.
+var ErrInvalidLock = errors.New("invalid lock")
+
+// Lock maps the tagged references of images to the digests they are pinned
+// to. Keys are in full (docker.io/library/python:3.12), so that any
+// spelling of a reference finds its digest.
+type Lock map[string]string
+
+// LoadLock reads a lock file.
+func LoadLock(path string) (Lock, error) {
+	//nolint:gosec // G304: reading a user-supplied lock file is the purpose.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock %s: %w", path, err)
+	}
+
+	lock, err := ParseLock(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lock %s: %w", path, err)
+	}
+
+	return lock, nil
+}
+
+// ParseLock decodes lock file content: a reference and its digest per line,
+// blank lines and # comments aside. Any tool can produce it, e.g. from
+// `docker buildx imagetools inspect`:
+//
+//	python:3.12-slim sha256:4f1d...
+//	ghcr.io/acme/app:1.4 sha256:9c2e...
+func ParseLock(content []byte) (Lock, error) {
+	lock := make(Lock)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.Contains(fields[0], "@") || !IsDigest(fields[1]) {
+			return nil, fmt.Errorf("%w: line %d: want a reference and its digest", ErrInvalidLock, number)
+		}
+
+		lock[key(Parse(fields[0]))] = fields[1]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read lock: %w", err)
+	}
+
+	return lock, nil
+}
+
+// Digest returns the digest the lock pins a reference to.
+func (l Lock) Digest(ref Reference) (string, bool) {
+	digest, ok := l[key(ref)]
+
+	return digest, ok
+}
+
+// key returns the lock key of a reference: its full name and tag.
+func key(ref Reference) string {
+	return ref.FullName() + ":" + ref.Tag
+}
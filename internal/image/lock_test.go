@@ -0,0 +1,46 @@
+package image_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/image"
+)
+
+func TestParseLock(t *testing.T) {
+	t.Parallel()
+
+	python := "sha256:" + strings.Repeat("a", 64)
+	app := "sha256:" + strings.Repeat("b", 64)
+
+	lock, err := image.ParseLock([]byte(`# produced by docker buildx imagetools inspect
+python:3.12-slim ` + python + `
+
+ghcr.io/acme/app:1.4	` + app + `
+`))
+	if err != nil {
+		t.Fatalf("ParseLock() error = %v", err)
+	}
+
+	for ref, want := range map[string]string{
+		"python:3.12-slim":                   python,
+		"docker.io/library/python:3.12-slim": python,
+		"ghcr.io/acme/app:1.4@" + python:     app,
+		"ghcr.io/acme/app:1.4":               app,
+	} {
+		if got, ok := lock.Digest(image.Parse(ref)); !ok || got != want {
+			t.Errorf("Digest(%q) = %q, %v, want %q", ref, got, ok, want)
+		}
+	}
+
+	if _, ok := lock.Digest(image.Parse("python:3.13")); ok {
+		t.Error("Digest(python:3.13) found, want it missing")
+	}
+
+	for _, content := range []string{"python:3.12\n", "python:3.12 latest\n", "python@" + python + " " + python + "\n"} {
+		if _, err := image.ParseLock([]byte(content)); !errors.Is(err, image.ErrInvalidLock) {
+			t.Errorf("ParseLock(%q) error = %v, want ErrInvalidLock", content, err)
+		}
+	}
+}
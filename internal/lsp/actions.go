@@ -0,0 +1,159 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/pin"
+)
+
+var (
+	// linePragmaRegex matches an existing `# hadolint ignore=` pragma, up to
+	// the end of its rule list.
+	linePragmaRegex = regexp.MustCompile(`^\s*#\s*hadolint\s+ignore\s*=\s*[A-Za-z0-9]+(?:\s*,\s*[A-Za-z0-9]+)*`)
+	// parserDirectiveRegex matches the parser directives (`# syntax=`,
+	// `# escape=`, `# check=`) that must stay at the very top of a Dockerfile.
+	parserDirectiveRegex = regexp.MustCompile(`^#\s*(?i:syntax|escape|check)\s*=`)
+)
+
+// ignoreActions offers, for each rule code among the diagnostics, quick fixes
+// adding an ignore pragma for the offending line or for the whole file.
+func ignoreActions(uri string, lines []string, diagnostics []diagnostic) []codeAction {
+	actions := []codeAction{}
+
+	var seen []string
+
+	for _, diag := range diagnostics {
+		if diag.Code == "" || diag.Source != serverName {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%d", diag.Code, diag.Range.Start.Line)
+		if slices.Contains(seen, key) {
+			continue
+		}
+
+		seen = append(seen, key)
+
+		actions = append(actions,
+			codeAction{
+				Title:       fmt.Sprintf("Ignore %s for this line", diag.Code),
+				Kind:        codeActionQuickFix,
+				Diagnostics: []diagnostic{diag},
+				Edit:        singleEdit(uri, lineIgnoreEdit(lines, diag.Range.Start.Line, diag.Code)),
+			},
+			codeAction{
+				Title:       fmt.Sprintf("Ignore %s for this file", diag.Code),
+				Kind:        codeActionQuickFix,
+				Diagnostics: []diagnostic{diag},
+				Edit:        singleEdit(uri, globalIgnoreEdit(lines, diag.Code)),
+			},
+		)
+	}
+
+	return actions
+}
+
+// pinActions offers, for each GD8005 diagnostic, a quick fix pinning the
+// image of its instruction to the digest of the lock file, as `godolint pin`
+// does. Images the lock does not have get none.
+func pinActions(uri, text string, lock image.Lock, diagnostics []diagnostic) []codeAction {
+	if len(lock) == 0 {
+		return nil
+	}
+
+	pinned, results, err := pin.Pin([]byte(text), lock)
+	if err != nil {
+		return nil
+	}
+
+	before, after := splitLines(text), splitLines(string(pinned))
+
+	var actions []codeAction
+
+	for _, diag := range diagnostics {
+		if diag.Code != "GD8005" || diag.Source != serverName {
+			continue
+		}
+
+		line := diag.Range.Start.Line
+
+		index := slices.IndexFunc(results, func(result pin.Result) bool {
+			return result.Line-1 == line && result.Status == pin.StatusPinned
+		})
+		if index < 0 {
+			continue
+		}
+
+		// The instruction spans the lines up to the next image.
+		end := len(before)
+		if index+1 < len(results) {
+			end = results[index+1].Line - 1
+		}
+
+		var edits []textEdit
+
+		for i := line; i < end && i < len(after); i++ {
+			if before[i] != after[i] {
+				edits = append(edits, textEdit{Range: lineRange(before, i), NewText: after[i]})
+			}
+		}
+
+		actions = append(actions, codeAction{
+			Title:       fmt.Sprintf("Pin %s to the digest of the lock file", results[index].Reference),
+			Kind:        codeActionQuickFix,
+			Diagnostics: []diagnostic{diag},
+			Edit:        workspaceEdit{Changes: map[string][]textEdit{uri: edits}},
+		})
+	}
+
+	return actions
+}
+
+// lineIgnoreEdit extends the ignore pragma right above line with code, or
+// inserts a new one with the line's indentation.
+func lineIgnoreEdit(lines []string, line int, code string) textEdit {
+	if line > 0 && line-1 < len(lines) {
+		if match := linePragmaRegex.FindString(lines[line-1]); match != "" {
+			// The match is ASCII, so its byte length is its UTF-16 length.
+			end := position{Line: line - 1, Character: len(match)}
+
+			return textEdit{Range: lspRange{Start: end, End: end}, NewText: "," + code}
+		}
+	}
+
+	indent := ""
+	if line < len(lines) {
+		indent = lines[line][:len(lines[line])-len(strings.TrimLeft(lines[line], " \t"))]
+	}
+
+	start := position{Line: line, Character: 0}
+
+	return textEdit{
+		Range:   lspRange{Start: start, End: start},
+		NewText: indent + "# hadolint ignore=" + code + "\n",
+	}
+}
+
+// globalIgnoreEdit inserts a global ignore pragma at the top of the file,
+// below any parser directive.
+func globalIgnoreEdit(lines []string, code string) textEdit {
+	line := 0
+	for line < len(lines) && parserDirectiveRegex.MatchString(lines[line]) {
+		line++
+	}
+
+	start := position{Line: line, Character: 0}
+
+	return textEdit{
+		Range:   lspRange{Start: start, End: start},
+		NewText: "# hadolint global ignore=" + code + "\n",
+	}
+}
+
+func singleEdit(uri string, edit textEdit) workspaceEdit {
+	return workspaceEdit{Changes: map[string][]textEdit{uri: {edit}}}
+}
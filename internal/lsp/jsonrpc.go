@@ -0,0 +1,185 @@
+// Package lsp implements a Language Server Protocol server for Dockerfiles,
+// speaking JSON-RPC 2.0 over a byte stream (stdio in practice).
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxContentLength bounds the body of a message, far above any Dockerfile,
+// so that a bogus header cannot make the server allocate without limit.
+const maxContentLength = 64 << 20
+
+var (
+	// errMissingContentLength reports a message header block without the
+	// mandatory Content-Length field.
+	errMissingContentLength = errors.New("missing Content-Length header")
+	// errContentTooLarge reports a Content-Length above maxContentLength.
+	errContentTooLarge = errors.New("message too large")
+)
+
+// message is a JSON-RPC request, response or notification. Requests carry an
+// ID and a Method, notifications only a Method, responses only an ID.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+// responseError is the error member of a JSON-RPC response.
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// isRequest tells requests (which expect a response) from notifications.
+func (m *message) isRequest() bool {
+	return m.ID != nil
+}
+
+// conn reads and writes Content-Length framed JSON-RPC messages.
+type conn struct {
+	reader *bufio.Reader
+	writer io.Writer
+	mu     sync.Mutex
+	nextID int
+}
+
+func newConn(in io.Reader, out io.Writer) *conn {
+	return &conn{
+		reader: bufio.NewReader(in),
+		writer: out,
+	}
+}
+
+// read returns the next message. It returns io.EOF once the stream is
+// closed between two messages.
+func (c *conn) read() (*message, error) {
+	length := -1
+
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) && line == "" && length == -1 {
+				return nil, io.EOF
+			}
+
+			return nil, fmt.Errorf("failed to read header: %w", err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			continue
+		}
+
+		length, err = strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Content-Length: %w", err)
+		}
+	}
+
+	if length < 0 {
+		return nil, errMissingContentLength
+	}
+
+	// The body cannot be skipped without reading it: the stream is lost.
+	if length > maxContentLength {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d", errContentTooLarge, length, maxContentLength)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+
+	return msg, nil
+}
+
+// write sends a message, framing it with its Content-Length.
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
+
+// reply answers the request id with either a result or an error.
+func (c *conn) reply(id *json.RawMessage, result any, rpcErr *responseError) error {
+	if rpcErr != nil {
+		return c.write(&message{ID: id, Error: rpcErr})
+	}
+
+	// A JSON-RPC response must carry a result member, even a null one.
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+
+	return c.write(&message{ID: id, Result: result})
+}
+
+// request sends a request to the client. Its response is not waited for.
+func (c *conn) request(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s params: %w", method, err)
+	}
+
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+
+	return c.write(&message{ID: &id, Method: method, Params: raw})
+}
+
+// notify sends a notification to the client.
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s params: %w", method, err)
+	}
+
+	return c.write(&message{Method: method, Params: raw})
+}
+
+// Error implements error, so a malformed message can travel up as one.
+func (e *responseError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,562 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/sdk"
+)
+
+// serverName identifies the server to clients, and is the source of every
+// diagnostic it publishes.
+const serverName = "godolint"
+
+// ErrExitWithoutShutdown reports an exit notification that was not preceded
+// by a shutdown request; the specification asks for a non-zero exit code.
+var ErrExitWithoutShutdown = errors.New("exit received before shutdown")
+
+// Options configures a Server.
+type Options struct {
+	// ConfigPath, when set, is the configuration used for every document.
+	// Otherwise each document uses the configuration file found next to it
+	// or in a parent directory (typically the workspace root).
+	ConfigPath string
+
+	// LabelPresets are added to the label schema of every configuration,
+	// like --label-preset.
+	LabelPresets []string
+
+	// RuleFamilies are enabled in every configuration, like --rule-family.
+	RuleFamilies []string
+
+	// LinterOptions are applied after the configuration, e.g.
+	// sdk.WithShellcheck().
+	LinterOptions []sdk.Option
+}
+
+// document is an open text document and the violations of its last lint.
+type document struct {
+	text       string
+	violations []sdk.Violation
+}
+
+// profile is what the documents of a configuration file are linted with.
+// It is built once, and again when the client reports changed files.
+type profile struct {
+	linter *sdk.Linter
+	lock   image.Lock
+}
+
+// Server lints open Dockerfiles and publishes the violations as diagnostics.
+// It handles one message at a time.
+type Server struct {
+	opts      Options
+	conn      *conn
+	rootPath  string
+	documents map[string]*document
+	rules     map[string]rule.Rule
+	shutdown  bool
+
+	// configPaths caches the configuration file of a directory, "" for
+	// none, and profiles the profile of a configuration file.
+	configPaths map[string]string
+	profiles    map[string]*profile
+	// watchFiles is set when the client can watch configuration files
+	// for the server.
+	watchFiles bool
+}
+
+// NewServer creates a server with the given options.
+func NewServer(opts Options) *Server {
+	// Every rule, so that hover describes the rules of any configuration.
+	rules := make(map[string]rule.Rule)
+	for _, r := range sdk.EveryRule() {
+		rules[string(r.Code())] = r
+	}
+
+	return &Server{
+		opts:        opts,
+		documents:   make(map[string]*document),
+		rules:       rules,
+		configPaths: make(map[string]string),
+		profiles:    make(map[string]*profile),
+	}
+}
+
+// Serve reads requests from in and writes responses to out until the client
+// sends exit, or in is closed.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.conn = newConn(in, out)
+
+	for {
+		msg, err := s.conn.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			// An undecodable message gets a parse error response, whose id
+			// is null as it could not be read.
+			rpcErr := &responseError{}
+			if errors.As(err, &rpcErr) {
+				nullID := json.RawMessage("null")
+				if err := s.conn.reply(&nullID, nil, rpcErr); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+
+			return nil
+		}
+
+		if err := s.handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a single message. Only transport failures are returned;
+// request failures are reported to the client.
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	var (
+		result any
+		rpcErr *responseError
+	)
+
+	switch msg.Method {
+	case "":
+		// A response to client/registerCapability: nothing to do.
+		return nil
+	case "initialize":
+		result, rpcErr = s.initialize(msg.Params)
+	case "initialized":
+		return s.watchConfigFiles()
+	case "$/cancelRequest", "$/setTrace", "textDocument/didSave":
+		// Nothing to do: documents are linted as they change.
+	case "workspace/didChangeWatchedFiles":
+		return s.reload(ctx)
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/didOpen":
+		return s.didOpen(ctx, msg.Params)
+	case "textDocument/didChange":
+		return s.didChange(ctx, msg.Params)
+	case "textDocument/didClose":
+		return s.didClose(msg.Params)
+	case "textDocument/hover":
+		result, rpcErr = s.hover(msg.Params)
+	case "textDocument/codeAction":
+		result, rpcErr = s.codeAction(msg.Params)
+	default:
+		if msg.isRequest() {
+			rpcErr = &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+		}
+	}
+
+	if !msg.isRequest() {
+		return nil
+	}
+
+	return s.conn.reply(msg.ID, result, rpcErr)
+}
+
+func (s *Server) initialize(raw json.RawMessage) (any, *responseError) {
+	var params initializeParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case params.RootURI != "":
+		s.rootPath = uriToPath(params.RootURI)
+	case len(params.WorkspaceFolders) > 0:
+		s.rootPath = uriToPath(params.WorkspaceFolders[0].URI)
+	default:
+		s.rootPath = params.RootPath
+	}
+
+	s.watchFiles = params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration
+
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync:   textDocumentSyncFull,
+			HoverProvider:      true,
+			CodeActionProvider: true,
+		},
+		ServerInfo: serverInfo{Name: serverName},
+	}, nil
+}
+
+// watchConfigFiles asks the client to report the changes of configuration
+// files, when it can.
+func (s *Server) watchConfigFiles() error {
+	if !s.watchFiles {
+		return nil
+	}
+
+	var watchers []fileSystemWatcher
+	for _, name := range config.FileNames() {
+		watchers = append(watchers, fileSystemWatcher{GlobPattern: "**/" + name})
+	}
+
+	return s.conn.request("client/registerCapability", registrationParams{
+		Registrations: []registration{{
+			ID:              "godolint-config",
+			Method:          "workspace/didChangeWatchedFiles",
+			RegisterOptions: didChangeWatchedFilesRegistrationOptions{Watchers: watchers},
+		}},
+	})
+}
+
+// reload drops the configurations read so far, and lints the open
+// documents again with the current ones.
+func (s *Server) reload(ctx context.Context) error {
+	clear(s.configPaths)
+	clear(s.profiles)
+
+	for _, uri := range slices.Sorted(maps.Keys(s.documents)) {
+		if err := s.publish(ctx, uri); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) didOpen(ctx context.Context, raw json.RawMessage) error {
+	// A malformed notification has no one to answer to: drop it.
+	var params didOpenParams
+	if decodeParams(raw, &params) != nil {
+		return nil
+	}
+
+	s.documents[params.TextDocument.URI] = &document{text: params.TextDocument.Text}
+
+	return s.publish(ctx, params.TextDocument.URI)
+}
+
+func (s *Server) didChange(ctx context.Context, raw json.RawMessage) error {
+	var params didChangeParams
+	if decodeParams(raw, &params) != nil || len(params.ContentChanges) == 0 {
+		return nil
+	}
+
+	// Full synchronization: the last change holds the whole document.
+	text := params.ContentChanges[len(params.ContentChanges)-1].Text
+	s.documents[params.TextDocument.URI] = &document{text: text}
+
+	return s.publish(ctx, params.TextDocument.URI)
+}
+
+func (s *Server) didClose(raw json.RawMessage) error {
+	var params didCloseParams
+	if decodeParams(raw, &params) != nil {
+		return nil
+	}
+
+	delete(s.documents, params.TextDocument.URI)
+
+	// Clear the diagnostics of a closed document.
+	return s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         params.TextDocument.URI,
+		Diagnostics: []diagnostic{},
+	})
+}
+
+// publish lints a document and sends its diagnostics.
+func (s *Server) publish(ctx context.Context, uri string) error {
+	doc := s.documents[uri]
+	lines := splitLines(doc.text)
+	diagnostics := []diagnostic{}
+
+	result, err := s.profile(uri).linter.Lint(ctx, []byte(doc.text))
+	if err != nil {
+		doc.violations = nil
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    lineRange(lines, 0),
+			Severity: severityError,
+			Source:   serverName,
+			Message:  err.Error(),
+		})
+	} else {
+		doc.violations = result.Violations
+		for _, violation := range result.Violations {
+			diagnostics = append(diagnostics, toDiagnostic(lines, violation))
+		}
+
+		// An unchecked instruction must not look clean.
+		for _, ruleErr := range result.Errors {
+			diagnostics = append(diagnostics, diagnostic{
+				Range:    lineRange(lines, max(ruleErr.Line-1, 0)),
+				Severity: severityWarning,
+				Code:     ruleErr.RuleCode,
+				Source:   serverName,
+				Message:  ruleErr.Error(),
+			})
+		}
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// profile returns the profile of a document, honoring the configuration
+// file that applies to it.
+func (s *Server) profile(uri string) *profile {
+	path := s.configPath(uri)
+	if cached, ok := s.profiles[path]; ok {
+		return cached
+	}
+
+	cfg := config.Default()
+
+	if path != "" {
+		loaded, err := config.Load(path)
+		if err != nil {
+			log.Warn().Err(err).Msg("ignoring invalid configuration file")
+		} else {
+			cfg = loaded
+		}
+	}
+
+	if err := cfg.ApplyLabelPresets(s.opts.LabelPresets...); err != nil {
+		log.Warn().Err(err).Msg("ignoring unknown label preset")
+	}
+
+	if err := cfg.EnableFamilies(s.opts.RuleFamilies...); err != nil {
+		log.Warn().Err(err).Msg("ignoring unknown rule family")
+	}
+
+	built := &profile{
+		linter: sdk.New(append([]sdk.Option{sdk.WithConfig(cfg)}, s.opts.LinterOptions...)...),
+		lock:   cfg.Images.Lock,
+	}
+	s.profiles[path] = built
+
+	return built
+}
+
+// configPath returns the configuration file of a document: --config, or
+// the file found next to it or in a parent directory, "" for none.
+func (s *Server) configPath(uri string) string {
+	if s.opts.ConfigPath != "" {
+		return s.opts.ConfigPath
+	}
+
+	dir := s.rootPath
+	if docPath := uriToPath(uri); docPath != "" {
+		dir = filepath.Dir(docPath)
+	}
+
+	if dir == "" {
+		return ""
+	}
+
+	path, ok := s.configPaths[dir]
+	if !ok {
+		path, _ = config.Find(dir)
+		s.configPaths[dir] = path
+	}
+
+	return path
+}
+
+func (s *Server) hover(raw json.RawMessage) (any, *responseError) {
+	var params hoverParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil, nil
+	}
+
+	var sections []string
+
+	for _, violation := range doc.violations {
+		if violationLine(violation) != params.Position.Line {
+			continue
+		}
+
+		sections = append(sections, s.describe(violation))
+	}
+
+	if len(sections) == 0 {
+		return nil, nil
+	}
+
+	hoverRange := lineRange(splitLines(doc.text), params.Position.Line)
+
+	return hover{
+		Contents: markupContent{Kind: "markdown", Value: strings.Join(sections, "\n\n---\n\n")},
+		Range:    &hoverRange,
+	}, nil
+}
+
+// describe renders the documentation of a violation's rule as markdown.
+func (s *Server) describe(violation sdk.Violation) string {
+	var build strings.Builder
+
+	_, _ = fmt.Fprintf(&build, "**%s** (%s): %s", violation.Code, violation.Severity, violation.Message)
+
+	r, ok := s.rules[violation.Code]
+	if ok && r.Message() != violation.Message {
+		_, _ = fmt.Fprintf(&build, "\n\n%s", r.Message())
+	}
+
+	if references := rule.References(r); ok && len(references) > 0 {
+		_, _ = fmt.Fprintf(&build, "\n\nReferences: %s", strings.Join(references, ", "))
+	}
+
+	if href := rule.DocURL(rule.Code(violation.Code)); href != "" {
+		_, _ = fmt.Fprintf(&build, "\n\n[Documentation](%s)", href)
+	}
+
+	return build.String()
+}
+
+func (s *Server) codeAction(raw json.RawMessage) (any, *responseError) {
+	var params codeActionParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return []codeAction{}, nil
+	}
+
+	uri, diagnostics := params.TextDocument.URI, params.Context.Diagnostics
+	actions := ignoreActions(uri, splitLines(doc.text), diagnostics)
+
+	return append(actions, pinActions(uri, doc.text, s.profile(uri).lock, diagnostics)...), nil
+}
+
+// toDiagnostic converts a violation to a diagnostic spanning its line.
+func toDiagnostic(lines []string, violation sdk.Violation) diagnostic {
+	diag := diagnostic{
+		Range:    lineRange(lines, violationLine(violation)),
+		Severity: toSeverity(violation.Severity),
+		Code:     violation.Code,
+		Source:   serverName,
+		Message:  violation.Message,
+	}
+
+	if href := rule.DocURL(rule.Code(violation.Code)); href != "" {
+		diag.CodeDescription = &codeDescription{Href: href}
+	}
+
+	return diag
+}
+
+// violationLine returns the 0-based line of a violation. File-level
+// violations (line 0) are pinned to the first line.
+func violationLine(violation sdk.Violation) int {
+	return max(violation.Line-1, 0)
+}
+
+func toSeverity(severity sdk.Severity) int {
+	switch severity {
+	case sdk.SeverityError:
+		return severityError
+	case sdk.SeverityWarning:
+		return severityWarning
+	case sdk.SeverityInfo:
+		return severityInformation
+	case sdk.SeverityStyle:
+		return severityHint
+	default:
+		return severityInformation
+	}
+}
+
+// lineRange spans the whole of a 0-based line, measured in UTF-16 code units
+// as the specification requires.
+func lineRange(lines []string, line int) lspRange {
+	end := 0
+	if line < len(lines) {
+		end = utf16Len(lines[line])
+	}
+
+	return lspRange{
+		Start: position{Line: line, Character: 0},
+		End:   position{Line: line, Character: end},
+	}
+}
+
+func utf16Len(text string) int {
+	length := 0
+
+	for _, char := range text {
+		length++
+		if char > 0xFFFF {
+			length++
+		}
+	}
+
+	return length
+}
+
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return lines
+}
+
+// uriToPath converts a file:// URI to a local path, or returns "" for any
+// other scheme.
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return ""
+	}
+
+	path := parsed.Path
+	// file:///C:/dir -> C:/dir
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+
+	return filepath.FromSlash(path)
+}
+
+func decodeParams(raw json.RawMessage, target any) *responseError {
+	if len(raw) == 0 {
+		return &responseError{Code: codeInvalidRequest, Message: "missing params"}
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
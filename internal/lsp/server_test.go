@@ -0,0 +1,566 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/lsp"
+)
+
+// session scripts a client: requests are queued, then served in one go.
+type session struct {
+	input  bytes.Buffer
+	nextID int
+}
+
+func (s *session) request(t *testing.T, method string, params any) int {
+	t.Helper()
+
+	s.nextID++
+	s.send(t, map[string]any{"jsonrpc": "2.0", "id": s.nextID, "method": method, "params": params})
+
+	return s.nextID
+}
+
+func (s *session) notify(t *testing.T, method string, params any) {
+	t.Helper()
+
+	s.send(t, map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (s *session) send(t *testing.T, msg map[string]any) {
+	t.Helper()
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("encoding message: %v", err)
+	}
+
+	_, _ = fmt.Fprintf(&s.input, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+type received struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// run serves the queued messages and decodes every message sent back.
+func (s *session) run(t *testing.T, opts lsp.Options) []received {
+	t.Helper()
+
+	var output bytes.Buffer
+	if err := lsp.NewServer(opts).Serve(t.Context(), &s.input, &output); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	return decode(t, &output)
+}
+
+// decode decodes the messages a server wrote.
+func decode(t *testing.T, output io.Reader) []received {
+	t.Helper()
+
+	reader := bufio.NewReader(output)
+
+	var messages []received
+
+	for {
+		header, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			return messages
+		}
+
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		if err != nil {
+			t.Fatalf("bad header %q: %v", header, err)
+		}
+
+		_, _ = reader.ReadString('\n')
+
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			t.Fatalf("reading body: %v", err)
+		}
+
+		var msg received
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("decoding %s: %v", body, err)
+		}
+
+		messages = append(messages, msg)
+	}
+}
+
+func response(t *testing.T, messages []received, id int, target any) {
+	t.Helper()
+
+	for _, msg := range messages {
+		if msg.ID != nil && *msg.ID == id {
+			if len(msg.Error) > 0 {
+				t.Fatalf("request %d failed: %s", id, msg.Error)
+			}
+
+			if err := json.Unmarshal(msg.Result, target); err != nil {
+				t.Fatalf("decoding result of request %d: %v", id, err)
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("no response to request %d", id)
+}
+
+type diagnostic struct {
+	Range struct {
+		Start struct {
+			Line int `json:"line"`
+		} `json:"start"`
+	} `json:"range"`
+	Code            string `json:"code"`
+	Source          string `json:"source"`
+	CodeDescription *struct {
+		Href string `json:"href"`
+	} `json:"codeDescription"`
+}
+
+func diagnosticsFor(t *testing.T, messages []received, uri string) [][]diagnostic {
+	t.Helper()
+
+	var published [][]diagnostic
+
+	for _, msg := range messages {
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var params struct {
+			URI         string       `json:"uri"`
+			Diagnostics []diagnostic `json:"diagnostics"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			t.Fatalf("decoding diagnostics: %v", err)
+		}
+
+		if params.URI == uri {
+			published = append(published, params.Diagnostics)
+		}
+	}
+
+	return published
+}
+
+func hasDiagnostic(diagnostics []diagnostic, code string, line int) bool {
+	for _, diag := range diagnostics {
+		if diag.Code == code && diag.Range.Start.Line == line {
+			return true
+		}
+	}
+
+	return false
+}
+
+// INTENTION: opening and editing a document publishes its violations, with
+// ranges and documentation links, and hover shows the rule documentation.
+func TestServer_DiagnosticsAndHover(t *testing.T) {
+	t.Parallel()
+
+	const uri = "untitled:Dockerfile"
+
+	client := &session{}
+	client.request(t, "initialize", map[string]any{})
+	client.notify(t, "initialized", map[string]any{})
+	client.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "dockerfile", "version": 1, "text": "FROM debian:latest\n"},
+	})
+	client.notify(t, "textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": "FROM debian:12\nWORKDIR app\n"}},
+	})
+	hoverID := client.request(t, "textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": 1, "character": 3},
+	})
+	client.request(t, "shutdown", nil)
+	client.notify(t, "exit", nil)
+
+	messages := client.run(t, lsp.Options{})
+
+	published := diagnosticsFor(t, messages, uri)
+	if len(published) != 2 {
+		t.Fatalf("published %d diagnostic sets, want 2 (open, change)", len(published))
+	}
+
+	if !hasDiagnostic(published[0], "DL3007", 0) {
+		t.Errorf("didOpen diagnostics = %+v, want DL3007 on line 0", published[0])
+	}
+
+	if hasDiagnostic(published[1], "DL3007", 0) || !hasDiagnostic(published[1], "DL3000", 1) {
+		t.Errorf("didChange diagnostics = %+v, want DL3000 on line 1 only", published[1])
+	}
+
+	for _, diag := range published[1] {
+		if diag.Source != "godolint" || diag.CodeDescription == nil || diag.CodeDescription.Href == "" {
+			t.Errorf("diagnostic %+v lacks its source or documentation link", diag)
+		}
+	}
+
+	var hover struct {
+		Contents struct {
+			Value string `json:"value"`
+		} `json:"contents"`
+	}
+
+	response(t, messages, hoverID, &hover)
+
+	if !strings.Contains(hover.Contents.Value, "DL3000") ||
+		!strings.Contains(hover.Contents.Value, "hadolint/wiki/DL3000") {
+		t.Errorf("hover = %q, want DL3000 documentation", hover.Contents.Value)
+	}
+}
+
+// INTENTION: the rule families of the options are enabled, and hover cites
+// the guidelines of the rules citing any.
+func TestServer_RuleFamiliesAndReferences(t *testing.T) {
+	t.Parallel()
+
+	const uri = "untitled:Dockerfile"
+
+	client := &session{}
+	client.request(t, "initialize", map[string]any{})
+	client.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": "FROM debian:12\nRUN chmod 4755 /usr/local/bin/tool\n"},
+	})
+	hoverID := client.request(t, "textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": 1, "character": 3},
+	})
+	client.request(t, "shutdown", nil)
+	client.notify(t, "exit", nil)
+
+	messages := client.run(t, lsp.Options{RuleFamilies: []string{"privileges"}})
+
+	published := diagnosticsFor(t, messages, uri)
+	if len(published) != 1 || !hasDiagnostic(published[0], "GD6002", 1) {
+		t.Fatalf("diagnostics = %+v, want GD6002 on line 1", published)
+	}
+
+	var hover struct {
+		Contents struct {
+			Value string `json:"value"`
+		} `json:"contents"`
+	}
+
+	response(t, messages, hoverID, &hover)
+
+	if !strings.Contains(hover.Contents.Value, "References: CIS Docker Benchmark 4.8") {
+		t.Errorf("hover = %q, want the CIS Docker Benchmark reference of GD6002", hover.Contents.Value)
+	}
+}
+
+// INTENTION: code actions offer line and file ignore pragmas, extending an
+// existing line pragma and keeping parser directives first.
+func TestServer_IgnoreCodeActions(t *testing.T) {
+	t.Parallel()
+
+	const uri = "untitled:Dockerfile"
+
+	text := "# syntax=docker/dockerfile:1\nFROM debian:12\n# hadolint ignore=DL3008\n  WORKDIR app\n"
+
+	client := &session{}
+	client.request(t, "initialize", map[string]any{})
+	client.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": text},
+	})
+	actionID := client.request(t, "textDocument/codeAction", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"range": map[string]any{
+			"start": map[string]any{"line": 3, "character": 0},
+			"end":   map[string]any{"line": 3, "character": 0},
+		},
+		"context": map[string]any{"diagnostics": []map[string]any{{
+			"range": map[string]any{
+				"start": map[string]any{"line": 3, "character": 0},
+				"end":   map[string]any{"line": 3, "character": 13},
+			},
+			"code":    "DL3000",
+			"source":  "godolint",
+			"message": "Use absolute WORKDIR",
+		}}},
+	})
+	client.request(t, "shutdown", nil)
+	client.notify(t, "exit", nil)
+
+	var actions []struct {
+		Title string `json:"title"`
+		Edit  struct {
+			Changes map[string][]struct {
+				Range struct {
+					Start struct {
+						Line      int `json:"line"`
+						Character int `json:"character"`
+					} `json:"start"`
+				} `json:"range"`
+				NewText string `json:"newText"`
+			} `json:"changes"`
+		} `json:"edit"`
+	}
+
+	response(t, client.run(t, lsp.Options{}), actionID, &actions)
+
+	if len(actions) != 2 {
+		t.Fatalf("got %d code actions, want 2: %+v", len(actions), actions)
+	}
+
+	line := actions[0].Edit.Changes[uri][0]
+	if line.NewText != ",DL3000" || line.Range.Start.Line != 2 || line.Range.Start.Character != 24 {
+		t.Errorf("line ignore edit = %+v, want ',DL3000' appended to the pragma on line 2", line)
+	}
+
+	global := actions[1].Edit.Changes[uri][0]
+	if global.NewText != "# hadolint global ignore=DL3000\n" || global.Range.Start.Line != 1 {
+		t.Errorf("file ignore edit = %+v, want a global pragma below the syntax directive", global)
+	}
+}
+
+// INTENTION: documents are linted with the configuration file of their
+// workspace.
+func TestServer_HonorsWorkspaceConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".hadolint.yaml"), []byte("ignored: [DL3007]\n"), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	uri := "file://" + filepath.ToSlash(filepath.Join(root, "Dockerfile"))
+
+	client := &session{}
+	client.request(t, "initialize", map[string]any{"rootUri": "file://" + filepath.ToSlash(root)})
+	client.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": "FROM debian:latest\nWORKDIR app\n"},
+	})
+	client.request(t, "shutdown", nil)
+	client.notify(t, "exit", nil)
+
+	published := diagnosticsFor(t, client.run(t, lsp.Options{}), uri)
+	if len(published) != 1 {
+		t.Fatalf("published %d diagnostic sets, want 1", len(published))
+	}
+
+	if hasDiagnostic(published[0], "DL3007", 0) {
+		t.Error("DL3007 reported, but the workspace configuration ignores it")
+	}
+
+	if !hasDiagnostic(published[0], "DL3000", 1) {
+		t.Errorf("diagnostics = %+v, want DL3000", published[0])
+	}
+}
+
+// INTENTION: the configuration is read once, and again when the client
+// reports changed files.
+func TestServer_ReloadsWatchedConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	configPath := filepath.Join(root, ".hadolint.yaml")
+
+	if err := os.WriteFile(configPath, []byte("ignored: [DL3007]\n"), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	uri := "file://" + filepath.ToSlash(filepath.Join(root, "Dockerfile"))
+	text := "FROM debian:latest\n"
+
+	opened := &session{}
+	opened.request(t, "initialize", map[string]any{
+		"rootUri": "file://" + filepath.ToSlash(root),
+		"capabilities": map[string]any{"workspace": map[string]any{
+			"didChangeWatchedFiles": map[string]any{"dynamicRegistration": true},
+		}},
+	})
+	opened.notify(t, "initialized", map[string]any{})
+	opened.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": text},
+	})
+
+	// The client acknowledges the registration once the configuration
+	// changed on disk.
+	changed := &session{}
+	changed.send(t, map[string]any{"jsonrpc": "2.0", "id": 1, "result": nil})
+	changed.notify(t, "textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri},
+		"contentChanges": []map[string]any{{"text": text}},
+	})
+	changed.notify(t, "workspace/didChangeWatchedFiles", map[string]any{
+		"changes": []map[string]any{{"uri": "file://" + filepath.ToSlash(configPath), "type": 2}},
+	})
+	changed.request(t, "shutdown", nil)
+	changed.notify(t, "exit", nil)
+
+	input := io.MultiReader(&opened.input, onRead(func() {
+		if err := os.WriteFile(configPath, []byte("ignored: []\n"), 0o600); err != nil {
+			t.Errorf("writing config: %v", err)
+		}
+	}), &changed.input)
+
+	var output bytes.Buffer
+	if err := lsp.NewServer(lsp.Options{}).Serve(t.Context(), input, &output); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	messages := decode(t, &output)
+
+	if len(messages) < 2 || messages[1].Method != "client/registerCapability" {
+		t.Fatalf("messages = %+v, want the configuration watcher registered after initialize", messages)
+	}
+
+	published := diagnosticsFor(t, messages, uri)
+	if len(published) != 3 {
+		t.Fatalf("published %d diagnostic sets, want 3", len(published))
+	}
+
+	if hasDiagnostic(published[1], "DL3007", 0) {
+		t.Error("DL3007 reported on change, but the configuration is only read again once reported changed")
+	}
+
+	if !hasDiagnostic(published[2], "DL3007", 0) {
+		t.Errorf("diagnostics after the configuration changed = %+v, want DL3007", published[2])
+	}
+}
+
+// onRead is a reader running fn once it is read, with nothing to read.
+type onRead func()
+
+func (fn onRead) Read([]byte) (int, error) {
+	fn()
+
+	return 0, io.EOF
+}
+
+// INTENTION: a GD8005 diagnostic offers to pin its image to the digest of the
+// lock file, as `godolint pin` does.
+func TestServer_PinCodeAction(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	lock := []byte("python:3.12 " + digest + "\n")
+	if err := os.WriteFile(filepath.Join(root, "godolint.lock"), lock, 0o600); err != nil {
+		t.Fatalf("writing lock: %v", err)
+	}
+
+	cfg := []byte("images:\n  lock-file: godolint.lock\n")
+	if err := os.WriteFile(filepath.Join(root, ".hadolint.yaml"), cfg, 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	uri := "file://" + filepath.ToSlash(filepath.Join(root, "Dockerfile"))
+	diagnostic := func(line int) map[string]any {
+		return map[string]any{
+			"range": map[string]any{
+				"start": map[string]any{"line": line, "character": 0},
+				"end":   map[string]any{"line": line, "character": 16},
+			},
+			"code":    "GD8005",
+			"source":  "godolint",
+			"message": "Pin the image by digest",
+		}
+	}
+
+	client := &session{}
+	client.request(t, "initialize", map[string]any{"rootUri": "file://" + filepath.ToSlash(root)})
+	client.notify(t, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": "FROM python:3.12\nFROM node:22\n"},
+	})
+	actionID := client.request(t, "textDocument/codeAction", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"range": map[string]any{
+			"start": map[string]any{"line": 0, "character": 0},
+			"end":   map[string]any{"line": 1, "character": 0},
+		},
+		"context": map[string]any{"diagnostics": []map[string]any{diagnostic(0), diagnostic(1)}},
+	})
+	client.request(t, "shutdown", nil)
+	client.notify(t, "exit", nil)
+
+	var actions []struct {
+		Title string `json:"title"`
+		Edit  struct {
+			Changes map[string][]struct {
+				Range struct {
+					Start struct {
+						Line int `json:"line"`
+					} `json:"start"`
+				} `json:"range"`
+				NewText string `json:"newText"`
+			} `json:"changes"`
+		} `json:"edit"`
+	}
+
+	messages := client.run(t, lsp.Options{})
+	response(t, messages, actionID, &actions)
+
+	if published := diagnosticsFor(t, messages, uri); len(published) != 1 || !hasDiagnostic(published[0], "GD8005", 0) {
+		t.Fatalf("diagnostics = %+v, want GD8005 with the lock file of the configuration", published)
+	}
+
+	var pins []string
+
+	for _, action := range actions {
+		if !strings.HasPrefix(action.Title, "Pin ") {
+			continue
+		}
+
+		pins = append(pins, action.Title)
+
+		edit := action.Edit.Changes[uri]
+		if len(edit) != 1 || edit[0].Range.Start.Line != 0 || edit[0].NewText != "FROM python:3.12@"+digest {
+			t.Errorf("pin edit = %+v, want line 0 pinned to the lock's digest", edit)
+		}
+	}
+
+	if len(pins) != 1 {
+		t.Errorf("pin actions = %v, want one, for the image the lock has", pins)
+	}
+}
+
+// INTENTION: a Content-Length beyond any document fails the stream instead
+// of allocating it.
+func TestServer_ContentLengthLimit(t *testing.T) {
+	t.Parallel()
+
+	input := strings.NewReader("Content-Length: 9999999999\r\n\r\n{}")
+
+	err := lsp.NewServer(lsp.Options{}).Serve(t.Context(), input, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("Serve() error = %v, want the message rejected as too large", err)
+	}
+}
+
+// INTENTION: exit without a prior shutdown is reported, so the CLI can exit
+// non-zero as the specification requires.
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	t.Parallel()
+
+	client := &session{}
+	client.notify(t, "exit", nil)
+
+	err := lsp.NewServer(lsp.Options{}).Serve(t.Context(), &client.input, io.Discard)
+	if !errors.Is(err, lsp.ErrExitWithoutShutdown) {
+		t.Errorf("Serve() error = %v, want ErrExitWithoutShutdown", err)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 
 	"github.com/farcloser/godolint/internal/syntax"
@@ -48,8 +50,9 @@ func (*BuildkitParser) Parse(dockerfile []byte) ([]syntax.InstructionPos, error)
 		return nil, fmt.Errorf("failed to parse Dockerfile: %w", err)
 	}
 
-	// Convert buildkit AST to our AST format
-	var instructions []syntax.InstructionPos
+	// Convert buildkit AST to our AST format, after the directives buildkit
+	// consumed.
+	instructions := parseDirectives(dockerfile)
 
 	for _, child := range result.AST.Children {
 		// First, add any preceding comments as Comment instructions
@@ -66,6 +69,7 @@ func (*BuildkitParser) Parse(dockerfile []byte) ([]syntax.InstructionPos, error)
 			instructions = append(instructions, syntax.InstructionPos{
 				Instruction: &syntax.Comment{Text: text},
 				LineNumber:  commentLine,
+				EndLine:     commentLine,
 			})
 		}
 
@@ -76,9 +80,12 @@ func (*BuildkitParser) Parse(dockerfile []byte) ([]syntax.InstructionPos, error)
 		}
 
 		if instr != nil {
+			instr.Raw().EmptyContinuation = hasEmptyContinuation(result.Warnings, child)
+
 			instructions = append(instructions, syntax.InstructionPos{
 				Instruction: instr,
 				LineNumber:  child.StartLine,
+				EndLine:     child.EndLine,
 			})
 		}
 	}
@@ -86,8 +93,77 @@ func (*BuildkitParser) Parse(dockerfile []byte) ([]syntax.InstructionPos, error)
 	return instructions, nil
 }
 
-// convertNode converts a buildkit AST node to our Instruction type.
+// directiveRegex matches a parser directive line, as buildkit reads them.
+var directiveRegex = regexp.MustCompile(`^#\s*([a-zA-Z][a-zA-Z0-9]*)\s*=\s*(.+?)\s*$`)
+
+// parseDirectives returns the parser directives at the top of the
+// Dockerfile. Like buildkit, it stops at the first line that is not a known
+// directive, blank lines included; buildkit keeps later ones as comments.
+func parseDirectives(dockerfile []byte) []syntax.InstructionPos {
+	var directives []syntax.InstructionPos
+
+	text := strings.TrimPrefix(string(dockerfile), "\ufeff")
+
+	for i, line := range strings.Split(text, "\n") {
+		match := directiveRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil {
+			break
+		}
+
+		key := strings.ToLower(match[1])
+		if key != "escape" && key != "syntax" && key != "check" {
+			break
+		}
+
+		directives = append(directives, syntax.InstructionPos{
+			Instruction: &syntax.Directive{Key: key, Value: match[2]},
+			LineNumber:  i + 1,
+			EndLine:     i + 1,
+		})
+	}
+
+	return directives
+}
+
+// hasEmptyContinuation reports whether buildkit warned about an empty
+// continuation line in the instruction of node.
+func hasEmptyContinuation(warnings []parser.Warning, node *parser.Node) bool {
+	for _, warning := range warnings {
+		if warning.Location != nil && warning.URL == linter.RuleNoEmptyContinuation.URL &&
+			warning.Location.Start.Line >= node.StartLine && warning.Location.Start.Line <= node.EndLine {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convertNode converts a buildkit AST node to our Instruction type, along
+// with its raw source.
 func convertNode(node *parser.Node) (syntax.Instruction, error) {
+	instr, err := convertInstruction(node)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := syntax.RawSource{
+		Keyword:  node.Value,
+		Original: node.Original,
+		Flags:    node.Flags,
+		Comments: node.PrevComment,
+	}
+
+	for n := node.Next; n != nil; n = n.Next {
+		raw.Args = append(raw.Args, n.Value)
+	}
+
+	instr.Raw().SetRaw(raw)
+
+	return instr, nil
+}
+
+// convertInstruction converts the fields of a buildkit AST node.
+func convertInstruction(node *parser.Node) (syntax.Instruction, error) {
 	switch strings.ToLower(node.Value) {
 	case "from":
 		return convertFrom(node)
@@ -203,7 +279,7 @@ func convertFrom(node *parser.Node) (*syntax.From, error) {
 
 	// Check for AS alias (last token after AS keyword)
 	for i := 1; i < len(values); i++ {
-		if values[i] == "AS" || values[i] == "as" {
+		if strings.EqualFold(values[i], "AS") {
 			if i+1 < len(values) {
 				alias := values[i+1]
 				baseImage.Alias = &alias
@@ -260,10 +336,19 @@ func convertRun(node *parser.Node) (*syntax.Run, error) {
 		command += n.Value
 	}
 
-	return &syntax.Run{
+	run := &syntax.Run{
 		Command: command,
 		Flags:   node.Flags,
-	}, nil
+	}
+
+	for _, heredoc := range node.Heredocs {
+		run.Heredocs = append(run.Heredocs, syntax.Heredoc{
+			Name:    heredoc.Name,
+			Content: heredoc.Content,
+		})
+	}
+
+	return run, nil
 }
 
 func convertCopy(node *parser.Node) (*syntax.Copy, error) {
@@ -295,10 +380,19 @@ func convertAdd(node *parser.Node) (*syntax.Add, error) {
 		return nil, ErrAddMissingArgs
 	}
 
-	return &syntax.Add{
+	addInstr := &syntax.Add{
 		Source:      values[:len(values)-1],
 		Destination: values[len(values)-1],
-	}, nil
+	}
+
+	for _, flag := range node.Flags {
+		if after, ok := strings.CutPrefix(flag, "--checksum="); ok {
+			checksum := after
+			addInstr.Checksum = &checksum
+		}
+	}
+
+	return addInstr, nil
 }
 
 //nolint:unparam // Uniform signature with other converters for consistent error handling
@@ -512,8 +606,9 @@ func convertOnBuild(node *parser.Node) (*syntax.OnBuild, error) {
 		return nil, ErrOnBuildNoInnerInstruction
 	}
 
-	// Convert the first (and only) child instruction
-	inner, err := convertNode(innerResult.AST.Children[0])
+	// Convert the first (and only) child instruction. It is left without raw
+	// source: BuildKit only dispatches triggers in the builds of child images.
+	inner, err := convertInstruction(innerResult.AST.Children[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert ONBUILD inner instruction: %w", err)
 	}
@@ -0,0 +1,172 @@
+// Package pin pins the images of a Dockerfile to the digests of a lock
+// file, rewriting FROM image:tag to FROM image:tag@sha256:..., and verifies
+// the digests already there. It needs no network: any tool can produce the
+// lock (see image.ParseLock).
+package pin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/parser"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// Status is the outcome of pinning an image.
+type Status string
+
+// Outcomes of pinning an image.
+const (
+	// StatusPinned is an image the lock's digest was added to.
+	StatusPinned Status = "pinned"
+	// StatusVerified is an image whose digest matches the lock.
+	StatusVerified Status = "verified"
+	// StatusMismatch is an image whose digest differs from the lock's.
+	StatusMismatch Status = "mismatch"
+	// StatusUnlocked is an image the lock has no digest for.
+	StatusUnlocked Status = "unlocked"
+)
+
+// Result is the outcome of pinning an image of FROM or COPY --from.
+type Result struct {
+	Line      int    // Line of the instruction
+	Reference string // Image as written
+	Digest    string // Digest of the lock, "" when unlocked
+	Status    Status
+}
+
+// Pin rewrites the images of a Dockerfile with the digests of the lock,
+// and returns the rewritten Dockerfile with the outcome for each image.
+// Images pinned by digest are verified, never rewritten; earlier stages,
+// scratch and references with variables are left alone.
+func Pin(dockerfile []byte, lock image.Lock) ([]byte, []Result, error) {
+	instructions, err := parser.NewBuildkitParser().Parse(dockerfile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	lines := bytes.SplitAfter(dockerfile, []byte("\n"))
+	stages := make(map[string]bool)
+
+	var results []Result
+
+	for _, pos := range instructions {
+		written, flag := pinnable(pos.Instruction, stages)
+		if written == "" {
+			continue
+		}
+
+		result := pinImage(written, lock)
+		result.Line = pos.LineNumber
+
+		if result.Status == StatusPinned {
+			// Untagged images are pinned as :latest, the tag the lock
+			// has them under, so that the pin verifies later on.
+			start, end := pos.Span()
+			rewrite(lines[start-1:end], flag+written, flag+image.Parse(written).String()+"@"+result.Digest)
+		}
+
+		results = append(results, result)
+	}
+
+	return bytes.Join(lines, nil), results, nil
+}
+
+// pinnable returns the image an instruction uses as written, and the flag
+// introducing it (--from= for COPY), recording the stages it declares.
+// It returns "" for instructions using no external image.
+func pinnable(instruction syntax.Instruction, stages map[string]bool) (written, flag string) {
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		if raw := inst.Raw().Args; len(raw) > 0 {
+			written = raw[0]
+		}
+
+		if written == "scratch" || stages[strings.ToLower(written)] {
+			written = ""
+		}
+
+		if inst.Image.Alias != nil {
+			stages[strings.ToLower(*inst.Image.Alias)] = true
+		}
+	case *syntax.Copy:
+		if inst.From == nil || stages[strings.ToLower(*inst.From)] ||
+			strings.Trim(*inst.From, "0123456789") == "" {
+			return "", ""
+		}
+
+		written, flag = *inst.From, "--from="
+	default:
+		return "", ""
+	}
+
+	if written == "" || strings.Contains(written, "$") {
+		return "", ""
+	}
+
+	return written, flag
+}
+
+// pinImage returns the outcome of pinning an image with the lock.
+func pinImage(written string, lock image.Lock) Result {
+	ref := image.Parse(written)
+	result := Result{Reference: written, Status: StatusUnlocked}
+
+	// Images pinned by digest alone have no tag to look up.
+	locked, ok := lock.Digest(ref)
+	if !ok || ref.Tag == "" {
+		return result
+	}
+
+	result.Digest = locked
+
+	switch ref.Digest {
+	case "":
+		result.Status = StatusPinned
+	case locked:
+		result.Status = StatusVerified
+	default:
+		result.Status = StatusMismatch
+	}
+
+	return result
+}
+
+// rewrite replaces the first whitespace-delimited occurrence of a word in
+// the lines of an instruction.
+func rewrite(lines [][]byte, word, replacement string) {
+	for i, line := range lines {
+		if start := wordIndex(line, []byte(word)); start >= 0 {
+			lines[i] = bytes.Join([][]byte{line[:start], []byte(replacement), line[start+len(word):]}, nil)
+
+			return
+		}
+	}
+}
+
+// wordIndex returns the index of the first occurrence of word in line
+// delimited by whitespace or the ends of the line, or -1.
+func wordIndex(line, word []byte) int {
+	for offset := 0; offset <= len(line); {
+		index := bytes.Index(line[offset:], word)
+		if index < 0 {
+			return -1
+		}
+
+		start, end := offset+index, offset+index+len(word)
+		if (start == 0 || isSpace(line[start-1])) && (end == len(line) || isSpace(line[end])) {
+			return start
+		}
+
+		offset = start + 1
+	}
+
+	return -1
+}
+
+// isSpace reports whether a byte is whitespace in a Dockerfile line.
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
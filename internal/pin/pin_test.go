@@ -0,0 +1,128 @@
+package pin_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/pin"
+)
+
+func TestPin(t *testing.T) {
+	t.Parallel()
+
+	python := "sha256:" + strings.Repeat("a", 64)
+	tool := "sha256:" + strings.Repeat("b", 64)
+	alpine := "sha256:" + strings.Repeat("c", 64)
+
+	lock, err := image.ParseLock([]byte("python:3.12-slim " + python + "\n" +
+		"ghcr.io/acme/tool:1.4 " + tool + "\n" +
+		"alpine:3.20 " + alpine + "\n"))
+	if err != nil {
+		t.Fatalf("ParseLock() error = %v", err)
+	}
+
+	stale := "sha256:" + strings.Repeat("d", 64)
+
+	pinned, results, err := pin.Pin([]byte(`FROM --platform=$BUILDPLATFORM python:3.12-slim AS build
+COPY --from=ghcr.io/acme/tool:1.4 /bin/tool /bin/tool
+COPY --from=build /app /app
+FROM build AS test
+FROM alpine:3.20@`+alpine+`
+COPY --from=0 /app /app
+FROM alpine:3.20@`+stale+`
+FROM debian:12
+FROM scratch
+`), lock)
+	if err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+
+	want := `FROM --platform=$BUILDPLATFORM python:3.12-slim@` + python + ` AS build
+COPY --from=ghcr.io/acme/tool:1.4@` + tool + ` /bin/tool /bin/tool
+COPY --from=build /app /app
+FROM build AS test
+FROM alpine:3.20@` + alpine + `
+COPY --from=0 /app /app
+FROM alpine:3.20@` + stale + `
+FROM debian:12
+FROM scratch
+`
+	if string(pinned) != want {
+		t.Errorf("Pin() =\n%s\nwant\n%s", pinned, want)
+	}
+
+	statuses := make([]pin.Status, 0, len(results))
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+	}
+
+	wantStatuses := []pin.Status{
+		pin.StatusPinned, pin.StatusPinned, pin.StatusVerified, pin.StatusMismatch, pin.StatusUnlocked,
+	}
+	if !slices.Equal(statuses, wantStatuses) {
+		t.Errorf("Pin() statuses = %v, want %v", statuses, wantStatuses)
+	}
+
+	if results[3].Line != 7 || results[3].Digest != alpine {
+		t.Errorf("Pin() mismatch = %+v, want line 7 locked to %s", results[3], alpine)
+	}
+}
+
+func TestPin_Untagged(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	lock, err := image.ParseLock([]byte("python:latest " + digest + "\n"))
+	if err != nil {
+		t.Fatalf("ParseLock() error = %v", err)
+	}
+
+	pinned, results, err := pin.Pin([]byte("FROM python\n"), lock)
+	if err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+
+	if want := "FROM python:latest@" + digest + "\n"; string(pinned) != want {
+		t.Errorf("Pin() = %q, want %q", pinned, want)
+	}
+
+	if len(results) != 1 || results[0].Status != pin.StatusPinned {
+		t.Errorf("Pin() results = %+v, want pinned", results)
+	}
+
+	// The pinned Dockerfile verifies against the same lock.
+	_, results, err = pin.Pin(pinned, lock)
+	if err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+
+	if len(results) != 1 || results[0].Status != pin.StatusVerified {
+		t.Errorf("Pin() of the pinned Dockerfile = %+v, want verified", results)
+	}
+}
+
+func TestPin_MultiLine(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	lock, err := image.ParseLock([]byte("node:22 " + digest + "\n"))
+	if err != nil {
+		t.Fatalf("ParseLock() error = %v", err)
+	}
+
+	// The image is on a continuation line; "node:22" in the comment of an
+	// earlier line must stay untouched.
+	pinned, _, err := pin.Pin([]byte("# node:22 is the LTS\nFROM \\\n  node:22 AS base\n"), lock)
+	if err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+
+	want := "# node:22 is the LTS\nFROM \\\n  node:22@" + digest + " AS base\n"
+	if string(pinned) != want {
+		t.Errorf("Pin() = %q, want %q", pinned, want)
+	}
+}
@@ -102,8 +102,9 @@ func parseRuleList(text string) []rule.Code {
 			continue
 		}
 
-		// Validate format: DL followed by 4 digits (DL3057, SC1234, etc)
-		if len(code) >= 6 && (code[:2] == "DL" || code[:2] == "SC") {
+		// Validate format: DL, SC, GD or BK followed by 4 digits (DL3057,
+		// SC1234, GD1001, BK1001, etc)
+		if len(code) >= 6 && (code[:2] == "DL" || code[:2] == "SC" || code[:2] == "GD" || code[:2] == "BK") {
 			codes = append(codes, rule.Code(code))
 		}
 	}
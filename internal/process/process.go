@@ -30,11 +30,22 @@ func (p *Processor) WithDisableIgnorePragmas(disable bool) *Processor {
 }
 
 // Run processes a Dockerfile AST and returns all rule violations found.
-// Uses fold-style accumulation with state for each rule.
-// Ported from Hadolint's Rule fold pattern.
+// Execution errors are dropped: see RunWithErrors.
 func (p *Processor) Run(instructions []syntax.InstructionPos) []rule.CheckFailure {
+	failures, _ := p.RunWithErrors(instructions)
+
+	return failures
+}
+
+// RunWithErrors processes a Dockerfile AST and returns all rule violations
+// found, plus the execution errors of the rules, which pragmas do not
+// silence. Uses fold-style accumulation with state for each rule.
+// Ported from Hadolint's Rule fold pattern.
+func (p *Processor) RunWithErrors(instructions []syntax.InstructionPos) ([]rule.CheckFailure, []rule.ExecError) {
 	allFailures := []rule.CheckFailure{}
 
+	var allErrors []rule.ExecError
+
 	// For each rule, fold over all instructions with state
 	for _, currentRule := range p.rules {
 		state := currentRule.InitialState()
@@ -55,6 +66,11 @@ func (p *Processor) Run(instructions []syntax.InstructionPos) []rule.CheckFailur
 
 		// Collect failures from final state
 		allFailures = append(allFailures, state.Failures...)
+
+		for _, execErr := range state.Errors {
+			execErr.Code = currentRule.Code()
+			allErrors = append(allErrors, execErr)
+		}
 	}
 
 	// Filter out failures with Ignore severity (like hadolint's DLIgnoreC filter)
@@ -67,7 +83,7 @@ func (p *Processor) Run(instructions []syntax.InstructionPos) []rule.CheckFailur
 		allFailures = filterIgnored(allFailures, directives)
 	}
 
-	return allFailures
+	return allFailures, allErrors
 }
 
 // filterIgnoreSeverity removes failures with Ignore severity.
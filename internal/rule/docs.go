@@ -0,0 +1,41 @@
+package rule
+
+import "strings"
+
+// DocURL returns the documentation page for a rule code, or "" for codes
+// without one. DL codes point at the hadolint wiki, SC codes at the
+// shellcheck wiki, GD and BK codes at godolint's rule tables.
+func DocURL(code Code) string {
+	switch {
+	case strings.HasPrefix(string(code), "DL"):
+		return "https://github.com/hadolint/hadolint/wiki/" + string(code)
+	case strings.HasPrefix(string(code), "SC"):
+		return "https://www.shellcheck.net/wiki/" + string(code)
+	case strings.HasPrefix(string(code), "GD"):
+		return "https://github.com/farcloser/godolint#godolint-rules"
+	case strings.HasPrefix(string(code), "BK"):
+		return "https://github.com/farcloser/godolint#buildkit-checks"
+	default:
+		return ""
+	}
+}
+
+// References returns the external guidelines a rule cites, from its Meta;
+// rules without a Meta, such as the shellcheck integration, cite none.
+func References(r Rule) []string {
+	if cited, ok := r.(interface{ References() []string }); ok {
+		return cited.References()
+	}
+
+	return nil
+}
+
+// StageScoped reports whether the findings of a rule concern a whole stage
+// rather than the instruction they are reported on, from its Meta.
+func StageScoped(r Rule) bool {
+	if scoped, ok := r.(interface{ StageScoped() bool }); ok {
+		return scoped.StageScoped()
+	}
+
+	return false
+}
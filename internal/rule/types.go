@@ -4,11 +4,15 @@ package rule
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/farcloser/godolint/internal/syntax"
 )
 
+// ErrUnknownSeverity reports a severity name that UnmarshalJSON cannot read.
+var ErrUnknownSeverity = errors.New("unknown severity")
+
 // Severity is ported from DLSeverity in Hadolint/Rule.hs.
 type Severity int
 
@@ -48,6 +52,25 @@ func (s Severity) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
 
+// UnmarshalJSON implements json.Unmarshaler, reading MarshalJSON's output
+// back (e.g. from a results cache).
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid severity: %w", err)
+	}
+
+	for candidate := Error; candidate <= Ignore; candidate++ {
+		if candidate.String() == text {
+			*s = candidate
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownSeverity, text)
+}
+
 // Code is ported from RuleCode in Hadolint/Rule.hs.
 type Code string
 
@@ -57,6 +80,13 @@ type Meta struct {
 	Code     Code
 	Severity Severity
 	Message  string
+	// References cites the external guidelines a rule enforces, such as
+	// "CIS Docker Benchmark 4.1". Hadolint's rules have none.
+	References []string
+	// StageScoped marks rules whose findings concern a whole stage rather
+	// than the instruction they are reported on, such as a final stage
+	// that never switches USER, reported on its FROM.
+	StageScoped bool
 }
 
 // CheckFailure is ported from CheckFailure in Hadolint/Rule.hs.
@@ -70,11 +100,35 @@ type CheckFailure struct {
 	Message  string   `json:"message"`
 }
 
+// ExecError is a rule that could not check an instruction, e.g. because
+// the shellcheck binary failed. Unlike a CheckFailure, it says nothing about
+// the Dockerfile: the instruction is unchecked, not clean.
+type ExecError struct {
+	Code Code
+	Line int // 0 when the error is not about one instruction
+	Err  error
+}
+
+func (e ExecError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("rule %s failed: %v", e.Code, e.Err)
+	}
+
+	return fmt.Sprintf("rule %s failed on line %d: %v", e.Code, e.Line, e.Err)
+}
+
+func (e ExecError) Unwrap() error {
+	return e.Err
+}
+
 // State holds failures and custom state for a rule.
 // Ported from State a in Hadolint/Rule.hs.
 type State struct {
 	Failures []CheckFailure
-	Data     any // Custom state data
+	// Errors are the execution errors of the rule; the processor sets
+	// their Code.
+	Errors []ExecError
+	Data   any // Custom state data
 }
 
 // EmptyState creates a new state with no failures and the given initial data.
@@ -89,6 +143,16 @@ func EmptyState(data any) State {
 func (s State) AddFailure(failure CheckFailure) State {
 	return State{
 		Failures: append(s.Failures, failure),
+		Errors:   s.Errors,
+		Data:     s.Data,
+	}
+}
+
+// AddError records that the rule could not check the instruction at line.
+func (s State) AddError(line int, err error) State {
+	return State{
+		Failures: s.Failures,
+		Errors:   append(s.Errors, ExecError{Line: line, Err: err}),
 		Data:     s.Data,
 	}
 }
@@ -97,6 +161,7 @@ func (s State) AddFailure(failure CheckFailure) State {
 func (s State) ReplaceData(data any) State {
 	return State{
 		Failures: s.Failures,
+		Errors:   s.Errors,
 		Data:     data,
 	}
 }
@@ -143,10 +208,11 @@ type Rule interface {
 
 // SimpleRule is ported from simpleRule in Hadolint/Rule.hs.
 type SimpleRule struct {
-	code     Code
-	severity Severity
-	message  string
-	checker  func(syntax.Instruction) bool
+	code       Code
+	severity   Severity
+	message    string
+	checker    func(syntax.Instruction) bool
+	references []string
 }
 
 // NewSimpleRule creates a new simple rule.
@@ -179,6 +245,18 @@ func (r *SimpleRule) Message() string {
 	return r.message
 }
 
+// WithReferences sets the external guidelines the rule cites.
+func (r *SimpleRule) WithReferences(references ...string) *SimpleRule {
+	r.references = references
+
+	return r
+}
+
+// References returns the external guidelines the rule cites.
+func (r *SimpleRule) References() []string {
+	return r.references
+}
+
 // InitialState returns an empty state for stateless rules.
 func (*SimpleRule) InitialState() State {
 	return EmptyState(nil)
@@ -231,6 +309,16 @@ func (b *StatefulRuleBase) Message() string {
 	return b.meta.Message
 }
 
+// References returns the external guidelines the rule cites.
+func (b *StatefulRuleBase) References() []string {
+	return b.meta.References
+}
+
+// StageScoped reports whether the rule's findings concern a whole stage.
+func (b *StatefulRuleBase) StageScoped() bool {
+	return b.meta.StageScoped
+}
+
 // Finalize performs final checks after processing all instructions.
 // Default implementation does nothing. Override in rules that need custom finalization.
 func (*StatefulRuleBase) Finalize(state State) State {
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1001Meta contains metadata for rule BK1001.
+var BK1001Meta = rule.Meta{
+	Code:       "BK1001",
+	Severity:   rule.Warning,
+	Message:    "Stage names should be lowercase",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/stage-name-casing/"},
+}
@@ -0,0 +1,42 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1001Rule reports a stage name that is not lowercase: BuildKit
+// lowercases it, so it is not referenced as written.
+type BK1001Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1001 creates the rule reporting stage names not in lowercase.
+func BK1001() rule.Rule {
+	return &BK1001Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1001Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1001Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the FROM naming its stage with uppercase letters.
+func (*BK1001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	from, ok := instruction.(*syntax.From)
+	if !ok || !dispatched(from) || from.Image.Alias == nil {
+		return state
+	}
+
+	if name := *from.Image.Alias; name != strings.ToLower(name) {
+		return state.AddFailure(bkFailure(BK1001Meta, line, linter.RuleStageNameCasing.Format(name)))
+	}
+
+	return state
+}
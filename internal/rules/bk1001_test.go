@@ -0,0 +1,65 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1001(),
+	}
+
+	t.Run("uppercase stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS Builder
+RUN true`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1001")
+	})
+
+	t.Run("lowercase stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS builder
+RUN true`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1001")
+	})
+
+	t.Run("lowercase as keyword", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine as BUILD"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1001")
+	})
+
+	t.Run("no stage name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1001")
+	})
+
+	t.Run("onbuild", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ONBUILD FROM alpine AS Inner`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1001")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1002Meta contains metadata for rule BK1002.
+var BK1002Meta = rule.Meta{
+	Code:       "BK1002",
+	Severity:   rule.Warning,
+	Message:    "The 'as' keyword should match the case of the 'from' keyword",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/from-as-casing/"},
+}
@@ -0,0 +1,56 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1002Rule reports a FROM whose AS keyword is not in the casing of the
+// FROM keyword (FROM alpine as build). A FROM keyword in mixed casing is
+// BK1003's.
+type BK1002Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1002 creates the rule reporting AS keywords cased unlike FROM.
+func BK1002() rule.Rule {
+	return &BK1002Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1002Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1002Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check compares the casing of the FROM and AS keywords.
+func (*BK1002Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	from, ok := instruction.(*syntax.From)
+	if !ok || !dispatched(from) || len(from.Raw().Args) < 3 {
+		return state
+	}
+
+	fromKeyword, as := from.Raw().Keyword, from.Raw().Args[1]
+
+	var matches bool
+
+	switch {
+	case fromKeyword == strings.ToLower(fromKeyword):
+		matches = as == strings.ToLower(as)
+	case fromKeyword == strings.ToUpper(fromKeyword):
+		matches = as == strings.ToUpper(as)
+	default:
+		matches = true
+	}
+
+	if !matches {
+		return state.AddFailure(bkFailure(BK1002Meta, line, linter.RuleFromAsCasing.Format(fromKeyword, as)))
+	}
+
+	return state
+}
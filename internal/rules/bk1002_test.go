@@ -0,0 +1,71 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1002(),
+	}
+
+	t.Run("lowercase as", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine as build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1002")
+	})
+
+	t.Run("uppercase AS with lowercase from", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "from alpine AS build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1002")
+	})
+
+	t.Run("matching upper", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine AS build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1002")
+	})
+
+	t.Run("matching lower", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "from alpine as build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1002")
+	})
+
+	t.Run("mixed from keyword", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "From alpine as build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1002")
+	})
+
+	t.Run("no stage name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1002")
+	})
+}
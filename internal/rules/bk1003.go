@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1003Meta contains metadata for rule BK1003.
+var BK1003Meta = rule.Meta{
+	Code:       "BK1003",
+	Severity:   rule.Warning,
+	Message:    "All commands within the Dockerfile should use the same casing (either upper or lower)",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/consistent-instruction-casing/"},
+}
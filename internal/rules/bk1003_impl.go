@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// casedKeyword is an instruction keyword as written, and its line.
+type casedKeyword struct {
+	keyword string
+	line    int
+}
+
+// bk1003State holds the keywords of the stages, in order.
+type bk1003State struct {
+	started  bool
+	keywords []casedKeyword
+}
+
+// BK1003Rule reports instruction keywords cased unlike the majority of
+// them, or in mixed casing (Run). Like BuildKit, it counts the keywords of
+// the stages, leaving out the global ARGs before the first FROM; a tie
+// makes uppercase the majority.
+type BK1003Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1003 creates the rule reporting inconsistent instruction casing.
+func BK1003() rule.Rule {
+	return &BK1003Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1003Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1003Rule) InitialState() rule.State {
+	return rule.EmptyState(bk1003State{})
+}
+
+// Check records the keyword of each instruction of the stages.
+func (*BK1003Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[bk1003State](state)
+
+	if _, ok := instruction.(*syntax.From); ok {
+		current.started = true
+	}
+
+	if !current.started || !dispatched(instruction) {
+		return state
+	}
+
+	current.keywords = append(current.keywords, casedKeyword{keyword: instruction.Raw().Keyword, line: line})
+
+	return state.ReplaceData(current)
+}
+
+// Finalize reports the keywords not in the casing of the majority.
+func (*BK1003Rule) Finalize(state rule.State) rule.State {
+	keywords := rule.Data[bk1003State](state).keywords
+
+	var lower, upper int
+
+	for _, cased := range keywords {
+		switch cased.keyword {
+		case strings.ToLower(cased.keyword):
+			lower++
+		case strings.ToUpper(cased.keyword):
+			upper++
+		}
+	}
+
+	casing, convert := "uppercase", strings.ToUpper
+	if lower > upper {
+		casing, convert = "lowercase", strings.ToLower
+	}
+
+	for _, cased := range keywords {
+		if cased.keyword != convert(cased.keyword) {
+			state = state.AddFailure(bkFailure(BK1003Meta, cased.line,
+				linter.RuleConsistentInstructionCasing.Format(cased.keyword, casing)))
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,84 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1003(),
+	}
+
+	t.Run("lowercase minority", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN true
+run true
+CMD ["sh"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1003")
+	})
+
+	t.Run("mixed casing", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+Run true`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1003")
+	})
+
+	t.Run("uppercase minority", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `from alpine
+run true
+RUN true
+cmd ["sh"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1003")
+	})
+
+	t.Run("all uppercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN true
+CMD ["sh"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1003")
+	})
+
+	t.Run("all lowercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `from alpine
+run true
+cmd ["sh"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1003")
+	})
+
+	t.Run("global arg not counted", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `arg VERSION=3.20
+FROM alpine:${VERSION}
+RUN true`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1003")
+	})
+}
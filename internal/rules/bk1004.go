@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1004Meta contains metadata for rule BK1004.
+var BK1004Meta = rule.Meta{
+	Code:       "BK1004",
+	Severity:   rule.Warning,
+	Message:    "Empty continuation lines will become errors in a future release",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/no-empty-continuation/"},
+}
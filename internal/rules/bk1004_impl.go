@@ -0,0 +1,35 @@
+package rules
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1004Rule reports an instruction continued over an empty line, which
+// BuildKit plans to reject. Comment lines within the continuation are fine.
+type BK1004Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1004 creates the rule reporting empty continuation lines.
+func BK1004() rule.Rule {
+	return &BK1004Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1004Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1004Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the instruction the parser found an empty continuation in.
+func (*BK1004Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	if instruction.Raw().EmptyContinuation {
+		return state.AddFailure(bkFailure(BK1004Meta, line, linter.RuleNoEmptyContinuation.Format()))
+	}
+
+	return state
+}
@@ -0,0 +1,52 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1004(),
+	}
+
+	t.Run("empty continuation", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN apk add \
+
+    curl`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1004")
+	})
+
+	t.Run("comment in continuation", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN apk add \
+# curl
+    curl`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1004")
+	})
+
+	t.Run("plain continuation", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN apk add \
+    curl`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1004")
+	})
+}
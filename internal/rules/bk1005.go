@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1005Meta contains metadata for rule BK1005.
+var BK1005Meta = rule.Meta{
+	Code:       "BK1005",
+	Severity:   rule.Warning,
+	Message:    "Legacy key/value format with whitespace separator should not be used",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/legacy-key-value-format/"},
+}
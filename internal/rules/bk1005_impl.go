@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1005Rule reports ENV and LABEL in the legacy "ENV key value" form,
+// which sets a single variable to the rest of the line.
+type BK1005Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1005 creates the rule reporting the legacy key/value format.
+func BK1005() rule.Rule {
+	return &BK1005Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1005Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1005Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the ENV or LABEL without = separator.
+func (*BK1005Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	switch instruction.(type) {
+	case *syntax.Env, *syntax.Label:
+	default:
+		return state
+	}
+
+	// buildkit splits the arguments into key, value and separator triples,
+	// the separator being empty in the legacy form.
+	args := instruction.Raw().Args
+	for i := 2; i < len(args); i += 3 {
+		if args[i] == "" {
+			return state.AddFailure(bkFailure(BK1005Meta, line, linter.RuleLegacyKeyValueFormat.Format(keyword(instruction))))
+		}
+	}
+
+	return state
+}
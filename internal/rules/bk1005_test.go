@@ -0,0 +1,67 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1005(),
+	}
+
+	t.Run("legacy env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV VERSION 1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1005")
+	})
+
+	t.Run("legacy label", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+LABEL maintainer someone`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1005")
+	})
+
+	t.Run("key value env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV VERSION=1.0 NAME=app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1005")
+	})
+
+	t.Run("key value label", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+LABEL version="1.0"`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1005")
+	})
+
+	t.Run("onbuild", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ONBUILD ENV VERSION 1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1005")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1006Meta contains metadata for rule BK1006.
+var BK1006Meta = rule.Meta{
+	Code:       "BK1006",
+	Severity:   rule.Warning,
+	Message:    "Variables should be defined before their use",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/undefined-var/"},
+}
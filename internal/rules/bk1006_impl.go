@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1006Rule reports variables BuildKit expands to nothing, as nothing in
+// scope defines them. Like BuildKit, it looks at the instructions it
+// expands: ENV, LABEL, ARG defaults, COPY, ADD, WORKDIR, USER, VOLUME and
+// STOPSIGNAL; RUN, CMD and ENTRYPOINT are left to the shell. FROM is BK1007's.
+// GD7001 reports the same variables, and those of RUN.
+type BK1006Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1006 creates the rule reporting undefined variables.
+func BK1006() rule.Rule {
+	return &BK1006Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1006Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1006Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// nonEnvArgs are the build arguments BuildKit reads itself, without
+// defining them in the stage.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var nonEnvArgs = []string{"BUILDKIT_SBOM_SCAN_CONTEXT", "BUILDKIT_SBOM_SCAN_STAGE"}
+
+// Check reports the undefined variables of an instruction, suggesting the
+// closest variable in scope.
+func (*BK1006Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	vars := rule.Data[buildVars](state)
+	state = state.ReplaceData(vars.apply(instruction))
+
+	if _, ok := instruction.(*syntax.From); ok {
+		return state
+	}
+
+	for _, variable := range vars.undefined(instruction) {
+		message := linter.RuleUndefinedVar.Format(variable.name, variable.suggestion)
+		state = state.AddFailure(bkFailure(BK1006Meta, line, message))
+	}
+
+	return state
+}
+
+// expandedWords returns the words of an instruction BuildKit expands
+// variables in.
+func expandedWords(instruction syntax.Instruction) []string {
+	switch inst := instruction.(type) {
+	case *syntax.Env:
+		var words []string
+		for _, declaration := range envDeclarations(inst) {
+			words = append(words, *declaration.value)
+		}
+
+		return words
+	case *syntax.Label:
+		var words []string
+
+		args := inst.Raw().Args
+		for i := 0; i+1 < len(args); i += 3 {
+			words = append(words, args[i], args[i+1])
+		}
+
+		return words
+	case *syntax.Arg:
+		var words []string
+
+		for _, declaration := range argDeclarations(inst) {
+			if declaration.value != nil {
+				words = append(words, *declaration.value)
+			}
+		}
+
+		return words
+	case *syntax.Copy:
+		return slices.Concat(flagValues(inst, "chown", "chmod"), inst.Source, []string{inst.Destination})
+	case *syntax.Add:
+		return slices.Concat(flagValues(inst, "chown", "chmod", "checksum"), inst.Source, []string{inst.Destination})
+	case *syntax.Workdir:
+		return []string{inst.Directory}
+	case *syntax.User:
+		return []string{inst.User}
+	case *syntax.Volume:
+		return inst.Volumes
+	case *syntax.StopSignal:
+		return []string{inst.Signal}
+	}
+
+	return nil
+}
+
+// flagValues returns the values of the named flags of an instruction, as
+// written (--chown=$UID).
+func flagValues(instruction syntax.Instruction, names ...string) []string {
+	var values []string
+
+	for _, flag := range instruction.Raw().Flags {
+		name, value, _ := strings.Cut(strings.TrimPrefix(flag, "--"), "=")
+		if slices.Contains(names, name) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,176 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1006(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1006(),
+	}
+
+	t.Run("undefined in copy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY $SRC /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("typo in workdir", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG APP_DIR=/app
+WORKDIR ${APPDIR}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("undefined in env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV BIN=$PREFIX/bin`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("global arg not redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM alpine
+LABEL version=$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("same env instruction", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV A=1 B=$A`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("platform arg not declared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY bin/$TARGETARCH /usr/local/bin/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+
+	t.Run("declared arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG SRC
+COPY $SRC /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("global arg redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM alpine
+ARG VERSION
+LABEL version=$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("env from earlier stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+ENV APP=/app
+FROM base
+WORKDIR $APP`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("path of base image", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV PATH=/opt/bin:$PATH`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("run left to the shell", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN echo $HOME`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("single quoted", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+LABEL description='costs $5'`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("declared platform arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG TARGETARCH
+COPY bin/$TARGETARCH /usr/local/bin/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1006")
+	})
+
+	t.Run("chown flag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY --chown=$UID app /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1006")
+	})
+}
+
+func TestBK1006_Message(t *testing.T) {
+	t.Parallel()
+
+	violations := testutils.LintDockerfile("FROM alpine\nARG APP_DIR=/app\nWORKDIR ${APPDIR}", []rule.Rule{rules.BK1006()})
+
+	want := "Usage of undefined variable '$APPDIR' (did you mean $APP_DIR?)"
+	if len(violations) != 1 || violations[0].Message != want {
+		t.Errorf("violations = %+v, want one with message %q", violations, want)
+	}
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1007Meta contains metadata for rule BK1007.
+var BK1007Meta = rule.Meta{
+	Code:       "BK1007",
+	Severity:   rule.Warning,
+	Message:    "FROM command must use declared ARGs",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/undefined-arg-in-from/"},
+}
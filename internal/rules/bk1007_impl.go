@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1007Rule reports variables of a FROM base name or --platform that no
+// global ARG declares. FROM only sees the ARGs declared before the first
+// FROM, and the platform ones (TARGETPLATFORM, BUILDARCH, ...).
+type BK1007Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1007 creates the rule reporting undeclared ARGs in FROM.
+func BK1007() rule.Rule {
+	return &BK1007Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1007Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1007Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the undeclared variables of each FROM, suggesting the
+// closest global ARG.
+func (*BK1007Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	vars := rule.Data[buildVars](state)
+	state = state.ReplaceData(vars.apply(instruction))
+
+	if _, ok := instruction.(*syntax.From); !ok {
+		return state
+	}
+
+	for _, variable := range vars.undefined(instruction) {
+		message := linter.RuleUndefinedArgInFrom.Format(variable.name, variable.suggestion)
+		state = state.AddFailure(bkFailure(BK1007Meta, line, message))
+	}
+
+	return state
+}
+
+// baseName returns the base image of a FROM as written, variables
+// unexpanded.
+func baseName(from *syntax.From) string {
+	if args := from.Raw().Args; len(args) > 0 {
+		return args[0]
+	}
+
+	name := from.Image.Image
+	if from.Image.Tag != nil {
+		name += ":" + *from.Image.Tag
+	}
+
+	if from.Image.Digest != nil {
+		name += "@" + *from.Image.Digest
+	}
+
+	return name
+}
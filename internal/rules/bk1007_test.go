@@ -0,0 +1,75 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1007(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1007(),
+	}
+
+	t.Run("undeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine:$VERSION"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1007")
+	})
+
+	t.Run("declared in stage only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG VERSION=3.20
+FROM alpine:$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1007")
+	})
+
+	t.Run("undeclared platform", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=$PLATFORM alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1007")
+	})
+
+	t.Run("declared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=3.20
+FROM alpine:$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1007")
+	})
+
+	t.Run("declared without default", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION
+FROM alpine:${VERSION:-3.20}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1007")
+	})
+
+	t.Run("platform arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=$BUILDPLATFORM golang:1.22"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1007")
+	})
+}
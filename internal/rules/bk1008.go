@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1008Meta contains metadata for rule BK1008.
+var BK1008Meta = rule.Meta{
+	Code:       "BK1008",
+	Severity:   rule.Warning,
+	Message:    "Default value for global ARG results in an empty or invalid base image name",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/invalid-default-arg-in-from/"},
+}
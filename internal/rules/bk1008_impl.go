@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1008Rule reports a FROM whose base name, expanded with the defaults of
+// the global ARGs, is empty or not a valid image reference: the build fails
+// unless the right --build-arg is given.
+type BK1008Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1008 creates the rule reporting base names invalid with default ARGs.
+func BK1008() rule.Rule {
+	return &BK1008Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1008Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1008Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check expands the base name of each FROM with the global ARG defaults.
+func (*BK1008Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	vars := rule.Data[buildVars](state)
+	state = state.ReplaceData(vars.apply(instruction))
+
+	from, ok := instruction.(*syntax.From)
+	if !ok || !dispatched(from) {
+		return state
+	}
+
+	name := baseName(from)
+	if !isImageReference(vars.expand(name, vars.fromEnv())) {
+		return state.AddFailure(bkFailure(BK1008Meta, line, linter.RuleInvalidDefaultArgInFrom.Format(name)))
+	}
+
+	return state
+}
@@ -0,0 +1,85 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1008(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1008(),
+	}
+
+	t.Run("no default", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG IMAGE
+FROM $IMAGE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1008")
+	})
+
+	t.Run("empty tag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG TAG
+FROM alpine:$TAG`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1008")
+	})
+
+	t.Run("uppercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM Alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1008")
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG IMAGE=alpine:3.20
+FROM $IMAGE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1008")
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG TAG
+FROM alpine:${TAG:-3.20}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1008")
+	})
+
+	t.Run("registry and digest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM registry.example.com:5000/team/app:1.0@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1008")
+	})
+
+	t.Run("earlier stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+FROM base`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1008")
+	})
+}
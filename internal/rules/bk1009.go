@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1009Meta contains metadata for rule BK1009.
+var BK1009Meta = rule.Meta{
+	Code:       "BK1009",
+	Severity:   rule.Warning,
+	Message:    "Relative workdir without an absolute workdir declared within the build can have unexpected results if the base image changes",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/workdir-relative-path/"},
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// bk1009State tracks whether the current stage, and each named one, set
+// WORKDIR.
+type bk1009State struct {
+	workdirSet bool
+	alias      string
+	stages     map[string]bool
+}
+
+// BK1009Rule reports the first WORKDIR of a stage when it is relative: it
+// resolves against the base image's working directory, which may change.
+// A stage built from an earlier one inherits its WORKDIR. Paths with
+// variables are not judged, and Windows rooted paths (\app) pass.
+type BK1009Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1009 creates the rule reporting relative first WORKDIRs.
+func BK1009() rule.Rule {
+	return &BK1009Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1009Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1009Rule) InitialState() rule.State {
+	return rule.EmptyState(bk1009State{})
+}
+
+// Check follows the stages, and reports a relative first WORKDIR.
+func (*BK1009Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[bk1009State](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		current.stages = maps.Clone(current.stages)
+		if current.stages == nil {
+			current.stages = make(map[string]bool)
+		}
+
+		if current.alias != "" {
+			current.stages[current.alias] = current.workdirSet
+		}
+
+		current.workdirSet = current.stages[strings.ToLower(inst.Image.Image)]
+		current.alias = ""
+
+		if inst.Image.Alias != nil {
+			current.alias = strings.ToLower(*inst.Image.Alias)
+		}
+
+		return state.ReplaceData(current)
+
+	case *syntax.Workdir:
+		if !dispatched(inst) || current.workdirSet {
+			return state
+		}
+
+		current.workdirSet = true
+		state = state.ReplaceData(current)
+
+		if !isAbsoluteWorkdir(inst.Directory, true) && !strings.Contains(inst.Directory, "$") {
+			return state.AddFailure(bkFailure(BK1009Meta, line, linter.RuleWorkdirRelativePath.Format(inst.Directory)))
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,104 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1009(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1009(),
+	}
+
+	t.Run("relative", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+WORKDIR app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1009")
+	})
+
+	t.Run("relative after run", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN true
+WORKDIR app/src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1009")
+	})
+
+	t.Run("absolute", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+WORKDIR /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1009")
+	})
+
+	t.Run("relative after absolute", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+WORKDIR /app
+WORKDIR src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1009")
+	})
+
+	t.Run("inherited from earlier stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+WORKDIR /app
+FROM base
+WORKDIR src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1009")
+	})
+
+	t.Run("new stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+WORKDIR /app
+FROM alpine
+WORKDIR src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1009")
+	})
+
+	t.Run("variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG DIR=/app
+WORKDIR $DIR`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1009")
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+WORKDIR C:\\app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1009")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1010Meta contains metadata for rule BK1010.
+var BK1010Meta = rule.Meta{
+	Code:       "BK1010",
+	Severity:   rule.Warning,
+	Message:    "Reserved words should not be used as stage names",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/reserved-stage-name/"},
+}
@@ -0,0 +1,49 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// reservedStageNames are the names BuildKit gives a meaning of its own in
+// FROM and COPY --from.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var reservedStageNames = []string{"context", "scratch"}
+
+// BK1010Rule reports a stage named after a reserved name, which FROM and
+// COPY --from would not resolve to the stage.
+type BK1010Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1010 creates the rule reporting reserved stage names.
+func BK1010() rule.Rule {
+	return &BK1010Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1010Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1010Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the FROM naming its stage with a reserved name.
+func (*BK1010Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	from, ok := instruction.(*syntax.From)
+	if !ok || !dispatched(from) || from.Image.Alias == nil {
+		return state
+	}
+
+	if name := strings.ToLower(*from.Image.Alias); slices.Contains(reservedStageNames, name) {
+		return state.AddFailure(bkFailure(BK1010Meta, line, linter.RuleReservedStageName.Format(name)))
+	}
+
+	return state
+}
@@ -0,0 +1,62 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1010(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1010(),
+	}
+
+	t.Run("scratch", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine AS scratch"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1010")
+	})
+
+	t.Run("context", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine AS context"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1010")
+	})
+
+	t.Run("uppercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine AS Context"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1010")
+	})
+
+	t.Run("regular", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine AS build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1010")
+	})
+
+	t.Run("from scratch", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM scratch"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1010")
+	})
+}
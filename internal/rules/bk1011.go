@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1011Meta contains metadata for rule BK1011.
+var BK1011Meta = rule.Meta{
+	Code:       "BK1011",
+	Severity:   rule.Warning,
+	Message:    "Protocol in EXPOSE instruction should be lowercase",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/expose-proto-casing/"},
+}
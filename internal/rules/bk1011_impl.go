@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1011Rule reports an EXPOSE protocol not in lowercase (80/TCP).
+type BK1011Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1011 creates the rule reporting EXPOSE protocols not in lowercase.
+func BK1011() rule.Rule {
+	return &BK1011Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1011Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1011Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports each port of an EXPOSE with an uppercase protocol.
+func (*BK1011Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	expose, ok := instruction.(*syntax.Expose)
+	if !ok || !dispatched(expose) {
+		return state
+	}
+
+	for _, port := range literalPorts(expose) {
+		_, _, containerPort := splitPortParts(port)
+		if _, proto, _ := strings.Cut(containerPort, "/"); proto != strings.ToLower(proto) {
+			state = state.AddFailure(bkFailure(BK1011Meta, line, linter.RuleExposeProtoCasing.Format(port)))
+		}
+	}
+
+	return state
+}
+
+// literalPorts returns the ports of an EXPOSE without variables, the ones
+// whose format is known before the build.
+func literalPorts(expose *syntax.Expose) []string {
+	var ports []string
+
+	for _, port := range expose.Ports {
+		if !strings.Contains(port, "$") {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// splitPortParts splits an [ip:][hostPort:]containerPort[/proto] port
+// specification as BuildKit does.
+func splitPortParts(port string) (ip, hostPort, containerPort string) {
+	parts := strings.Split(port, ":")
+
+	switch count := len(parts); count {
+	case 1:
+		return "", "", parts[0]
+	case 2:
+		return "", parts[0], parts[1]
+	default:
+		return strings.Join(parts[:count-2], ":"), parts[count-2], parts[count-1]
+	}
+}
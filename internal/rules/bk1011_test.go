@@ -0,0 +1,57 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1011(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1011(),
+	}
+
+	t.Run("uppercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 80/TCP`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1011")
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 53/Udp`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1011")
+	})
+
+	t.Run("lowercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 80/tcp 53/udp`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1011")
+	})
+
+	t.Run("no protocol", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 80`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1011")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1012Meta contains metadata for rule BK1012.
+var BK1012Meta = rule.Meta{
+	Code:       "BK1012",
+	Severity:   rule.Warning,
+	Message:    "IP address and host-port mapping should not be used in EXPOSE instruction. This will become an error in a future release",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/expose-invalid-format/"},
+}
@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1012Rule reports an EXPOSE with an IP address or a host port
+// (127.0.0.1:8080:80, 8080:80): EXPOSE only documents the container port,
+// publishing is up to docker run.
+type BK1012Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1012 creates the rule reporting EXPOSE host mappings.
+func BK1012() rule.Rule {
+	return &BK1012Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1012Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1012Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports each port of an EXPOSE mapping an IP address or host port.
+func (*BK1012Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	expose, ok := instruction.(*syntax.Expose)
+	if !ok || !dispatched(expose) {
+		return state
+	}
+
+	for _, port := range literalPorts(expose) {
+		if ip, hostPort, _ := splitPortParts(port); ip != "" || hostPort != "" {
+			state = state.AddFailure(bkFailure(BK1012Meta, line, linter.RuleExposeInvalidFormat.Format(port)))
+		}
+	}
+
+	return state
+}
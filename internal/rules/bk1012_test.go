@@ -0,0 +1,68 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1012(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1012(),
+	}
+
+	t.Run("host port", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 8080:80`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1012")
+	})
+
+	t.Run("ip and host port", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 127.0.0.1:8080:80/tcp`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1012")
+	})
+
+	t.Run("container port", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 80/tcp`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1012")
+	})
+
+	t.Run("range", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+EXPOSE 8000-8010`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1012")
+	})
+
+	t.Run("variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG PORT=80
+EXPOSE $PORT`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1012")
+	})
+}
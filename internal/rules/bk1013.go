@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1013Meta contains metadata for rule BK1013.
+var BK1013Meta = rule.Meta{
+	Code:       "BK1013",
+	Severity:   rule.Warning,
+	Message:    "Multiple instructions of the same type should not be used in the same stage",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/multiple-instructions-disallowed/"},
+}
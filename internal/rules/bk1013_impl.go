@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"maps"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1013Rule reports a CMD, ENTRYPOINT or HEALTHCHECK followed by another
+// in the same stage, which overrides it. Like BuildKit, it reports the
+// earlier one, the one without effect; DL4003 and DL4004 report the later.
+type BK1013Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1013 creates the rule reporting instructions overridden in their stage.
+func BK1013() rule.Rule {
+	return &BK1013Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1013Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule, the line of the
+// last CMD, ENTRYPOINT and HEALTHCHECK of the stage.
+func (*BK1013Rule) InitialState() rule.State {
+	return rule.EmptyState(map[string]int(nil))
+}
+
+// Check reports the previous instruction of the same type in the stage.
+func (*BK1013Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	switch instruction.(type) {
+	case *syntax.From:
+		return state.ReplaceData(map[string]int(nil))
+	case *syntax.Cmd, *syntax.Entrypoint, *syntax.Healthcheck:
+		if !dispatched(instruction) {
+			return state
+		}
+	default:
+		return state
+	}
+
+	seen := maps.Clone(rule.Data[map[string]int](state))
+	if seen == nil {
+		seen = make(map[string]int)
+	}
+
+	previous, overridden := seen[instruction.Name()]
+	seen[instruction.Name()] = line
+	state = state.ReplaceData(seen)
+
+	if overridden {
+		message := linter.RuleMultipleInstructionsDisallowed.Format(keyword(instruction))
+		state = state.AddFailure(bkFailure(BK1013Meta, previous, message))
+	}
+
+	return state
+}
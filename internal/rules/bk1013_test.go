@@ -0,0 +1,84 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1013(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1013(),
+	}
+
+	t.Run("two cmd", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+CMD ["a"]
+CMD ["b"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1013")
+	})
+
+	t.Run("two entrypoint", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENTRYPOINT ["a"]
+ENTRYPOINT ["b"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1013")
+	})
+
+	t.Run("two healthcheck", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+HEALTHCHECK CMD true
+HEALTHCHECK NONE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1013")
+	})
+
+	t.Run("different stages", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS build
+CMD ["a"]
+FROM alpine
+CMD ["b"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1013")
+	})
+
+	t.Run("cmd and entrypoint", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENTRYPOINT ["a"]
+CMD ["b"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1013")
+	})
+
+	t.Run("onbuild", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+CMD ["a"]
+ONBUILD CMD ["b"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1013")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1014Meta contains metadata for rule BK1014.
+var BK1014Meta = rule.Meta{
+	Code:       "BK1014",
+	Severity:   rule.Warning,
+	Message:    "FROM --platform flag should not use a constant value",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/from-platform-flag-const-disallowed/"},
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// platformOSes are the operating systems a one-part --platform may name;
+// other values name an architecture.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var platformOSes = []string{"linux", "windows", "darwin", "freebsd", "netbsd", "openbsd", "solaris", "illumos", "aix"}
+
+// platformArchAliases are the architecture spellings normalized by
+// BuildKit's platform parsing.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var platformArchAliases = map[string]string{
+	"x86_64":  "amd64",
+	"x86-64":  "amd64",
+	"aarch64": "arm64",
+	"i386":    "386",
+	"i686":    "386",
+	"armhf":   "arm",
+	"armel":   "arm",
+}
+
+// BK1014Rule reports a FROM --platform set to a constant, which makes a
+// multi-platform build use the same base image for every platform. A stage
+// whose name mentions the platform's OS or architecture (FROM --platform=
+// linux/arm64 alpine AS build-arm64) is taken as deliberately
+// platform-specific.
+type BK1014Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1014 creates the rule reporting constant FROM --platform values.
+func BK1014() rule.Rule {
+	return &BK1014Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1014Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1014Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the FROM with a constant --platform.
+func (*BK1014Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	from, ok := instruction.(*syntax.From)
+	if !ok || !dispatched(from) || from.Image.Platform == nil || strings.Contains(*from.Image.Platform, "$") {
+		return state
+	}
+
+	platform := *from.Image.Platform
+
+	os, arch, ok := parsePlatform(platform)
+	if !ok {
+		return state
+	}
+
+	var stage string
+	if from.Image.Alias != nil {
+		stage = strings.ToLower(*from.Image.Alias)
+	}
+
+	if strings.Contains(stage, os) || strings.Contains(stage, arch) {
+		return state
+	}
+
+	return state.AddFailure(bkFailure(BK1014Meta, line, linter.RuleFromPlatformFlagConstDisallowed.Format(platform)))
+}
+
+// parsePlatform returns the OS and architecture of an os[/arch[/variant]]
+// platform; a one-part platform is an OS, or an architecture of Linux.
+func parsePlatform(platform string) (os, arch string, ok bool) {
+	parts := strings.Split(strings.ToLower(platform), "/")
+	if len(parts) > 3 || slices.Contains(parts, "") {
+		return "", "", false
+	}
+
+	normalize := func(arch string) string {
+		if alias, ok := platformArchAliases[arch]; ok {
+			return alias
+		}
+
+		return arch
+	}
+
+	switch {
+	case len(parts) > 1:
+		return parts[0], normalize(parts[1]), true
+	case slices.Contains(platformOSes, parts[0]):
+		return parts[0], "amd64", true
+	default:
+		return "linux", normalize(parts[0]), true
+	}
+}
@@ -0,0 +1,71 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1014(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1014(),
+	}
+
+	t.Run("constant", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=linux/amd64 alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1014")
+	})
+
+	t.Run("constant with unrelated name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=linux/arm64 alpine AS build"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1014")
+	})
+
+	t.Run("variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=$BUILDPLATFORM alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1014")
+	})
+
+	t.Run("architecture in name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=linux/arm64 alpine AS build-arm64"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1014")
+	})
+
+	t.Run("os in name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM --platform=windows/amd64 alpine AS windows"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1014")
+	})
+
+	t.Run("no platform", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1014")
+	})
+}
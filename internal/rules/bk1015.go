@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1015Meta contains metadata for rule BK1015.
+var BK1015Meta = rule.Meta{
+	Code:       "BK1015",
+	Severity:   rule.Warning,
+	Message:    "Attempting to Copy file that is excluded by .dockerignore",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/copy-ignored-file/"},
+}
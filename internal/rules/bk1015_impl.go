@@ -0,0 +1,161 @@
+package rules
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1015Rule reports COPY and ADD sources from the build context that its
+// .dockerignore excludes, and the build will not find. It needs the
+// .dockerignore patterns, given with config.DockerIgnore, and like BuildKit
+// stays silent when they hold exceptions (!pattern): whether a file inside
+// an excluded directory is brought back cannot be told without the
+// context. COPY --from, heredocs and remote ADD sources are not from the
+// context, and sources with variables are not judged.
+type BK1015Rule struct {
+	rule.StatefulRuleBase
+
+	ignored []*regexp.Regexp
+}
+
+// BK1015 creates the rule reporting sources excluded by .dockerignore, with
+// no .dockerignore: it reports nothing until given one with
+// BK1015WithConfig.
+func BK1015() rule.Rule {
+	return BK1015WithConfig(config.Default())
+}
+
+// BK1015WithConfig creates the rule with the .dockerignore patterns of
+// cfg.DockerIgnore.
+func BK1015WithConfig(cfg *config.Config) rule.Rule {
+	return &BK1015Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1015Meta),
+		ignored:          compileDockerIgnore(cfg.DockerIgnore),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1015Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the ignored sources of each COPY and ADD.
+func (r *BK1015Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	if len(r.ignored) == 0 || !dispatched(instruction) {
+		return state
+	}
+
+	var sources []string
+
+	command := "Copy"
+
+	switch inst := instruction.(type) {
+	case *syntax.Copy:
+		if inst.From == nil {
+			sources = inst.Source
+		}
+	case *syntax.Add:
+		command = "Add"
+
+		for _, source := range inst.Source {
+			if !strings.Contains(source, "://") && !strings.HasPrefix(source, "git@") {
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	for _, source := range sources {
+		if strings.HasPrefix(source, "<<") || strings.Contains(source, "$") {
+			continue
+		}
+
+		cleaned := strings.TrimPrefix(path.Clean(strings.ReplaceAll(source, `\`, "/")), "/")
+		if cleaned != "." && r.isIgnored(cleaned) {
+			state = state.AddFailure(bkFailure(BK1015Meta, line, linter.RuleCopyIgnoredFile.Format(command, cleaned)))
+		}
+	}
+
+	return state
+}
+
+// isIgnored reports whether a context path, or one of its parent
+// directories, matches a pattern.
+func (r *BK1015Rule) isIgnored(source string) bool {
+	elements := strings.Split(source, "/")
+
+	for i := range elements {
+		candidate := strings.Join(elements[:i+1], "/")
+		if slices.ContainsFunc(r.ignored, func(pattern *regexp.Regexp) bool { return pattern.MatchString(candidate) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileDockerIgnore turns .dockerignore patterns into expressions
+// matching context paths: * and ? within a path element, ** across them.
+// It returns none when a pattern is an exception, or does not compile.
+func compileDockerIgnore(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			return nil
+		}
+
+		pattern = strings.TrimPrefix(path.Clean(strings.ReplaceAll(pattern, `\`, "/")), "/")
+
+		var expr strings.Builder
+
+		for i := 0; i < len(pattern); i++ {
+			switch char := pattern[i]; {
+			case strings.HasPrefix(pattern[i:], "**/"):
+				expr.WriteString("(?:.*/)?")
+
+				i += 2
+			case strings.HasPrefix(pattern[i:], "**"):
+				expr.WriteString(".*")
+
+				i++
+			case char == '*':
+				expr.WriteString("[^/]*")
+			case char == '?':
+				expr.WriteString("[^/]")
+			case char == '[':
+				end := strings.IndexByte(pattern[i:], ']')
+				if end < 0 {
+					return nil
+				}
+
+				class := pattern[i+1 : i+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+
+				expr.WriteString("[" + class + "]")
+
+				i += end
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(char)))
+			}
+		}
+
+		re, err := regexp.Compile("^" + expr.String() + "$")
+		if err != nil {
+			return nil
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled
+}
@@ -0,0 +1,120 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1015(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1015(),
+	}
+
+	t.Run("no dockerignore", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY node_modules /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1015")
+	})
+}
+
+func TestBK1015_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.DockerIgnore = []string{"node_modules", "/dist", "*.log", "**/fixtures"}
+
+	allRules := []rule.Rule{
+		rules.BK1015WithConfig(cfg),
+	}
+
+	t.Run("ignored directory", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY node_modules /app/node_modules`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1015")
+	})
+
+	t.Run("file in ignored directory", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY dist/app.js /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1015")
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD debug.log /var/log/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1015")
+	})
+
+	t.Run("double star", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY src/test/fixtures/data.json /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1015")
+	})
+
+	t.Run("not ignored", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY src /app/src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1015")
+	})
+
+	t.Run("whole context", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY . /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1015")
+	})
+
+	t.Run("from stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS build
+FROM alpine
+COPY --from=build dist /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1015")
+	})
+
+	t.Run("remote add", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD https://example.com/debug.log /tmp/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1015")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// BK1016Meta contains metadata for rule BK1016.
+var BK1016Meta = rule.Meta{
+	Code:       "BK1016",
+	Severity:   rule.Info,
+	Message:    "Comment for build stage or argument should follow the format: `# <arg/stage name> <description>`",
+	References: []string{"https://docs.docker.com/go/dockerfile/rule/invalid-definition-description/"},
+}
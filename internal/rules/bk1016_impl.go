@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/linter"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// BK1016Rule reports a comment right above a named FROM or an ARG that
+// does not start with the stage or argument name, the format BuildKit reads
+// their descriptions in (# version of the toolchain to build with). An
+// empty comment line (#) between comment and instruction makes it a plain
+// comment; like BuildKit, a blank line does not. Pragmas
+// (# hadolint ignore=...) are not descriptions. The check is experimental in BuildKit.
+type BK1016Rule struct {
+	rule.StatefulRuleBase
+}
+
+// BK1016 creates the rule reporting malformed definition descriptions.
+func BK1016() rule.Rule {
+	return &BK1016Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(BK1016Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*BK1016Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check compares the comment above a FROM or ARG with the names it defines.
+func (*BK1016Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	comments := instruction.Raw().Comments
+	if !dispatched(instruction) || len(comments) == 0 {
+		return state
+	}
+
+	var names []string
+
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		if inst.Image.Alias != nil {
+			names = append(names, strings.ToLower(*inst.Image.Alias))
+		}
+	case *syntax.Arg:
+		for _, declaration := range argDeclarations(inst) {
+			names = append(names, declaration.key)
+		}
+	}
+
+	description := comments[len(comments)-1]
+	if len(names) == 0 || strings.HasPrefix(description, "hadolint ") {
+		return state
+	}
+
+	if first, _, _ := strings.Cut(description, " "); slices.Contains(names, first) {
+		return state
+	}
+
+	example := names[0]
+	if len(names) > 1 {
+		example = "<arg_key>"
+	}
+
+	message := linter.RuleInvalidDefinitionDescription.Format(instruction.Name(), example)
+
+	return state.AddFailure(bkFailure(BK1016Meta, line, message))
+}
@@ -0,0 +1,92 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestBK1016(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.BK1016(),
+	}
+
+	t.Run("stage description", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `# builder compiles the app
+FROM golang:1.22 AS builder`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1016")
+	})
+
+	t.Run("wrong stage description", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `# compiles the app
+FROM golang:1.22 AS builder`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1016")
+	})
+
+	t.Run("arg description", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+# VERSION of the app
+ARG VERSION=1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1016")
+	})
+
+	t.Run("wrong arg description", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+# the app version
+ARG VERSION=1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "BK1016")
+	})
+
+	t.Run("empty comment line", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+# some notes
+#
+ARG VERSION=1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1016")
+	})
+
+	t.Run("unnamed stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `# base image
+FROM alpine`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1016")
+	})
+
+	t.Run("pragma", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+# hadolint ignore=DL3006
+ARG VERSION=1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "BK1016")
+	})
+}
@@ -0,0 +1,419 @@
+package rules
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	bkshell "github.com/moby/buildkit/frontend/dockerfile/shell"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// bkFailure reports a BuildKit check with the message BuildKit gives it.
+func bkFailure(meta rule.Meta, line int, message string) rule.CheckFailure {
+	return rule.CheckFailure{
+		Code:     meta.Code,
+		Severity: meta.Severity,
+		Message:  message,
+		Line:     line,
+		Column:   1,
+	}
+}
+
+// dispatched reports whether BuildKit checks an instruction: it was read by
+// the parser, and is not wrapped by ONBUILD, whose triggers are only
+// dispatched in the builds of child images.
+func dispatched(instruction syntax.Instruction) bool {
+	return instruction.Raw().Keyword != ""
+}
+
+// keyword returns the keyword of an instruction as written.
+func keyword(instruction syntax.Instruction) string {
+	if raw := instruction.Raw(); raw.Keyword != "" {
+		return raw.Keyword
+	}
+
+	return instruction.Name()
+}
+
+// platformArgs are the build arguments BuildKit defines for FROM, with their
+// values in a linux/amd64 build. Stages only see those they declare.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var platformArgs = map[string]string{
+	"BUILDPLATFORM":   "linux/amd64",
+	"BUILDOS":         "linux",
+	"BUILDOSVERSION":  "",
+	"BUILDARCH":       "amd64",
+	"BUILDVARIANT":    "",
+	"TARGETPLATFORM":  "linux/amd64",
+	"TARGETOS":        "linux",
+	"TARGETOSVERSION": "",
+	"TARGETARCH":      "amd64",
+	"TARGETVARIANT":   "",
+	"TARGETSTAGE":     "default",
+}
+
+// keyValue is a variable an ARG or ENV declares; value is nil for an ARG
+// without default.
+type keyValue struct {
+	key   string
+	value *string
+}
+
+// argDeclarations returns the variables an ARG declares (ARG a b=1), with
+// their default as written.
+func argDeclarations(arg *syntax.Arg) []keyValue {
+	raw := arg.Raw().Args
+	if len(raw) == 0 {
+		return []keyValue{{arg.ArgName, arg.Value}}
+	}
+
+	declarations := make([]keyValue, 0, len(raw))
+
+	for _, word := range raw {
+		key, value, ok := strings.Cut(word, "=")
+		if !ok {
+			declarations = append(declarations, keyValue{key: key})
+
+			continue
+		}
+
+		declarations = append(declarations, keyValue{key, &value})
+	}
+
+	return declarations
+}
+
+// envDeclarations returns the variables an ENV declares, with their value as
+// written.
+func envDeclarations(env *syntax.Env) []keyValue {
+	raw := env.Raw().Args
+	if len(raw) == 0 {
+		declarations := make([]keyValue, 0, len(env.Pairs))
+		for _, pair := range env.Pairs {
+			declarations = append(declarations, keyValue{pair.Key, &pair.Value})
+		}
+
+		return declarations
+	}
+
+	declarations := make([]keyValue, 0, len(raw)/3)
+
+	// buildkit splits ENV into key, value and separator triples.
+	for i := 0; i+1 < len(raw); i += 3 {
+		declarations = append(declarations, keyValue{raw[i], &raw[i+1]})
+	}
+
+	return declarations
+}
+
+// buildVars follows the variables BuildKit expands instructions with: in
+// FROM, the ARGs declared before the first one and the platform ones; in a
+// stage, the ARGs it declares and the ENVs it sets, on top of those of the
+// earlier stage it is built from. Of an external base image's environment,
+// only PATH is assumed. Methods return updated copies, so it can be kept in
+// rule state.
+type buildVars struct {
+	escape  rune
+	started bool
+	global  map[string]*string
+	stage   map[string]string
+	alias   string
+	stages  map[string]map[string]string
+}
+
+// apply follows the escape directive, FROM, ARG and ENV.
+func (v buildVars) apply(instruction syntax.Instruction) buildVars {
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		if inst.Key == "escape" && inst.Value != "" {
+			v.escape = rune(inst.Value[0])
+		}
+	case *syntax.From:
+		return v.from(inst)
+	case *syntax.Arg:
+		if !v.started {
+			v.global = maps.Clone(v.global)
+			if v.global == nil {
+				v.global = make(map[string]*string)
+			}
+
+			for _, declaration := range argDeclarations(inst) {
+				if declaration.value == nil {
+					v.global[declaration.key] = nil
+
+					continue
+				}
+
+				value := v.expand(*declaration.value, v.fromEnv())
+				v.global[declaration.key] = &value
+			}
+
+			return v
+		}
+
+		env := v.stageEnv()
+		v.stage = maps.Clone(v.stage)
+
+		for _, declaration := range argDeclarations(inst) {
+			switch {
+			case declaration.value != nil:
+				v.stage[declaration.key] = v.expand(*declaration.value, env)
+			case v.global[declaration.key] != nil:
+				v.stage[declaration.key] = *v.global[declaration.key]
+			default:
+				v.stage[declaration.key] = ""
+			}
+		}
+	case *syntax.Env:
+		if !v.started {
+			return v
+		}
+
+		env := v.stageEnv()
+		v.stage = maps.Clone(v.stage)
+
+		for _, declaration := range envDeclarations(inst) {
+			v.stage[declaration.key] = v.expand(*declaration.value, env)
+		}
+	}
+
+	return v
+}
+
+// from starts a stage, inheriting the variables of the earlier stage it is
+// built from, if any.
+func (v buildVars) from(from *syntax.From) buildVars {
+	v.stages = maps.Clone(v.stages)
+	if v.stages == nil {
+		v.stages = make(map[string]map[string]string)
+	}
+
+	if v.started && v.alias != "" {
+		v.stages[v.alias] = v.stage
+	}
+
+	v.started = true
+	v.alias = ""
+
+	if from.Image.Alias != nil {
+		v.alias = strings.ToLower(*from.Image.Alias)
+	}
+
+	if inherited, ok := v.stages[strings.ToLower(from.Image.Image)]; ok && from.Image.Tag == nil {
+		v.stage = inherited
+	} else if from.Image.Image == "scratch" {
+		v.stage = map[string]string{}
+	} else {
+		v.stage = map[string]string{"PATH": "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"}
+	}
+
+	return v
+}
+
+// fromEnv returns the variables FROM expands with: the global ARGs with a
+// default, and the platform ones.
+func (v buildVars) fromEnv() bkshell.EnvGetter {
+	env := maps.Clone(platformArgs)
+
+	for key, value := range v.global {
+		if value != nil {
+			env[key] = *value
+		}
+	}
+
+	return envGetter(env)
+}
+
+// globalKeys returns the names FROM may use: the global ARGs, with or
+// without default, and the platform ones.
+func (v buildVars) globalKeys() []string {
+	keys := slices.Concat(slices.Collect(maps.Keys(platformArgs)), slices.Collect(maps.Keys(v.global)))
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
+
+// stageEnv returns the variables of the current stage.
+func (v buildVars) stageEnv() bkshell.EnvGetter {
+	return envGetter(v.stage)
+}
+
+// lexer returns BuildKit's word expansion, with the escape character of the
+// Dockerfile.
+func (v buildVars) lexer() *bkshell.Lex {
+	if v.escape == 0 {
+		return bkshell.NewLex('\\')
+	}
+
+	return bkshell.NewLex(v.escape)
+}
+
+// expand returns a word with its variables replaced; words BuildKit fails
+// to expand are kept as written.
+func (v buildVars) expand(word string, env bkshell.EnvGetter) string {
+	expanded, _, err := v.lexer().ProcessWord(word, env)
+	if err != nil {
+		return word
+	}
+
+	return expanded
+}
+
+// unmatched returns the variables of a word env does not define, sorted.
+func (v buildVars) unmatched(word string, env bkshell.EnvGetter) []string {
+	result, err := v.lexer().ProcessWordWithMatches(word, env)
+	if err != nil {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(result.Unmatched))
+}
+
+// undefinedVar is a variable an instruction expands that nothing in scope
+// defines, with the closest name in scope, if any.
+type undefinedVar struct {
+	name       string
+	suggestion string
+}
+
+// undefined returns the variables BuildKit expands to nothing in an
+// instruction: in FROM, those no global ARG declares; elsewhere, those the
+// stage does not define, in the words BuildKit expands itself (RUN, CMD and
+// ENTRYPOINT are left to the shell). v holds the variables before the
+// instruction.
+func (v buildVars) undefined(instruction syntax.Instruction) []undefinedVar {
+	if !dispatched(instruction) {
+		return nil
+	}
+
+	var found []undefinedVar
+
+	if from, ok := instruction.(*syntax.From); ok {
+		words := []string{baseName(from)}
+		if from.Image.Platform != nil {
+			words = append(words, *from.Image.Platform)
+		}
+
+		env, keys := v.fromEnv(), v.globalKeys()
+
+		for _, word := range words {
+			for _, name := range v.unmatched(word, env) {
+				if _, declared := v.global[name]; !declared {
+					found = append(found, undefinedVar{name, suggestName(name, keys)})
+				}
+			}
+		}
+
+		return found
+	}
+
+	if !v.started {
+		return nil
+	}
+
+	env := v.stageEnv()
+	names := make(map[string]bool)
+
+	for _, word := range expandedWords(instruction) {
+		for _, name := range v.unmatched(word, env) {
+			if !slices.Contains(nonEnvArgs, name) {
+				names[name] = true
+			}
+		}
+	}
+
+	options := slices.Sorted(maps.Keys(v.stage))
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		found = append(found, undefinedVar{name, suggestName(name, options)})
+	}
+
+	return found
+}
+
+// envGetter adapts a map to BuildKit's variable lookup.
+func envGetter(env map[string]string) bkshell.EnvGetter {
+	pairs := make([]string, 0, len(env))
+	for key, value := range env {
+		pairs = append(pairs, key+"="+value)
+	}
+
+	return bkshell.EnvsFromSlice(pairs)
+}
+
+// maxSuggestionDistance bounds the edits between a misspelled name and the
+// one suggested for it, as BuildKit's suggestions do.
+const maxSuggestionDistance = 3
+
+// suggestName returns the option closest to name by edit distance, or ""
+// when none is close enough or name is one of them.
+func suggestName(name string, options []string) string {
+	var match string
+
+	best := maxSuggestionDistance
+
+	for _, option := range options {
+		if option == name {
+			return ""
+		}
+
+		if distance := editDistance(name, option); distance < best {
+			match, best = option, distance
+		}
+	}
+
+	return match
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(from, to string) int {
+	source, target := []rune(from), []rune(to)
+
+	previous := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := range source {
+		current := make([]int, len(target)+1)
+		current[0] = i + 1
+
+		for j := range target {
+			cost := 1
+			if source[i] == target[j] {
+				cost = 0
+			}
+
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(target)]
+}
+
+// isImageReference reports whether a FROM base name is a valid image
+// reference, per the distribution reference grammar BuildKit parses it
+// with: [domain[:port]/]path[:tag][@digest], lowercase path components.
+func isImageReference(ref string) bool {
+	return len(ref) <= 255 && imageReference.MatchString(ref)
+}
+
+// Parts of the distribution reference grammar.
+const (
+	referenceDomain    = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	referenceComponent = `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+)
+
+// imageReference matches an image reference, see isImageReference.
+var imageReference = regexp.MustCompile(
+	`^(?:` + referenceDomain + `(?:\.` + referenceDomain + `)*(?::[0-9]+)?/)?` +
+		referenceComponent + `(?:/` + referenceComponent + `)*` +
+		`(?::[\w][\w.-]{0,127})?` +
+		`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`,
+)
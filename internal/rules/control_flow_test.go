@@ -0,0 +1,62 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+// Control flow cases for DL3003, DL3009 and DL4006, which hadolint's suite
+// (the generated tests) does not cover: hadolint only looks for commands.
+func TestControlFlow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		rule       rule.Rule
+		dockerfile string
+		violation  bool
+	}{
+		{"cd in a subshell", rules.DL3003(), `RUN (cd /src && make) && make install`, false},
+		{"cd in a command substitution", rules.DL3003(), `RUN echo "$(cd /src && pwd)"`, false},
+		{"cd after a subshell", rules.DL3003(), `RUN (make) && cd /opt`, true},
+		{"cd in a branch", rules.DL3003(), `RUN if [ -d /src ]; then cd /src; fi`, true},
+		{
+			"cleanup only on failure", rules.DL3009(),
+			"FROM debian\nRUN apt-get update && apt-get install -y curl || rm -rf /var/lib/apt/lists/*", true,
+		},
+		{
+			"cleanup before update", rules.DL3009(),
+			"FROM debian\nRUN rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y curl", true,
+		},
+		{
+			"cleanup in a branch", rules.DL3009(),
+			"FROM debian\nRUN apt-get update && apt-get install -y curl; if [ -n \"$CLEAN\" ]; then rm -rf /var/lib/apt/lists/*; fi",
+			true,
+		},
+		{
+			"cleanup after update with ;", rules.DL3009(),
+			"FROM debian\nRUN apt-get update; apt-get install -y curl; rm -rf /var/lib/apt/lists/*", false,
+		},
+		{"set -o pipefail before the pipe", rules.DL4006(), `RUN set -euo pipefail; curl -fsSL https://example.com | sh`, false},
+		{"set -o pipefail after the pipe", rules.DL4006(), `RUN curl -fsSL https://example.com | sh; set -o pipefail`, true},
+		{"set -o pipefail in a subshell", rules.DL4006(), `RUN (set -o pipefail) && curl -fsSL https://example.com | sh`, true},
+		{"set +o pipefail", rules.DL4006(), `RUN set -o pipefail && set +o pipefail && curl -fsSL https://example.com | sh`, true},
+		{"pipe in a command substitution", rules.DL4006(), `RUN echo "$(ls | wc -l)"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := testutils.LintDockerfile(tt.dockerfile, []rule.Rule{tt.rule})
+			if tt.violation {
+				testutils.AssertContainsViolation(t, violations, string(tt.rule.Code()))
+			} else {
+				testutils.AssertNoViolation(t, violations, string(tt.rule.Code()))
+			}
+		})
+	}
+}
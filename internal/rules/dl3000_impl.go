@@ -4,26 +4,77 @@ import (
 	"strings"
 
 	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
 	"github.com/farcloser/godolint/internal/syntax"
 )
 
+// DL3000Rule checks WORKDIR paths are absolute, by the rules of the stage's
+// platform: on Windows stages, \app and \\server\share are absolute too.
+type DL3000Rule struct{}
+
 // DL3000 creates a rule for checking WORKDIR paths are absolute.
 func DL3000() rule.Rule {
-	return rule.NewSimpleRule(
-		DL3000Meta.Code,
-		DL3000Meta.Severity,
-		DL3000Meta.Message,
-		checkDL3000,
-	)
+	return &DL3000Rule{}
 }
 
-func checkDL3000(instruction syntax.Instruction) bool {
-	workdir, ok := instruction.(*syntax.Workdir)
-	if !ok {
-		return true
+// Code returns the rule code.
+func (*DL3000Rule) Code() rule.Code {
+	return DL3000Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*DL3000Rule) Severity() rule.Severity {
+	return DL3000Meta.Severity
+}
+
+// Message returns the rule message.
+func (*DL3000Rule) Message() string {
+	return DL3000Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*DL3000Rule) InitialState() rule.State {
+	return rule.EmptyState(shell.StageDialects{})
+}
+
+// Check follows the stage platform and validates WORKDIR paths.
+func (*DL3000Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	stages := rule.Data[shell.StageDialects](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		return state.ReplaceData(stages.Directive(inst))
+
+	case *syntax.From:
+		return state.ReplaceData(stages.From(inst))
+
+	case *syntax.Shell:
+		return state.ReplaceData(stages.Shell(inst))
+
+	case *syntax.Workdir:
+		if isAbsoluteWorkdir(inst.Directory, stages.Windows()) {
+			return state
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     DL3000Meta.Code,
+			Severity: DL3000Meta.Severity,
+			Message:  DL3000Meta.Message,
+			Line:     line,
+			Column:   1, // Hardcoded to 1 (matches hadolint)
+		})
 	}
 
-	path := dropQuotes(workdir.Directory)
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*DL3000Rule) Finalize(state rule.State) rule.State {
+	return state // No finalization needed
+}
+
+func isAbsoluteWorkdir(directory string, windows bool) bool {
+	path := dropQuotes(directory)
 
 	// Variable expansion - allowed
 	if strings.HasPrefix(path, "$") {
@@ -40,6 +91,6 @@ func checkDL3000(instruction syntax.Instruction) bool {
 		return true
 	}
 
-	// Relative path - fail
-	return false
+	// Rooted Windows path (\app, \\server\share), on Windows stages only
+	return windows && isWindowsRooted(path)
 }
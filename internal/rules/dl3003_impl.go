@@ -27,6 +27,17 @@ func checkDL3003(instruction syntax.Instruction) bool {
 		return true
 	}
 
-	// Fail if using `cd` command
-	return !shell.UsingProgram("cd", parsed)
+	// Fail if using `cd` command, unless it runs in a subshell, as in
+	// (cd src && make): its directory change ends with the subshell.
+	ok = true
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, ctx shell.Context) bool {
+		if cmd := stmt.Command(); cmd != nil && cmd.Name == "cd" && !ctx.Subshell {
+			ok = false
+		}
+
+		return ok
+	})
+
+	return ok
 }
@@ -141,23 +141,36 @@ func (*DL3009Rule) Finalize(state rule.State) rule.State {
 	return finalState
 }
 
+// forgotToCleanup reports whether the lists an apt update fetches may be
+// left in the layer: no cleanup runs after the last update, on every path
+// the script succeeds on. A cleanup in a branch (if, case, loop) or only on
+// failure (|| rm ...) does not count.
 func forgotToCleanup(parsed *shell.ParsedShell) bool {
 	hasUpdate := false
 	hasCleanup := false
 
-	for _, cmd := range parsed.PresentCommands {
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, ctx shell.Context) bool {
+		cmd := stmt.Command()
+		if cmd == nil {
+			return true
+		}
+
 		// Check for apt/apt-get/aptitude update
-		if shell.CmdHasArgs("apt", []string{"update"}, cmd) ||
-			shell.CmdHasArgs("apt-get", []string{"update"}, cmd) ||
-			shell.CmdHasArgs("aptitude", []string{"update"}, cmd) {
+		if shell.CmdHasArgs("apt", []string{"update"}, *cmd) ||
+			shell.CmdHasArgs("apt-get", []string{"update"}, *cmd) ||
+			shell.CmdHasArgs("aptitude", []string{"update"}, *cmd) {
 			hasUpdate = true
+			hasCleanup = false
 		}
 
 		// Check for cleanup
-		if shell.CmdHasArgs("rm", []string{"-rf", "/var/lib/apt/lists/*"}, cmd) {
+		if hasUpdate && !ctx.Branch && !ctx.OnFailure &&
+			shell.CmdHasArgs("rm", []string{"-rf", "/var/lib/apt/lists/*"}, *cmd) {
 			hasCleanup = true
 		}
-	}
+
+		return true
+	})
 
 	return hasUpdate && !hasCleanup
 }
@@ -36,6 +36,13 @@ func (*DL3024Rule) Message() string {
 	return DL3024Meta.Message
 }
 
+// StageScoped reports that the rule's findings concern a whole stage.
+// DL3024Meta is generated from hadolint, so the scope is declared here: the
+// duplicate is reported on the later FROM, but concerns both stages.
+func (*DL3024Rule) StageScoped() bool {
+	return true
+}
+
 // InitialState returns the initial state for this rule.
 func (*DL3024Rule) InitialState() rule.State {
 	return rule.EmptyState(dl3024State{
@@ -3,6 +3,7 @@ package rules
 import (
 	"strings"
 
+	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/rule"
 	"github.com/farcloser/godolint/internal/syntax"
 )
@@ -20,11 +21,16 @@ type DL3026Rule struct {
 }
 
 // DL3026 creates a rule that checks for allowed registries.
-// TODO: Add configuration support to specify allowed registries.
 func DL3026() rule.Rule {
+	return DL3026WithConfig(config.Default())
+}
+
+// DL3026WithConfig creates the rule with custom configuration: only the
+// registries in cfg.AllowedRegistries may be used (empty allows all).
+func DL3026WithConfig(cfg *config.Config) rule.Rule {
 	return &DL3026Rule{
 		StatefulRuleBase:  rule.NewStatefulRuleBase(DL3026Meta),
-		allowedRegistries: []string{}, // Empty = all allowed (for now)
+		allowedRegistries: cfg.AllowedRegistries,
 	}
 }
 
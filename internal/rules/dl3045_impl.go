@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
 	"github.com/farcloser/godolint/internal/syntax"
 )
 
@@ -41,6 +42,8 @@ type dl3045State struct {
 	CurrentStage string
 	// Map from stage name to whether WORKDIR has been set
 	WorkdirSet map[string]bool
+	// Platform of the current stage, for Windows paths
+	Stages shell.StageDialects
 }
 
 // Check validates COPY instructions use absolute paths or have WORKDIR set.
@@ -48,6 +51,16 @@ func (r *DL3045Rule) Check(line int, state rule.State, instruction syntax.Instru
 	currentState := rule.Data[dl3045State](state)
 
 	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		currentState.Stages = currentState.Stages.Directive(inst)
+
+		return state.ReplaceData(currentState)
+
+	case *syntax.Shell:
+		currentState.Stages = currentState.Stages.Shell(inst)
+
+		return state.ReplaceData(currentState)
+
 	case *syntax.From:
 		// Remember the stage
 		stageName := inst.Image.Image
@@ -63,6 +76,7 @@ func (r *DL3045Rule) Check(line int, state rule.State, instruction syntax.Instru
 
 		currentState.CurrentStage = stageName
 		currentState.WorkdirSet[stageName] = inheritWorkdir
+		currentState.Stages = currentState.Stages.From(inst)
 
 		return state.ReplaceData(currentState)
 
@@ -88,8 +102,9 @@ func (r *DL3045Rule) Check(line int, state rule.State, instruction syntax.Instru
 			return state.ReplaceData(currentState)
 		}
 
-		// 3. Destination is Windows absolute (like C:\path)
-		if len(dest) >= 2 && dest[1] == ':' && isLetter(rune(dest[0])) {
+		// 3. Destination is Windows absolute (like C:\path), or rooted
+		// (like \app) on a Windows stage
+		if isWindowsAbsolute(dest) || (currentState.Stages.Windows() && isWindowsRooted(dest)) {
 			return state.ReplaceData(currentState)
 		}
 
@@ -117,7 +132,3 @@ func (r *DL3045Rule) Check(line int, state rule.State, instruction syntax.Instru
 func (*DL3045Rule) Finalize(state rule.State) rule.State {
 	return state // No finalization needed
 }
-
-func isLetter(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
-}
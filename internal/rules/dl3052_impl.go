@@ -3,6 +3,7 @@ package rules
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/rule"
@@ -82,9 +83,19 @@ func (*DL3052Rule) Finalize(state rule.State) rule.State {
 	return state
 }
 
-// isValidURL checks if a string is a valid URL.
+// uriCharacters matches the characters RFC 3986 allows in a URI, with
+// percent-encodings well-formed.
+var uriCharacters = regexp.MustCompile(`^(?:[A-Za-z0-9\-._~:/?#\[\]@!$&'()*+,;=]|%[0-9A-Fa-f]{2})*$`)
+
+// isValidURL checks if a string is a valid URL: an absolute URI, as
+// hadolint's parseURI (Network.URI) accepts. Go's parser is more lenient
+// with the characters, which RFC 3986 restricts.
 func isValidURL(urlStr string) bool {
-	parsedURL, err := url.ParseRequestURI(urlStr)
+	if !uriCharacters.MatchString(urlStr) {
+		return false
+	}
+
+	parsedURL, err := url.Parse(urlStr)
 	if err != nil {
 		return false
 	}
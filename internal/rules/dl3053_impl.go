@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/farcloser/godolint/internal/config"
@@ -82,8 +83,17 @@ func (*DL3053Rule) Finalize(state rule.State) rule.State {
 	return state
 }
 
-// isValidRFC3339 checks if a string is a valid RFC3339 timestamp.
+// isValidRFC3339 checks if a string is a valid RFC3339 timestamp. RFC 3339
+// allows a lowercase t and z, which Go's layout does not.
 func isValidRFC3339(timestamp string) bool {
+	if len(timestamp) > len(time.DateOnly) && timestamp[len(time.DateOnly)] == 't' {
+		timestamp = timestamp[:len(time.DateOnly)] + "T" + timestamp[len(time.DateOnly)+1:]
+	}
+
+	if strings.HasSuffix(timestamp, "z") {
+		timestamp = strings.TrimSuffix(timestamp, "z") + "Z"
+	}
+
 	_, err := time.Parse(time.RFC3339, timestamp)
 
 	return err == nil
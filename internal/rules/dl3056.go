@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// DL3056Meta contains metadata for rule DL3056.
+// Source: hadolint/src/Hadolint/Rule/DL3056.hs
+var DL3056Meta = rule.Meta{
+	Code:     "DL3056",
+	Severity: rule.Warning,
+	Message:  "Label `",
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// DL3056Rule checks that semantic version labels are valid.
+type DL3056Rule struct {
+	cfg *config.Config
+}
+
+// DL3056 creates the rule for checking semantic version labels.
+func DL3056() rule.Rule {
+	return &DL3056Rule{
+		cfg: config.Default(),
+	}
+}
+
+// DL3056WithConfig creates the rule with custom configuration.
+func DL3056WithConfig(cfg *config.Config) rule.Rule {
+	return &DL3056Rule{
+		cfg: cfg,
+	}
+}
+
+// Code returns the rule code.
+func (*DL3056Rule) Code() rule.Code {
+	return DL3056Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*DL3056Rule) Severity() rule.Severity {
+	return DL3056Meta.Severity
+}
+
+// Message returns the rule message.
+func (*DL3056Rule) Message() string {
+	return DL3056Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*DL3056Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check validates that semantic version labels are valid.
+func (r *DL3056Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	label, ok := instruction.(*syntax.Label)
+	if !ok {
+		return state
+	}
+
+	// Check each label that should be a semantic version
+	for _, pair := range label.Pairs {
+		labelType, exists := r.cfg.LabelSchema[pair.Key]
+		if !exists || labelType != config.LabelTypeSemVer {
+			continue
+		}
+
+		// Validate semantic version: MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]
+		if !isValidSemVer(pair.Value) {
+			return state.AddFailure(rule.CheckFailure{
+				Code:     DL3056Meta.Code,
+				Severity: DL3056Meta.Severity,
+				Message:  fmt.Sprintf("Label `%s` does not conform to semantic versioning.", pair.Key),
+				Line:     line,
+				Column:   1, // Hardcoded to 1 (matches hadolint)
+			})
+		}
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*DL3056Rule) Finalize(state rule.State) rule.State {
+	return state
+}
+
+// semVerPattern is the regular expression of Semantic Versioning 2.0.0
+// (https://semver.org): numbers without leading zeros, dot-separated
+// pre-release and build identifiers, and no v prefix.
+var semVerPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// isValidSemVer checks if a string is a valid semantic version.
+func isValidSemVer(version string) bool {
+	return semVerPattern.MatchString(version)
+}
@@ -41,6 +41,13 @@ func (*DL3057Rule) Message() string {
 	return DL3057Meta.Message
 }
 
+// StageScoped reports that the rule's findings concern a whole stage.
+// DL3057Meta is generated from hadolint, so the scope is declared here: the
+// missing HEALTHCHECK is reported on the stage's FROM.
+func (*DL3057Rule) StageScoped() bool {
+	return true
+}
+
 // InitialState returns the initial state for this rule.
 func (*DL3057Rule) InitialState() rule.State {
 	return rule.EmptyState(dl3057State{
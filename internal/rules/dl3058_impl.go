@@ -3,6 +3,7 @@ package rules
 import (
 	"fmt"
 	"net/mail"
+	"strings"
 
 	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/rule"
@@ -82,9 +83,11 @@ func (*DL3058Rule) Finalize(state rule.State) rule.State {
 	return state
 }
 
-// isValidEmail checks if a string is a valid email address (RFC5322).
+// isValidEmail checks if a string is a valid email address (RFC5322): a
+// bare addr-spec, as hadolint's email-validate accepts, rather than the
+// name-addr ("Jane <jane@example.com>") Go's parser accepts too.
 func isValidEmail(email string) bool {
-	_, err := mail.ParseAddress(email)
+	address, err := mail.ParseAddress(email)
 
-	return err == nil
+	return err == nil && address.Name == "" && !strings.ContainsAny(email, "<>")
 }
@@ -59,6 +59,11 @@ func (*DL3061Rule) Check(line int, state rule.State, instruction syntax.Instruct
 		return state
 	}
 
+	// Parser directives come first by definition
+	if _, ok := instruction.(*syntax.Directive); ok {
+		return state
+	}
+
 	// Any other instruction before FROM - fail
 	return state.AddFailure(rule.CheckFailure{
 		Code:     DL3061Meta.Code,
@@ -76,7 +76,7 @@ func (*DL4006Rule) Check(line int, state rule.State, instruction syntax.Instruct
 
 	case *syntax.Run:
 		// If pipefail is not set and command has pipes, fail
-		if !currentState.pipefailSet && hasPipes(inst.Command) {
+		if !currentState.pipefailSet && hasPipesWithoutPipefail(inst.Command) {
 			return state.AddFailure(rule.CheckFailure{
 				Code:     DL4006Meta.Code,
 				Severity: DL4006Meta.Severity,
@@ -119,8 +119,10 @@ func isNonPosixShell(shellCmd string) bool {
 	return false
 }
 
-// hasPipes checks if a command contains pipes.
-func hasPipes(command string) bool {
+// hasPipesWithoutPipefail checks if a command runs a pipeline before any
+// `set -o pipefail` of its own. Only a set that lasts counts: one in the
+// main shell, that runs on every path.
+func hasPipesWithoutPipefail(command string) bool {
 	// Parse the shell script to check for actual pipe operators
 	parsed, err := shell.ParseShell(command)
 	if err != nil {
@@ -128,8 +130,53 @@ func hasPipes(command string) bool {
 		return false
 	}
 
-	// Use shell package to detect pipes properly
-	return shell.HasPipes(parsed)
+	pipefail := false
+	found := false
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, ctx shell.Context) bool {
+		if _, ok := stmt.Node.(*shell.Pipeline); ok && !pipefail {
+			found = true
+
+			return false
+		}
+
+		if cmd := stmt.Command(); cmd != nil && !ctx.Subshell && !ctx.Branch && !ctx.OnFailure {
+			if enabled, ok := setsPipefail(*cmd); ok {
+				pipefail = enabled
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// setsPipefail reports whether a command is a set changing the pipefail
+// option (set -o pipefail, set -euo pipefail, set +o pipefail), and its
+// new value.
+func setsPipefail(cmd shell.Command) (enabled, ok bool) {
+	if cmd.Name != "set" {
+		return false, false
+	}
+
+	args := shell.GetArgs(cmd)
+	for i := range len(args) - 1 {
+		option := args[i]
+		if args[i+1] != "pipefail" || len(option) < 2 || strings.HasPrefix(option, "--") ||
+			!strings.HasSuffix(option, "o") {
+			continue
+		}
+
+		switch option[0] {
+		case '-':
+			enabled, ok = true, true
+		case '+':
+			enabled, ok = false, true
+		}
+	}
+
+	return enabled, ok
 }
 
 // hasPipefailOption checks if a shell command sets pipefail.
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+// isDownload reports whether a command fetches a URL: curl or wget.
+func isDownload(cmd *shell.Command) bool {
+	if cmd == nil {
+		return false
+	}
+
+	name := programName(*cmd)
+
+	return name == "curl" || name == "wget"
+}
+
+// scriptInterpreters are the programs running a script read from their
+// standard input or given as an argument.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var scriptInterpreters = []string{
+	"sh", "bash", "dash", "ash", "zsh", "ksh", "mksh", "fish",
+	"python", "python2", "python3", "perl", "ruby", "node", "php", "pwsh",
+}
+
+// commandWrappers are the programs running the command given as their
+// arguments.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var commandWrappers = []string{"sudo", "env", "exec", "command", "nice", "nohup", "time"}
+
+// isInterpreter reports whether a command runs a script interpreter,
+// directly (bash -s) or through a wrapper (sudo -E bash).
+func isInterpreter(cmd *shell.Command) bool {
+	if cmd == nil {
+		return false
+	}
+
+	words := append([]string{cmd.Name}, shell.GetArgs(*cmd)...)
+	for _, word := range words {
+		name := path.Base(word)
+
+		switch {
+		case slices.Contains(scriptInterpreters, name):
+			return true
+		case slices.Contains(commandWrappers, name), strings.HasPrefix(word, "-"), strings.Contains(word, "="):
+			continue
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// downloadOutputs returns the files a curl or wget command writes.
+func downloadOutputs(cmd shell.Command) []string {
+	switch programName(cmd) {
+	case "curl":
+		return curlOutputs(cmd)
+	case "wget":
+		return wgetOutputs(cmd)
+	}
+
+	return nil
+}
+
+// isPlainHTTP reports whether a URL is fetched over plain HTTP or FTP from
+// a remote host.
+func isPlainHTTP(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "ftp") {
+		return false
+	}
+
+	host := parsed.Hostname()
+
+	return host != "" && host != "localhost" && host != "127.0.0.1" && host != "::1"
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD1001Meta contains metadata for rule GD1001.
+var GD1001Meta = rule.Meta{
+	Code:     "GD1001",
+	Severity: rule.Warning,
+	Message:  "Bash feature used in a RUN executed by a POSIX /bin/sh",
+}
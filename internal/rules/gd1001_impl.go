@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD1001Rule reports bash features in RUN instructions executed by a POSIX
+// /bin/sh: dash on debian and ubuntu, busybox ash on alpine (spared the
+// features it implements), or an unknown sh. The dialect follows the SHELL
+// instruction, the base image and here-document shebangs.
+type GD1001Rule struct{}
+
+// GD1001 creates the rule reporting bashisms under a POSIX sh.
+func GD1001() rule.Rule {
+	return &GD1001Rule{}
+}
+
+// Code returns the rule code.
+func (*GD1001Rule) Code() rule.Code {
+	return GD1001Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD1001Rule) Severity() rule.Severity {
+	return GD1001Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD1001Rule) Message() string {
+	return GD1001Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD1001Rule) InitialState() rule.State {
+	return rule.EmptyState(shell.StageDialects{})
+}
+
+// Check follows the stage dialect and reports the bashisms of each RUN.
+func (*GD1001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	stages := rule.Data[shell.StageDialects](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		return state.ReplaceData(stages.Directive(inst))
+
+	case *syntax.From:
+		return state.ReplaceData(stages.From(inst))
+
+	case *syntax.Shell:
+		return state.ReplaceData(stages.Shell(inst))
+
+	case *syntax.Run:
+		script, dialect, offset := shell.RunScript(inst, stages.Dialect())
+
+		bashisms, err := shell.Bashisms(script, dialect)
+		if err != nil {
+			// Unparsable scripts are not this rule's concern.
+			return state
+		}
+
+		for _, bashism := range bashisms {
+			state = state.AddFailure(rule.CheckFailure{
+				Code:     GD1001Meta.Code,
+				Severity: GD1001Meta.Severity,
+				Message: fmt.Sprintf("%s: %s is not available in %s, use %s or set SHELL to bash",
+					GD1001Meta.Message, bashism.Feature, dialect, bashism.Alternative),
+				Line:   line + offset + bashism.Line,
+				Column: 1,
+			})
+		}
+
+		return state
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD1001Rule) Finalize(state rule.State) rule.State {
+	return state
+}
@@ -0,0 +1,102 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD1001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD1001(),
+	}
+
+	t.Run("warns on [[ ]] under debian's dash", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+RUN [[ -f /etc/os-release ]] && echo ok`, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1001")
+	})
+
+	t.Run("does not warn on [[ ]] under alpine's ash", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM alpine:3.20
+RUN [[ -f /etc/os-release ]] && echo ok`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1001")
+	})
+
+	t.Run("warns on arrays under alpine's ash", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM alpine:3.20
+RUN pkgs=(curl git) && apk add "${pkgs[@]}"`, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1001")
+	})
+
+	t.Run("does not warn with SHELL bash", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+SHELL ["/bin/bash", "-o", "pipefail", "-c"]
+RUN source /etc/profile && [[ -n "$PATH" ]]`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1001")
+	})
+
+	t.Run("inherits SHELL from the parent stage", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm AS base
+SHELL ["/bin/bash", "-c"]
+FROM base
+RUN source /etc/profile`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1001")
+	})
+
+	t.Run("does not warn on a heredoc with a bash shebang", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+RUN <<EOF
+#!/bin/bash
+[[ -f /etc/os-release ]] && echo ok
+EOF`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1001")
+	})
+
+	t.Run("anchors heredoc bashisms to their line", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+RUN <<EOF
+set -e
+echo -e "a\tb"
+EOF`, allRules)
+
+		if len(violations) != 1 || violations[0].Line != 4 {
+			t.Errorf("violations = %+v, want one on line 4", violations)
+		}
+	})
+
+	t.Run("ignores bashisms in a python heredoc", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+RUN <<EOF python3
+print([[1]])
+EOF`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1001")
+	})
+}
@@ -0,0 +1,12 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD1101Meta contains metadata for rule GD1101.
+var GD1101Meta = rule.Meta{
+	Code:     "GD1101",
+	Severity: rule.Warning,
+	Message: "Set $ErrorActionPreference = 'Stop' in the SHELL or the RUN, " +
+		"so that failing PowerShell cmdlets fail the build",
+	StageScoped: true,
+}
@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"regexp"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD1101Rule reports PowerShell RUN instructions that keep the default
+// $ErrorActionPreference, Continue: a failing cmdlet prints an error and
+// the build carries on. It is set in the SHELL instruction usually, as in
+// SHELL ["powershell", "-Command", "$ErrorActionPreference = 'Stop';"].
+type GD1101Rule struct{}
+
+// GD1101 creates the rule reporting PowerShell RUNs without
+// $ErrorActionPreference = 'Stop'.
+func GD1101() rule.Rule {
+	return &GD1101Rule{}
+}
+
+// Code returns the rule code.
+func (*GD1101Rule) Code() rule.Code {
+	return GD1101Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD1101Rule) Severity() rule.Severity {
+	return GD1101Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD1101Rule) Message() string {
+	return GD1101Meta.Message
+}
+
+// StageScoped reports whether the rule's findings concern a whole stage: a
+// RUN is reported for the SHELL of its stage.
+func (*GD1101Rule) StageScoped() bool {
+	return GD1101Meta.StageScoped
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD1101Rule) InitialState() rule.State {
+	return rule.EmptyState(shell.StageDialects{})
+}
+
+// errorActionStop matches $ErrorActionPreference = 'Stop'.
+var errorActionStop = regexp.MustCompile(`(?i)\$(?:global:)?ErrorActionPreference\s*=\s*['"]?Stop\b`)
+
+// Check follows the stage dialect and checks PowerShell RUNs.
+func (*GD1101Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return checkPowerShellPreference(GD1101Meta, errorActionStop, line, state, instruction)
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD1101Rule) Finalize(state rule.State) rule.State {
+	return state
+}
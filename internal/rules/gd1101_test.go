@@ -0,0 +1,67 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD1101(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD1101(),
+	}
+
+	t.Run("warns on a PowerShell RUN without ErrorActionPreference", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`# escape=`+"`"+`
+FROM mcr.microsoft.com/windows/servercore:ltsc2022
+SHELL ["powershell", "-Command"]
+RUN Invoke-WebRequest https://example.com/tool.zip -OutFile tool.zip`, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1101")
+	})
+
+	t.Run("does not warn when the SHELL sets it", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022
+SHELL ["powershell", "-Command", "$ErrorActionPreference = 'Stop';"]
+RUN Invoke-WebRequest https://example.com/tool.zip -OutFile tool.zip`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1101")
+	})
+
+	t.Run("does not warn when the RUN sets it", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN powershell -Command $ErrorActionPreference = 'Stop'; Expand-Archive tool.zip`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1101")
+	})
+
+	t.Run("warns on a RUN starting powershell", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN powershell -Command Expand-Archive tool.zip`, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1101")
+	})
+
+	t.Run("ignores cmd and sh RUNs", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/nanoserver:ltsc2022
+RUN mkdir C:\app
+FROM debian:bookworm
+RUN mkdir /app`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1101")
+	})
+}
@@ -0,0 +1,12 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD1102Meta contains metadata for rule GD1102.
+var GD1102Meta = rule.Meta{
+	Code:     "GD1102",
+	Severity: rule.Info,
+	Message: "Set $ProgressPreference = 'SilentlyContinue' in the SHELL or the RUN, " +
+		"PowerShell progress bars slow down downloads and clutter the build log",
+	StageScoped: true,
+}
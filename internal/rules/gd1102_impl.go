@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"regexp"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD1102Rule reports PowerShell RUN instructions that keep the default
+// $ProgressPreference: Invoke-WebRequest then redraws a progress bar for
+// every chunk it downloads, which slows it down by an order of magnitude in
+// a build. Ignore is as good as SilentlyContinue.
+type GD1102Rule struct{}
+
+// GD1102 creates the rule reporting PowerShell RUNs that render progress
+// bars.
+func GD1102() rule.Rule {
+	return &GD1102Rule{}
+}
+
+// Code returns the rule code.
+func (*GD1102Rule) Code() rule.Code {
+	return GD1102Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD1102Rule) Severity() rule.Severity {
+	return GD1102Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD1102Rule) Message() string {
+	return GD1102Meta.Message
+}
+
+// StageScoped reports whether the rule's findings concern a whole stage: a
+// RUN is reported for the SHELL of its stage.
+func (*GD1102Rule) StageScoped() bool {
+	return GD1102Meta.StageScoped
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD1102Rule) InitialState() rule.State {
+	return rule.EmptyState(shell.StageDialects{})
+}
+
+// progressSilenced matches $ProgressPreference = 'SilentlyContinue', or
+// 'Ignore'.
+var progressSilenced = regexp.MustCompile(
+	`(?i)\$(?:global:)?ProgressPreference\s*=\s*['"]?(?:SilentlyContinue|Ignore)\b`,
+)
+
+// Check follows the stage dialect and checks PowerShell RUNs.
+func (*GD1102Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return checkPowerShellPreference(GD1102Meta, progressSilenced, line, state, instruction)
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD1102Rule) Finalize(state rule.State) rule.State {
+	return state
+}
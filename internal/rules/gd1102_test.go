@@ -0,0 +1,48 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD1102(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD1102(),
+	}
+
+	t.Run("reports a PowerShell RUN rendering progress bars", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022
+SHELL ["powershell", "-Command", "$ErrorActionPreference = 'Stop';"]
+RUN Invoke-WebRequest https://example.com/tool.zip -OutFile tool.zip`, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1102")
+	})
+
+	t.Run("does not report when the SHELL silences them", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022
+SHELL ["powershell", "-Command", "$ErrorActionPreference = 'Stop'; $ProgressPreference = 'SilentlyContinue';"]
+RUN Invoke-WebRequest https://example.com/tool.zip -OutFile tool.zip`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1102")
+	})
+
+	t.Run("inherits the SHELL from the parent stage", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM mcr.microsoft.com/windows/servercore:ltsc2022 AS base
+SHELL ["pwsh", "-Command", "$ProgressPreference = 'Ignore';"]
+FROM base
+RUN Invoke-WebRequest https://example.com/tool.zip -OutFile tool.zip`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1102")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD1103Meta contains metadata for rule GD1103.
+var GD1103Meta = rule.Meta{
+	Code:     "GD1103",
+	Severity: rule.Warning,
+	Message: "Pin versions in choco install. Instead of `choco install <package>` " +
+		"use `choco install <package> --version <version>`",
+}
@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD1103Rule reports Chocolatey packages installed without a version. It
+// checks the RUNs of Windows stages, whether choco is called from cmd or
+// PowerShell, and RUNs starting powershell or pwsh, as GD1101 does.
+type GD1103Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD1103 creates the rule checking Chocolatey packages are pinned to a
+// version.
+func GD1103() rule.Rule {
+	return &GD1103Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD1103Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD1103Rule) InitialState() rule.State {
+	return rule.EmptyState(shell.StageDialects{})
+}
+
+// Check follows the stage dialect and checks the choco commands of Windows
+// RUNs.
+func (*GD1103Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	stages := rule.Data[shell.StageDialects](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		return state.ReplaceData(stages.Directive(inst))
+	case *syntax.From:
+		return state.ReplaceData(stages.From(inst))
+	case *syntax.Shell:
+		return state.ReplaceData(stages.Shell(inst))
+	case *syntax.Run:
+		if _, _, ok := powershellScript(inst, stages); !ok && !stages.Windows() {
+			return state
+		}
+
+		for _, words := range windowsScriptCommands(inst.Command) {
+			if len(chocoUnpinnedPackages(words)) > 0 {
+				return state.AddFailure(rule.CheckFailure{
+					Code:     GD1103Meta.Code,
+					Severity: GD1103Meta.Severity,
+					Message:  GD1103Meta.Message,
+					Line:     line,
+					Column:   1,
+				})
+			}
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,158 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD1103(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD1103(),
+	}
+
+	t.Run("unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco install git -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco install git --version 2.45.1 -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("pinned with =", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco install git --version=2.45.1 -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("cinst", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN cinst nodejs-lts -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("source option value", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco install --source https://example.com/feed --version 1.0 tool`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("packages.config", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco install C:\packages.config -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("after another command", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN Set-ExecutionPolicy Bypass; choco.exe install 7zip -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("from powershell", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN powershell -Command choco install python3 -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("quoted powershell command", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN powershell -NoProfile -Command "choco install python3 -y"`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("powershell stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+SHELL ["powershell", "-Command", "$ErrorActionPreference = 'Stop';"]
+RUN if (-not (Test-Path C:\tools)) { choco install git -y }`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("argument of another command", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN echo choco install git`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("linux stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian:12
+RUN choco install git -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+
+	t.Run("powershell in a linux stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian:12
+RUN pwsh -Command choco install git -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD1103")
+	})
+
+	t.Run("other choco command", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+RUN choco upgrade chocolatey -y`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD1103")
+	})
+}
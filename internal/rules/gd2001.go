@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2001Meta contains metadata for rule GD2001.
+var GD2001Meta = rule.Meta{
+	Code:     "GD2001",
+	Severity: rule.Info,
+	Message:  "Delete files in the RUN that creates them, removing them in a later layer does not shrink the image",
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2001Rule reports files removed in a later layer than the one creating
+// them: package manager lists and caches, downloads, clones and copied
+// files. Layers only add to the image, so the files still take up space.
+// Archives extracted before their removal are GD2002's.
+type GD2001Rule struct{}
+
+// GD2001 creates the rule reporting files removed in a later layer.
+func GD2001() rule.Rule {
+	return &GD2001Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2001Rule) Code() rule.Code {
+	return GD2001Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2001Rule) Severity() rule.Severity {
+	return GD2001Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2001Rule) Message() string {
+	return GD2001Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2001Rule) InitialState() rule.State {
+	return rule.EmptyState(stageLayers{})
+}
+
+// Check follows the layers of each stage and reports late removals.
+func (*GD2001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return checkLayerRemovals(GD2001Meta, line, state, instruction, func(removal layerRemoval) (string, bool) {
+		if removal.extracted {
+			return "", false
+		}
+
+		created := removal.created
+
+		return fmt.Sprintf("%s was created by %s on line %d", created.path, created.origin, created.line), true
+	})
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2001Rule) Finalize(state rule.State) rule.State {
+	return state
+}
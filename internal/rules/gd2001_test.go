@@ -0,0 +1,90 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2001(),
+	}
+
+	tests := []struct {
+		name       string
+		dockerfile string
+		line       int // 0 for no violation
+	}{
+		{
+			name: "apt lists removed in a later RUN",
+			dockerfile: `FROM debian:bookworm
+RUN apt-get update && apt-get install -y curl
+RUN rm -rf /var/lib/apt/lists/*`,
+			line: 3,
+		},
+		{
+			name: "apt lists removed in the same RUN",
+			dockerfile: `FROM debian:bookworm
+RUN apt-get update && apt-get install -y curl && rm -rf /var/lib/apt/lists/*
+RUN rm -rf /var/lib/apt/lists/*`,
+		},
+		{
+			name: "download removed relative to WORKDIR",
+			dockerfile: `FROM debian:bookworm
+WORKDIR /tmp
+RUN curl -fsSL -o tool.tar.gz https://example.com/tool.tar.gz && tar -xzf tool.tar.gz
+RUN rm /tmp/tool.tar.gz`,
+			line: 4,
+		},
+		{
+			name: "copied file removed later",
+			dockerfile: `FROM debian:bookworm
+COPY build/ /src/
+RUN make -C /src install && rm -rf /src`,
+			line: 3,
+		},
+		{
+			name: "cache mount keeps the cache out of the layer",
+			dockerfile: `FROM alpine:3.20
+RUN --mount=type=cache,target=/var/cache/apk apk add curl
+RUN rm -rf /var/cache/apk/*`,
+		},
+		{
+			name: "inherited from the parent stage",
+			dockerfile: `FROM alpine:3.20 AS base
+RUN apk add curl
+FROM base
+RUN apk cache clean`,
+			line: 4,
+		},
+		{
+			name: "new stage starts afresh",
+			dockerfile: `FROM alpine:3.20
+RUN apk add curl
+FROM alpine:3.20
+RUN rm -rf /var/cache/apk/*`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := testutils.LintDockerfile(tt.dockerfile, allRules)
+			if tt.line == 0 {
+				testutils.AssertNoViolation(t, violations, "GD2001")
+
+				return
+			}
+
+			if len(violations) != 1 || violations[0].Line != tt.line {
+				t.Errorf("violations = %+v, want one on line %d", violations, tt.line)
+			}
+		})
+	}
+}
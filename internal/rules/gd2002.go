@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2002Meta contains metadata for rule GD2002.
+var GD2002Meta = rule.Meta{
+	Code:     "GD2002",
+	Severity: rule.Warning,
+	Message: "Archive copied in, then extracted and deleted by a later RUN, still takes up space in its layer; " +
+		"download it or bind-mount it (RUN --mount=type=bind) in the RUN extracting it",
+}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2002Rule reports archives brought in by COPY or ADD (remote files, zip
+// files, or any archive COPY copies as is), then extracted and deleted by a
+// later RUN: the archive's layer keeps it, on top of the extracted files.
+type GD2002Rule struct{}
+
+// GD2002 creates the rule reporting archives extracted and deleted in a
+// later layer.
+func GD2002() rule.Rule {
+	return &GD2002Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2002Rule) Code() rule.Code {
+	return GD2002Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2002Rule) Severity() rule.Severity {
+	return GD2002Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2002Rule) Message() string {
+	return GD2002Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2002Rule) InitialState() rule.State {
+	return rule.EmptyState(stageLayers{})
+}
+
+// Check follows the layers of each stage and reports archives extracted and
+// deleted after the layer copying them in.
+func (*GD2002Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return checkLayerRemovals(GD2002Meta, line, state, instruction, func(removal layerRemoval) (string, bool) {
+		if !removal.extracted {
+			return "", false
+		}
+
+		created := removal.created
+
+		return fmt.Sprintf("%s was copied in by %s on line %d", created.path, created.origin, created.line), true
+	})
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2002Rule) Finalize(state rule.State) rule.State {
+	return state
+}
@@ -0,0 +1,64 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2001(),
+		rules.GD2002(),
+	}
+
+	t.Run("warns on a remote archive extracted and deleted later", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+ADD https://example.com/tool-1.0.tar.gz /tmp/
+RUN tar -xzf /tmp/tool-1.0.tar.gz -C /opt && rm /tmp/tool-1.0.tar.gz`, allRules)
+
+		testutils.AssertViolation(t, violations, "GD2002", 3,
+			rules.GD2002Meta.Message+": /tmp/tool-1.0.tar.gz was copied in by ADD on line 2")
+		testutils.AssertNoViolation(t, violations, "GD2001")
+	})
+
+	t.Run("warns on a copied zip unzipped and deleted later", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+WORKDIR /opt
+COPY dist.zip .
+RUN unzip dist.zip && rm dist.zip`, allRules)
+
+		testutils.AssertViolation(t, violations, "GD2002", 4,
+			rules.GD2002Meta.Message+": /opt/dist.zip was copied in by COPY on line 3")
+	})
+
+	t.Run("does not warn on a local archive ADD extracts", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+ADD rootfs.tar.gz /
+RUN rm -rf /rootfs.tar.gz`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2002")
+	})
+
+	t.Run("removal without extraction is GD2001's", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM debian:bookworm
+COPY dist.zip /tmp/dist.zip
+RUN rm /tmp/dist.zip`, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2002")
+		testutils.AssertViolation(t, violations, "GD2001", 3,
+			rules.GD2001Meta.Message+": /tmp/dist.zip was created by COPY on line 2")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2101Meta contains metadata for rule GD2101.
+var GD2101Meta = rule.Meta{
+	Code:     "GD2101",
+	Severity: rule.Warning,
+	Message: "Mount a cache on the Go build cache (RUN --mount=type=cache,target=/root/.cache/go-build), " +
+		"or clean it with go clean -cache, it otherwise ends up in the layer",
+}
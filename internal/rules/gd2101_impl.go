@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2101Rule reports go build and go install runs whose build cache, in
+// $GOCACHE (/root/.cache/go-build by default), is neither on a cache or
+// tmpfs mount nor cleaned by the RUN: hundreds of megabytes of compiled
+// packages end up in the layer.
+type GD2101Rule struct{}
+
+// GD2101 creates the rule checking the Go build cache stays out of the
+// layer.
+func GD2101() rule.Rule {
+	return &GD2101Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2101Rule) Code() rule.Code {
+	return GD2101Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2101Rule) Severity() rule.Severity {
+	return GD2101Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2101Rule) Message() string {
+	return GD2101Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2101Rule) InitialState() rule.State {
+	return rule.EmptyState(stageEnv{})
+}
+
+// Check follows the stage environment and checks the RUNs building Go
+// binaries.
+func (*GD2101Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	env := rule.Data[stageEnv](state)
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return state.ReplaceData(env.apply(instruction))
+	}
+
+	commands := runSimpleCommands(run)
+	if slices.ContainsFunc(commands, func(simple *shell.Simple) bool {
+		return programName(*simple.Command) == "go" && subcommand(*simple.Command) == "clean" &&
+			shell.HasArg("-cache", *simple.Command)
+	}) {
+		return state
+	}
+
+	for _, simple := range commands {
+		if !isGoBuild(*simple.Command) {
+			continue
+		}
+
+		gocache := env.lookup(simple, "GOCACHE")
+		if gocache == "" {
+			gocache = "/root/.cache/go-build"
+		}
+
+		if gocache == "off" || isUnresolved(gocache) || isUnderCacheOrTmpfsMount(run.Flags, gocache) {
+			continue
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     GD2101Meta.Code,
+			Severity: GD2101Meta.Severity,
+			Message:  GD2101Meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2101Rule) Finalize(state rule.State) rule.State {
+	return state
+}
@@ -0,0 +1,99 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2101(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2101(),
+	}
+
+	t.Run("go build", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go build -trimpath -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2101")
+	})
+
+	t.Run("cache mount", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN --mount=type=cache,target=/root/.cache/go-build go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+
+	t.Run("cache mount on a parent", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN --mount=type=cache,target=/root/.cache go install ./cmd/app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+
+	t.Run("GOCACHE in ENV", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+ENV GOCACHE=/cache
+RUN --mount=type=cache,target=/cache go build .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+
+	t.Run("GOCACHE elsewhere", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+ENV GOCACHE=/cache
+RUN --mount=type=cache,target=/root/.cache/go-build go build .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2101")
+	})
+
+	t.Run("inline GOCACHE", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN --mount=type=tmpfs,target=/tmp/gocache GOCACHE=/tmp/gocache go build .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+
+	t.Run("go clean -cache", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go build -o /app . && go clean -cache`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+
+	t.Run("go test", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go test ./...`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2101")
+	})
+}
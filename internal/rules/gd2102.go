@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2102Meta contains metadata for rule GD2102.
+var GD2102Meta = rule.Meta{
+	Code:     "GD2102",
+	Severity: rule.Info,
+	Message:  "Build Go binaries with -trimpath, they otherwise embed the paths of the build stage",
+}
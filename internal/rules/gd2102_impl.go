@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2102Rule reports go build and go install runs without -trimpath, on the
+// command line or in $GOFLAGS: the binaries embed the absolute paths of the
+// build stage, which makes them larger and not reproducible.
+type GD2102Rule struct{}
+
+// GD2102 creates the rule checking Go binaries are built with -trimpath.
+func GD2102() rule.Rule {
+	return &GD2102Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2102Rule) Code() rule.Code {
+	return GD2102Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2102Rule) Severity() rule.Severity {
+	return GD2102Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2102Rule) Message() string {
+	return GD2102Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2102Rule) InitialState() rule.State {
+	return rule.EmptyState(stageEnv{})
+}
+
+// Check follows the stage environment and checks the RUNs building Go
+// binaries.
+func (*GD2102Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	env := rule.Data[stageEnv](state)
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return state.ReplaceData(env.apply(instruction))
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		if !isGoBuild(*simple.Command) {
+			continue
+		}
+
+		goflags := env.lookup(simple, "GOFLAGS")
+		if isUnresolved(goflags) || strings.Contains(goflags, "-trimpath") ||
+			slices.ContainsFunc(shell.GetArgs(*simple.Command), isTrimpathFlag) {
+			continue
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     GD2102Meta.Code,
+			Severity: GD2102Meta.Severity,
+			Message:  GD2102Meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2102Rule) Finalize(state rule.State) rule.State {
+	return state
+}
+
+// isTrimpathFlag reports whether arg is -trimpath, --trimpath or
+// -trimpath=true.
+func isTrimpathFlag(arg string) bool {
+	name, value, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+
+	return strings.HasPrefix(arg, "-") && name == "trimpath" && (value == "" || value == "true")
+}
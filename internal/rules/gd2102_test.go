@@ -0,0 +1,80 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2102(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2102(),
+	}
+
+	t.Run("without -trimpath", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2102")
+	})
+
+	t.Run("with -trimpath", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go build -trimpath -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2102")
+	})
+
+	t.Run("with --trimpath=true", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install --trimpath=true ./cmd/app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2102")
+	})
+
+	t.Run("GOFLAGS in ENV", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+ENV GOFLAGS="-trimpath -mod=readonly"
+RUN go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2102")
+	})
+
+	t.Run("GOFLAGS reset by FROM", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+ENV GOFLAGS=-trimpath
+FROM golang:1.23
+RUN go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2102")
+	})
+
+	t.Run("inline GOFLAGS", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN GOFLAGS=-trimpath go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2102")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2103Meta contains metadata for rule GD2103.
+var GD2103Meta = rule.Meta{
+	Code:     "GD2103",
+	Severity: rule.Warning,
+	Message: "Mount a cache on the cargo registry or the target directory " +
+		"(RUN --mount=type=cache,target=/usr/local/cargo/registry), they otherwise end up in the layer",
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"path"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2103Rule reports cargo build and cargo install runs without a cache or
+// tmpfs mount on the cargo registry ($CARGO_HOME/registry) or the target
+// directory: downloaded crates and build artifacts end up in the layer.
+type GD2103Rule struct{}
+
+// GD2103 creates the rule checking cargo builds use a cache mount.
+func GD2103() rule.Rule {
+	return &GD2103Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2103Rule) Code() rule.Code {
+	return GD2103Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2103Rule) Severity() rule.Severity {
+	return GD2103Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2103Rule) Message() string {
+	return GD2103Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2103Rule) InitialState() rule.State {
+	return rule.EmptyState(stageEnv{})
+}
+
+// Check follows the stage environment and checks the RUNs building Rust
+// crates.
+func (*GD2103Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	env := rule.Data[stageEnv](state)
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return state.ReplaceData(env.apply(instruction))
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if sub := subcommand(cmd); programName(cmd) != "cargo" || (sub != "build" && sub != "install") {
+			continue
+		}
+
+		if cargoCacheMounted(run.Flags, env, simple) {
+			continue
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     GD2103Meta.Code,
+			Severity: GD2103Meta.Severity,
+			Message:  GD2103Meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2103Rule) Finalize(state rule.State) rule.State {
+	return state
+}
+
+// cargoCacheMounted reports whether a cache or tmpfs mount holds the cargo
+// registry, or the target directory.
+func cargoCacheMounted(flags []string, env stageEnv, simple *shell.Simple) bool {
+	// The rust images set CARGO_HOME to /usr/local/cargo, rustup's default
+	// is ~/.cargo.
+	homes := []string{"/usr/local/cargo", "/root/.cargo"}
+	if home := env.lookup(simple, "CARGO_HOME"); home != "" {
+		homes = []string{home}
+	}
+
+	for _, home := range homes {
+		if isUnresolved(home) || isUnderCacheOrTmpfsMount(flags, path.Join(home, "registry")) {
+			return true
+		}
+	}
+
+	targets := shell.GetFlagArg("target-dir", *simple.Command)
+	if dir := env.lookup(simple, "CARGO_TARGET_DIR"); dir != "" {
+		targets = append(targets, dir)
+	}
+
+	for _, target := range targets {
+		if isUnresolved(target) || isUnderCacheOrTmpfsMount(flags, target) {
+			return true
+		}
+	}
+
+	return hasCacheOrTmpfsMountNamed(flags, "target")
+}
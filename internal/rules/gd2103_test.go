@@ -0,0 +1,79 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2103(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2103(),
+	}
+
+	t.Run("cargo build", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo build --release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2103")
+	})
+
+	t.Run("registry mount", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN --mount=type=cache,target=/usr/local/cargo/registry cargo build --release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2103")
+	})
+
+	t.Run("target mount", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+WORKDIR /src
+RUN --mount=type=cache,target=/src/target cargo build --release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2103")
+	})
+
+	t.Run("CARGO_HOME", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+ENV CARGO_HOME=/cargo
+RUN --mount=type=cache,target=/cargo cargo install ripgrep`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2103")
+	})
+
+	t.Run("bind mount only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN --mount=type=bind,target=/src cargo build --release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2103")
+	})
+
+	t.Run("cargo test", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo test`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2103")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2104Meta contains metadata for rule GD2104.
+var GD2104Meta = rule.Meta{
+	Code:     "GD2104",
+	Severity: rule.Warning,
+	Message: "Mount a cache on the Maven or Gradle repository (RUN --mount=type=cache,target=/root/.m2), " +
+		"or build offline, downloaded dependencies otherwise end up in the layer",
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2104Rule reports Maven and Gradle builds that neither run offline nor
+// keep their dependency repository on a cache or tmpfs mount: every
+// downloaded dependency ends up in the layer. Builds running offline rely
+// on an earlier dependency layer, which is a deliberate trade-off.
+type GD2104Rule struct{}
+
+// GD2104 creates the rule checking Maven and Gradle builds cache their
+// dependencies.
+func GD2104() rule.Rule {
+	return &GD2104Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2104Rule) Code() rule.Code {
+	return GD2104Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2104Rule) Severity() rule.Severity {
+	return GD2104Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2104Rule) Message() string {
+	return GD2104Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2104Rule) InitialState() rule.State {
+	return rule.EmptyState(stageEnv{})
+}
+
+// Check follows the stage environment and checks the RUNs running Maven or
+// Gradle.
+func (*GD2104Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	env := rule.Data[stageEnv](state)
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return state.ReplaceData(env.apply(instruction))
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+
+		var cached bool
+
+		switch programName(cmd) {
+		case "mvn", "mvnw":
+			cached = mavenRepositoryCached(run.Flags, cmd)
+		case "gradle", "gradlew":
+			cached = gradleHomeCached(run.Flags, env, simple)
+		default:
+			continue
+		}
+
+		args := shell.GetArgs(cmd)
+		if cached || len(shell.GetArgsNoFlags(cmd)) == 0 || slices.Contains(args, "-o") ||
+			slices.Contains(args, "--offline") {
+			continue
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     GD2104Meta.Code,
+			Severity: GD2104Meta.Severity,
+			Message:  GD2104Meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// Finalize performs final checks after processing all instructions.
+func (*GD2104Rule) Finalize(state rule.State) rule.State {
+	return state
+}
+
+// mavenRepositoryCached reports whether a cache or tmpfs mount holds the
+// Maven local repository: ~/.m2, or -Dmaven.repo.local.
+func mavenRepositoryCached(flags []string, cmd shell.Command) bool {
+	for _, arg := range shell.GetArgs(cmd) {
+		if repository, ok := strings.CutPrefix(arg, "-Dmaven.repo.local="); ok {
+			return isUnresolved(repository) || isUnderCacheOrTmpfsMount(flags, repository)
+		}
+	}
+
+	return isUnderCacheOrTmpfsMount(flags, "/root/.m2") || hasCacheOrTmpfsMountNamed(flags, ".m2")
+}
+
+// gradleHomeCached reports whether a cache or tmpfs mount holds the Gradle
+// user home: $GRADLE_USER_HOME, or ~/.gradle (/home/gradle/.gradle in the
+// gradle images).
+func gradleHomeCached(flags []string, env stageEnv, simple *shell.Simple) bool {
+	if home := env.lookup(simple, "GRADLE_USER_HOME"); home != "" {
+		return isUnresolved(home) || isUnderCacheOrTmpfsMount(flags, home)
+	}
+
+	return hasCacheOrTmpfsMountNamed(flags, ".gradle", "caches")
+}
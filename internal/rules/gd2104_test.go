@@ -0,0 +1,98 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2104(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2104(),
+	}
+
+	t.Run("mvn package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM maven:3-eclipse-temurin-21
+RUN mvn -B package`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2104")
+	})
+
+	t.Run("m2 mount", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM maven:3-eclipse-temurin-21
+RUN --mount=type=cache,target=/root/.m2 mvn -B package`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2104")
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM maven:3-eclipse-temurin-21
+RUN mvn -o package`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2104")
+	})
+
+	t.Run("maven.repo.local", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM maven:3-eclipse-temurin-21
+RUN --mount=type=cache,target=/repo ./mvnw -Dmaven.repo.local=/repo verify`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2104")
+	})
+
+	t.Run("gradle build", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM gradle:8-jdk21
+RUN gradle build`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2104")
+	})
+
+	t.Run("gradle home mount", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM gradle:8-jdk21
+RUN --mount=type=cache,target=/home/gradle/.gradle ./gradlew build`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2104")
+	})
+
+	t.Run("gradle --offline", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM gradle:8-jdk21
+RUN gradle --offline build`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2104")
+	})
+
+	t.Run("GRADLE_USER_HOME elsewhere", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM gradle:8-jdk21
+ENV GRADLE_USER_HOME=/gh
+RUN --mount=type=cache,target=/root/.gradle gradle build`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2104")
+	})
+}
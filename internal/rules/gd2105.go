@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2105Meta contains metadata for rule GD2105.
+var GD2105Meta = rule.Meta{
+	Code:     "GD2105",
+	Severity: rule.Warning,
+	Message:  "Use composer install --no-dev in the final stage, development dependencies do not belong in the image",
+}
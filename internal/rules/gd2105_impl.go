@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"path"
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd2105State tracks the composer installs keeping development
+// dependencies, by stage.
+type gd2105State struct {
+	env      stageEnv
+	stage    int         // Index of the current stage
+	installs map[int]int // map[line]stage
+}
+
+// GD2105Rule reports composer install runs that keep the development
+// dependencies (no --no-dev, nor COMPOSER_NO_DEV) in the final stage. Build
+// stages may need them, for tests or asset builds.
+type GD2105Rule struct{}
+
+// GD2105 creates the rule checking the final stage installs composer
+// dependencies without the development ones.
+func GD2105() rule.Rule {
+	return &GD2105Rule{}
+}
+
+// Code returns the rule code.
+func (*GD2105Rule) Code() rule.Code {
+	return GD2105Meta.Code
+}
+
+// Severity returns the rule severity.
+func (*GD2105Rule) Severity() rule.Severity {
+	return GD2105Meta.Severity
+}
+
+// Message returns the rule message.
+func (*GD2105Rule) Message() string {
+	return GD2105Meta.Message
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD2105Rule) InitialState() rule.State {
+	return rule.EmptyState(gd2105State{installs: make(map[int]int)})
+}
+
+// Check records the composer installs keeping development dependencies.
+func (*GD2105Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	currentState := rule.Data[gd2105State](state)
+
+	if _, ok := instruction.(*syntax.From); ok {
+		currentState.stage++
+	}
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		currentState.env = currentState.env.apply(instruction)
+
+		return state.ReplaceData(currentState)
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		if isComposerInstall(*simple.Command) && !slices.Contains(shell.GetArgs(*simple.Command), "--no-dev") &&
+			!isTruthy(currentState.env.lookup(simple, "COMPOSER_NO_DEV")) {
+			currentState.installs[line] = currentState.stage
+		}
+	}
+
+	return state.ReplaceData(currentState)
+}
+
+// Finalize reports the composer installs of the final stage.
+func (*GD2105Rule) Finalize(state rule.State) rule.State {
+	currentState := rule.Data[gd2105State](state)
+
+	lines := make([]int, 0, len(currentState.installs))
+	for line, stage := range currentState.installs {
+		if stage == currentState.stage {
+			lines = append(lines, line)
+		}
+	}
+
+	slices.Sort(lines)
+
+	for _, line := range lines {
+		state = state.AddFailure(rule.CheckFailure{
+			Code:     GD2105Meta.Code,
+			Severity: GD2105Meta.Severity,
+			Message:  GD2105Meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// isComposerInstall reports whether a command is composer install, run as
+// composer, composer.phar or php composer.phar.
+func isComposerInstall(cmd shell.Command) bool {
+	args := shell.GetArgsNoFlags(cmd)
+
+	name := programName(cmd)
+	if name == "php" && len(args) > 0 {
+		name, args = path.Base(args[0]), args[1:]
+	}
+
+	return (name == "composer" || name == "composer.phar") && len(args) > 0 && args[0] == "install"
+}
@@ -0,0 +1,80 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2105(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2105(),
+	}
+
+	t.Run("final stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM php:8.3
+RUN composer install`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2105")
+	})
+
+	t.Run("--no-dev", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM php:8.3
+RUN composer install --no-dev --optimize-autoloader`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2105")
+	})
+
+	t.Run("COMPOSER_NO_DEV", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM php:8.3
+ENV COMPOSER_NO_DEV=1
+RUN composer install`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2105")
+	})
+
+	t.Run("build stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2 AS vendor
+RUN composer install
+FROM php:8.3
+COPY --from=vendor /app/vendor /app/vendor`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2105")
+	})
+
+	t.Run("php composer.phar", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM php:8.3
+RUN php composer.phar install`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2105")
+	})
+
+	t.Run("composer require", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM php:8.3
+RUN composer require monolog/monolog`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2105")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD2106Meta contains metadata for rule GD2106.
+var GD2106Meta = rule.Meta{
+	Code:     "GD2106",
+	Severity: rule.Info,
+	Message:  "Use npm ci instead of npm install, to install the exact dependencies of the lockfile",
+}
@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD2106 creates the rule preferring npm ci to npm install: it installs the
+// lockfile's exact dependencies into a clean node_modules, and fails when the
+// lockfile and package.json disagree. Installs of named packages are
+// DL3016's.
+func GD2106() rule.Rule {
+	return rule.NewSimpleRule(
+		GD2106Meta.Code,
+		GD2106Meta.Severity,
+		GD2106Meta.Message,
+		checkGD2106,
+	)
+}
+
+// npmInstallAliases are the npm install command and its aliases.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var npmInstallAliases = []string{"install", "i", "in", "ins", "inst", "insta", "instal", "isnt", "isnta", "isntal", "add"}
+
+func checkGD2106(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		args := shell.GetArgsNoFlags(cmd)
+
+		if programName(cmd) == "npm" && len(args) == 1 && slices.Contains(npmInstallAliases, args[0]) &&
+			!shell.HasAnyFlag([]string{"g", "global"}, cmd) {
+			return false
+		}
+	}
+
+	return true
+}
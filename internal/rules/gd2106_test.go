@@ -0,0 +1,67 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD2106(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD2106(),
+	}
+
+	t.Run("npm install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN npm install`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2106")
+	})
+
+	t.Run("npm i with flags", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN npm i --omit=dev`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD2106")
+	})
+
+	t.Run("npm ci", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN npm ci --omit=dev`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2106")
+	})
+
+	t.Run("named package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN npm install express@4.19.2`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2106")
+	})
+
+	t.Run("global install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN npm install -g`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD2106")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3001Meta contains metadata for rule GD3001.
+var GD3001Meta = rule.Meta{
+	Code:     "GD3001",
+	Severity: rule.Warning,
+	Message:  "Pin versions in go install. Instead of `go install <package>@latest` use `go install <package>@<version>`",
+}
@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3001 creates the rule checking go install pins a version: a semantic
+// version tag (@v1.2.3) or a commit, not @latest or a branch. Packages
+// without a version are resolved through the go.mod of the module being
+// built, and pass.
+func GD3001() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3001Meta.Code,
+		GD3001Meta.Severity,
+		GD3001Meta.Message,
+		checkGD3001,
+	)
+}
+
+// goBuildValueFlags are the go build flags taking a separate value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var goBuildValueFlags = []string{
+	"C", "o", "p", "asmflags", "buildmode", "buildvcs", "compiler", "gccgoflags", "gcflags",
+	"installsuffix", "ldflags", "mod", "modfile", "overlay", "pgo", "pkgdir", "tags", "toolexec",
+}
+
+func checkGD3001(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) != "go" || subcommand(cmd) != "install" {
+			continue
+		}
+
+		for _, pkg := range packageArgs(cmd, 1, goBuildValueFlags...) {
+			if _, version, ok := splitVersionAt(pkg); ok && !isGoVersionFixed(version) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isGoVersionFixed reports whether a go install version query names a
+// single revision: a semantic version or a commit hash. Expansions are
+// given the benefit of the doubt.
+func isGoVersionFixed(version string) bool {
+	if isUnresolved(version) {
+		return true
+	}
+
+	if strings.HasPrefix(version, "v") {
+		return goSemVer.MatchString(version)
+	}
+
+	return goCommit.MatchString(version)
+}
+
+// goSemVer matches the semantic versions of go modules, pre-releases and
+// pseudo-versions included.
+var goSemVer = regexp.MustCompile(`^v\d+(\.\d+){2}`)
+
+// goCommit matches a commit hash, full or abbreviated.
+var goCommit = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
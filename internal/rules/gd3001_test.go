@@ -0,0 +1,117 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3001(),
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install golang.org/x/tools/gopls@latest`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3001")
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install github.com/acme/tool@main`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3001")
+	})
+
+	t.Run("semver", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install golang.org/x/tools/gopls@v0.16.2`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install github.com/acme/tool@4f1c2b9e`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+
+	t.Run("variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install github.com/acme/tool@${TOOL_VERSION}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+
+	t.Run("flags before package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install -ldflags '-s -w' github.com/acme/tool@latest`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3001")
+	})
+
+	t.Run("flag value before package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install -tags netgo -o /usr/local/bin github.com/acme/tool@v1.2.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+
+	t.Run("flag value after a pinned package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install --tags=netgo github.com/acme/tool@v1.2.3 github.com/acme/other@main`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3001")
+	})
+
+	t.Run("module package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go install ./cmd/app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+
+	t.Run("go build", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23
+RUN go build -o /app .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3001")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3002Meta contains metadata for rule GD3002.
+var GD3002Meta = rule.Meta{
+	Code:     "GD3002",
+	Severity: rule.Warning,
+	Message:  "Pin versions in cargo install. Instead of `cargo install <crate>` use `cargo install <crate>@<version>` or `--version`",
+}
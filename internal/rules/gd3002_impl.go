@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3002 creates the rule checking cargo install pins a version: with
+// --version, as crate@version, or for a git source with --tag or --rev.
+// Installs of a local --path pass.
+func GD3002() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3002Meta.Code,
+		GD3002Meta.Severity,
+		GD3002Meta.Message,
+		checkGD3002,
+	)
+}
+
+// cargoInstallValueFlags are the cargo install flags taking a separate
+// value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var cargoInstallValueFlags = []string{
+	"version", "vers", "git", "branch", "tag", "rev", "path", "root", "index", "registry",
+	"features", "F", "jobs", "j", "target", "target-dir", "profile", "bin", "example",
+	"config", "color", "Z",
+}
+
+func checkGD3002(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) == "cargo" && subcommand(cmd) == "install" && forgotToPinCargoVersion(cmd) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func forgotToPinCargoVersion(cmd shell.Command) bool {
+	switch {
+	case hasFlagValue(cmd, "path"):
+		return false
+	case hasFlagValue(cmd, "git"):
+		return !hasFlagValue(cmd, "tag", "rev")
+	case hasFlagValue(cmd, "version", "vers"):
+		return false
+	}
+
+	for _, crate := range packageArgs(cmd, 1, cargoInstallValueFlags...) {
+		if _, version, ok := splitVersionAt(crate); !ok || isFloatingVersion(version) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3002(),
+	}
+
+	t.Run("no version", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install ripgrep`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3002")
+	})
+
+	t.Run("crate at version", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install ripgrep@14.1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3002")
+	})
+
+	t.Run("version flag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install ripgrep --version 14.1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3002")
+	})
+
+	t.Run("version requirement", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install --version ^14 ripgrep`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3002")
+	})
+
+	t.Run("git branch", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install --git https://github.com/acme/tool --branch main`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3002")
+	})
+
+	t.Run("git tag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install --git https://github.com/acme/tool --tag v1.0.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3002")
+	})
+
+	t.Run("local path", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install --path .`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3002")
+	})
+
+	t.Run("locked flag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM rust:1.80
+RUN cargo install --locked --root /usr/local ripgrep`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3002")
+	})
+}
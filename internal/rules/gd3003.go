@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3003Meta contains metadata for rule GD3003.
+var GD3003Meta = rule.Meta{
+	Code:     "GD3003",
+	Severity: rule.Warning,
+	Message:  "Pin versions in pnpm. Instead of `pnpm add <package>` use `pnpm add <package>@<version>`",
+}
@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3003 creates the rule checking packages added with pnpm, globally or
+// not, pin a version: package@version, a tarball, a folder or a git
+// commit, as DL3016 accepts for npm. The @latest tag does not pin.
+func GD3003() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3003Meta.Code,
+		GD3003Meta.Severity,
+		GD3003Meta.Message,
+		checkGD3003,
+	)
+}
+
+// pnpmAddCommands are the pnpm commands taking packages to add.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var pnpmAddCommands = []string{"add", "install", "i"}
+
+// pnpmValueFlags are the pnpm flags taking a separate value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var pnpmValueFlags = []string{"filter", "F", "dir", "C", "store-dir", "global-dir", "reporter", "registry", "config"}
+
+func checkGD3003(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) != "pnpm" || !slices.Contains(pnpmAddCommands, subcommand(cmd)) {
+			continue
+		}
+
+		for _, pkg := range packageArgs(cmd, 1, pnpmValueFlags...) {
+			if _, version, ok := splitVersionAt(pkg); (ok && isFloatingVersion(version)) || !isNpmVersionFixed(pkg) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3003(),
+	}
+
+	t.Run("global add", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add -g typescript`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3003")
+	})
+
+	t.Run("latest tag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add -g typescript@latest`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3003")
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add -g typescript@5.6.2`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3003")
+	})
+
+	t.Run("scoped pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add @types/node@20.11.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3003")
+	})
+
+	t.Run("scoped unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add @types/node`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3003")
+	})
+
+	t.Run("filter value", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm add --filter web react@18.3.1`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3003")
+	})
+
+	t.Run("lockfile install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+RUN pnpm install --frozen-lockfile`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3003")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3004Meta contains metadata for rule GD3004.
+var GD3004Meta = rule.Meta{
+	Code:     "GD3004",
+	Severity: rule.Warning,
+	Message:  "Pin versions in pipx. Instead of `pipx install <package>` use `pipx install <package>==<version>`",
+}
@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3004 creates the rule checking pipx install and pipx inject pin a
+// version. Package specs, given as operands or with --spec, follow pip's
+// syntax and are checked as DL3013 checks pip's.
+func GD3004() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3004Meta.Code,
+		GD3004Meta.Severity,
+		GD3004Meta.Message,
+		checkGD3004,
+	)
+}
+
+// pipxValueFlags are the pipx install and inject flags taking a separate
+// value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var pipxValueFlags = []string{"spec", "python", "index-url", "i", "pip-args", "suffix", "preinstall"}
+
+func checkGD3004(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) == "pipx" && forgotToPinPipxVersion(cmd) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func forgotToPinPipxVersion(cmd shell.Command) bool {
+	var specs []string
+
+	switch subcommand(cmd) {
+	case "install":
+		if specs = shell.GetFlagArg("spec", cmd); len(specs) == 0 {
+			specs = packageArgs(cmd, 1, pipxValueFlags...)
+		}
+	case "inject":
+		// The first operand is the venv injected into.
+		specs = packageArgs(cmd, 2, pipxValueFlags...)
+	}
+
+	for _, spec := range specs {
+		if !isPipVersionFixed(spec) {
+			return true
+		}
+	}
+
+	return false
+}
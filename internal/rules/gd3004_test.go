@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3004(),
+	}
+
+	t.Run("unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx install black`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3004")
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx install black==24.8.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3004")
+	})
+
+	t.Run("spec flag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx install --spec black==24.8.0 black`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3004")
+	})
+
+	t.Run("unpinned spec", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx install --spec black black`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3004")
+	})
+
+	t.Run("python flag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx install --python python3.12 poetry==1.8.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3004")
+	})
+
+	t.Run("inject unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx inject poetry==1.8.3 && pipx inject poetry poetry-plugin-export`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3004")
+	})
+
+	t.Run("inject pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN pipx inject poetry poetry-plugin-export==1.8.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3004")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3005Meta contains metadata for rule GD3005.
+var GD3005Meta = rule.Meta{
+	Code:     "GD3005",
+	Severity: rule.Warning,
+	Message:  "Pin versions in poetry. Instead of `poetry add <package>` use `poetry add <package>@<version>`",
+}
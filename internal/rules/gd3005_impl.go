@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3005 creates the rule checking poetry add, and poetry self add, pin a
+// version: package@constraint (@^1.2, @~1.2, @1.2.3), a pip style
+// constraint (==, >=), a path, or a versioned VCS URL. The @latest
+// constraint does not pin.
+func GD3005() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3005Meta.Code,
+		GD3005Meta.Severity,
+		GD3005Meta.Message,
+		checkGD3005,
+	)
+}
+
+// poetryAddValueFlags are the poetry add flags taking a separate value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var poetryAddValueFlags = []string{
+	"group", "G", "extras", "E", "source", "python", "platform", "markers", "directory", "C", "project", "P",
+}
+
+func checkGD3005(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) == "poetry" && slices.ContainsFunc(poetryAddPackages(cmd), isPoetryVersionFloating) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// poetryAddPackages returns the packages of a poetry add or poetry self add.
+func poetryAddPackages(cmd shell.Command) []string {
+	args := packageArgs(cmd, 0, poetryAddValueFlags...)
+
+	switch {
+	case len(args) > 0 && args[0] == "add":
+		return args[1:]
+	case len(args) > 1 && args[0] == "self" && args[1] == "add":
+		return args[2:]
+	}
+
+	return nil
+}
+
+func isPoetryVersionFloating(pkg string) bool {
+	if _, version, ok := splitVersionAt(pkg); ok {
+		return isFloatingVersion(version)
+	}
+
+	return !isPipVersionFixed(pkg) && !isLocalPath(pkg)
+}
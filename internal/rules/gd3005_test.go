@@ -0,0 +1,97 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3005(),
+	}
+
+	t.Run("unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add requests`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3005")
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add requests@latest`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3005")
+	})
+
+	t.Run("caret", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add requests@^2.32`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3005")
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add requests==2.32.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3005")
+	})
+
+	t.Run("group value", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add --group dev pytest@8.3.2`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3005")
+	})
+
+	t.Run("self add", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry self add poetry-plugin-export`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3005")
+	})
+
+	t.Run("local path", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry add ./libs/core`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3005")
+	})
+
+	t.Run("install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN poetry install --no-root`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3005")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3006Meta contains metadata for rule GD3006.
+var GD3006Meta = rule.Meta{
+	Code:     "GD3006",
+	Severity: rule.Warning,
+	Message:  "Pin versions in conda. Instead of `conda install <package>` use `conda install <package>=<version>`",
+}
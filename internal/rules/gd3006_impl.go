@@ -0,0 +1,65 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3006 creates the rule checking conda, mamba and micromamba install and
+// create pin versions: a match spec with a version (numpy=1.26,
+// "numpy>=1.26,<2", "numpy 1.26"). Installs from a --file pass.
+func GD3006() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3006Meta.Code,
+		GD3006Meta.Severity,
+		GD3006Meta.Message,
+		checkGD3006,
+	)
+}
+
+// condaPrograms are the conda compatible package managers.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var condaPrograms = []string{"conda", "mamba", "micromamba"}
+
+// condaValueFlags are the conda install and create flags taking a separate
+// value.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var condaValueFlags = []string{
+	"channel", "c", "name", "n", "prefix", "p", "file", "platform", "repodata-fn", "root-prefix", "r",
+}
+
+func checkGD3006(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if slices.Contains(condaPrograms, programName(cmd)) && forgotToPinCondaVersion(cmd) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func forgotToPinCondaVersion(cmd shell.Command) bool {
+	if sub := subcommand(cmd); (sub != "install" && sub != "create") || hasFlagValue(cmd, "file") {
+		return false
+	}
+
+	for _, spec := range packageArgs(cmd, 1, condaValueFlags...) {
+		if !strings.ContainsAny(spec, "=<> ") && !isLocalPath(spec) && !isUnresolved(spec) {
+			return true
+		}
+	}
+
+	return false
+}
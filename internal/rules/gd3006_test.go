@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3006(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3006(),
+	}
+
+	t.Run("unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda install -y numpy`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3006")
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda install -y numpy=1.26.4`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3006")
+	})
+
+	t.Run("channel value", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda install -c conda-forge numpy==1.26.4`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3006")
+	})
+
+	t.Run("range", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda install 'numpy>=1.26,<2'`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3006")
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda create -n app python=3.12 pandas`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3006")
+	})
+
+	t.Run("micromamba", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mambaorg/micromamba
+RUN micromamba install -y -n base -c conda-forge python=3.12`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3006")
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM continuumio/miniconda3
+RUN conda install --file requirements.txt`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3006")
+	})
+}
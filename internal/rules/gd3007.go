@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3007Meta contains metadata for rule GD3007.
+var GD3007Meta = rule.Meta{
+	Code:     "GD3007",
+	Severity: rule.Warning,
+	Message:  "Pin versions in composer. Instead of `composer require <package>` use `composer require <package>:<version>`",
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3007 creates the rule checking composer require, and composer global
+// require, pin versions: vendor/package:constraint, vendor/package=constraint,
+// or the constraint as the next operand (vendor/package ^1.2).
+func GD3007() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3007Meta.Code,
+		GD3007Meta.Severity,
+		GD3007Meta.Message,
+		checkGD3007,
+	)
+}
+
+func checkGD3007(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if isComposer(cmd) && forgotToPinComposerVersion(cmd) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isComposer reports whether a command runs composer, installed as
+// composer or as composer.phar.
+func isComposer(cmd shell.Command) bool {
+	name := programName(cmd)
+
+	return name == "composer" || name == "composer.phar"
+}
+
+func forgotToPinComposerVersion(cmd shell.Command) bool {
+	args := packageArgs(cmd, 0, "working-dir", "d")
+	if len(args) > 0 && args[0] == "global" {
+		args = args[1:]
+	}
+
+	if len(args) == 0 || args[0] != "require" {
+		return false
+	}
+
+	packages := args[1:]
+	for i := 0; i < len(packages); i++ {
+		if strings.ContainsAny(packages[i], ":= ") || isUnresolved(packages[i]) {
+			continue
+		}
+
+		if i+1 < len(packages) && isComposerConstraint(packages[i+1]) {
+			i++
+
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// isComposerConstraint reports whether an operand is a version constraint
+// rather than a package: 1.2.*, ^1.2, ~1.2, >=1.2, v1.2 or dev-main.
+func isComposerConstraint(arg string) bool {
+	return !strings.Contains(arg, "/") &&
+		regexp.MustCompile(`^(?:[\d^~*<>=!]|v\d|dev-)`).MatchString(arg)
+}
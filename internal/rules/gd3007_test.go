@@ -0,0 +1,77 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3007(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3007(),
+	}
+
+	t.Run("global require", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer global require laravel/installer`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3007")
+	})
+
+	t.Run("colon constraint", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer global require laravel/installer:^5.8`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3007")
+	})
+
+	t.Run("separate constraint", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer require monolog/monolog 3.7.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3007")
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer require monolog/monolog:3.7.0 guzzlehttp/guzzle`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3007")
+	})
+
+	t.Run("working dir value", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer require -d /app monolog/monolog=3.7.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3007")
+	})
+
+	t.Run("install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM composer:2
+RUN composer install --no-dev`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3007")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3008Meta contains metadata for rule GD3008.
+var GD3008Meta = rule.Meta{
+	Code:     "GD3008",
+	Severity: rule.Warning,
+	Message:  "Pin versions in NuGet. Instead of `dotnet tool install <package>` use `dotnet tool install <package> --version <version>`",
+}
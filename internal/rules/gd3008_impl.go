@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3008 creates the rule checking NuGet packages are installed at a
+// version: dotnet tool install and dotnet add package with --version, and
+// nuget install with -Version.
+func GD3008() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3008Meta.Code,
+		GD3008Meta.Severity,
+		GD3008Meta.Message,
+		checkGD3008,
+	)
+}
+
+func checkGD3008(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+
+		switch programName(cmd) {
+		case "dotnet", "dotnet.exe":
+			if forgotToPinDotnetVersion(cmd) {
+				return false
+			}
+		case "nuget", "nuget.exe":
+			if subcommand(cmd) == "install" && isNugetPackageInstall(cmd) && !hasFlagValue(cmd, "Version", "version") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// forgotToPinDotnetVersion reports whether a dotnet command installs a
+// package from a feed without a version: dotnet tool install <tool>, or
+// dotnet add [project] package <package>, where -v is short for --version.
+func forgotToPinDotnetVersion(cmd shell.Command) bool {
+	args := shell.GetArgsNoFlags(cmd)
+
+	switch {
+	case len(args) > 1 && args[0] == "tool" && args[1] == "install":
+		return !hasFlagValue(cmd, "version")
+	case len(args) > 0 && args[0] == "add" && slices.Contains(args[1:], "package"):
+		return !hasFlagValue(cmd, "version", "v")
+	}
+
+	return false
+}
+
+// isNugetPackageInstall reports whether nuget install names a package,
+// rather than a packages.config file that pins its own versions.
+func isNugetPackageInstall(cmd shell.Command) bool {
+	packages := packageArgs(cmd, 1, "OutputDirectory", "Source", "ConfigFile", "Framework", "Verbosity")
+
+	return len(packages) > 0 && !isPackagesConfig(packages[0])
+}
+
+func isPackagesConfig(arg string) bool {
+	return strings.HasSuffix(strings.ToLower(arg), "packages.config")
+}
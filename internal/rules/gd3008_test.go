@@ -0,0 +1,97 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3008(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3008(),
+	}
+
+	t.Run("tool install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN dotnet tool install -g dotnet-ef`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3008")
+	})
+
+	t.Run("tool install pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN dotnet tool install -g dotnet-ef --version 8.0.8`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3008")
+	})
+
+	t.Run("add package", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN dotnet add src/app.csproj package Serilog`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3008")
+	})
+
+	t.Run("add package short version", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN dotnet add package Serilog -v 4.0.1`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3008")
+	})
+
+	t.Run("nuget install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN nuget install Newtonsoft.Json -OutputDirectory packages`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3008")
+	})
+
+	t.Run("nuget install pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN nuget install Newtonsoft.Json -Version 13.0.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3008")
+	})
+
+	t.Run("packages config", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN nuget install packages.config`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3008")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM mcr.microsoft.com/dotnet/sdk:8.0
+RUN dotnet restore`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3008")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD3009Meta contains metadata for rule GD3009.
+var GD3009Meta = rule.Meta{
+	Code:     "GD3009",
+	Severity: rule.Warning,
+	Message:  "Pin versions in bundler. Instead of `bundle add <gem>` use `bundle add <gem> --version <version>`",
+}
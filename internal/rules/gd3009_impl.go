@@ -0,0 +1,47 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD3009 creates the rule checking gems added with bundle add pin a
+// version: --version, any requirement (1.2.3, "~> 1.2"), or for a git
+// source --ref. Gems added from a local --path pass. gem install is
+// DL3028's.
+func GD3009() rule.Rule {
+	return rule.NewSimpleRule(
+		GD3009Meta.Code,
+		GD3009Meta.Severity,
+		GD3009Meta.Message,
+		checkGD3009,
+	)
+}
+
+func checkGD3009(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if programName(cmd) == "bundle" && subcommand(cmd) == "add" && forgotToPinBundlerVersion(cmd) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func forgotToPinBundlerVersion(cmd shell.Command) bool {
+	switch {
+	case hasFlagValue(cmd, "path"):
+		return false
+	case hasFlagValue(cmd, "git", "github"):
+		return !hasFlagValue(cmd, "ref", "version", "v")
+	}
+
+	return !hasFlagValue(cmd, "version", "v")
+}
@@ -0,0 +1,77 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD3009(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD3009(),
+	}
+
+	t.Run("unpinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle add rails`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3009")
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle add rails --version 7.1.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3009")
+	})
+
+	t.Run("requirement", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle add rails --version '~> 7.1'`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3009")
+	})
+
+	t.Run("git without ref", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle add tool --git https://github.com/acme/tool`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD3009")
+	})
+
+	t.Run("git ref", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle add tool --git https://github.com/acme/tool --ref 4f1c2b9`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3009")
+	})
+
+	t.Run("bundle install", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ruby:3.3
+RUN bundle install --jobs 4`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD3009")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD4001Meta contains metadata for rule GD4001.
+var GD4001Meta = rule.Meta{
+	Code:     "GD4001",
+	Severity: rule.Warning,
+	Message:  "Do not pass secrets with ARG or ENV, they are kept in the image history and config. Use RUN --mount=type=secret instead",
+}
@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD4001Rule reports ARG and ENV names suggesting a secret, as BuildKit's
+// SecretsUsedInArgOrEnv check does: build arguments are recorded in the image
+// history, environment variables in its config, whatever their value.
+type GD4001Rule struct {
+	rule.StatefulRuleBase
+
+	secrets *secretDetector
+}
+
+// GD4001 creates the rule reporting secrets passed with ARG or ENV.
+func GD4001() rule.Rule {
+	return GD4001WithConfig(config.Default())
+}
+
+// GD4001WithConfig creates the rule with the secret names of cfg.Secrets
+// (Keys, AllowedKeys) on top of the built-in ones.
+func GD4001WithConfig(cfg *config.Config) rule.Rule {
+	return &GD4001Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD4001Meta),
+		secrets:          newSecretDetector(cfg.Secrets),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD4001Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the secret names of ARG and ENV.
+func (r *GD4001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	var names []string
+
+	switch inst := instruction.(type) {
+	case *syntax.Arg:
+		names = append(names, inst.ArgName)
+
+	case *syntax.Env:
+		for _, pair := range inst.Pairs {
+			names = append(names, pair.Key)
+		}
+	}
+
+	for _, name := range names {
+		if r.secrets.isSecretKey(name) {
+			state = state.AddFailure(secretFailure(GD4001Meta, line, instruction.Name()+" "+name))
+		}
+	}
+
+	return state
+}
+
+// secretFailure reports a secret detection rule's failure, with the
+// offending name or kind of secret, never the secret itself.
+func secretFailure(meta rule.Meta, line int, detail string) rule.CheckFailure {
+	return rule.CheckFailure{
+		Code:     meta.Code,
+		Severity: meta.Severity,
+		Message:  meta.Message + " (" + detail + ")",
+		Line:     line,
+		Column:   1,
+	}
+}
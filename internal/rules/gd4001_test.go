@@ -0,0 +1,130 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD4001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD4001(),
+	}
+
+	t.Run("arg token", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG GITHUB_TOKEN`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+
+	t.Run("env secret", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV AWS_SECRET_ACCESS_KEY=placeholder`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+
+	t.Run("env password among others", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ENV APP_HOME=/app DB_PASSWORD=changeme`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+
+	t.Run("lowercase", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG npm_auth`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG PUBLIC_KEY`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4001")
+	})
+
+	t.Run("word inside name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG MONKEY=1`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4001")
+	})
+
+	t.Run("plain arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG VERSION=1.2.3`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4001")
+	})
+}
+
+func TestGD4001_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Secrets.Keys = []string{`(?i)passphrase`}
+	cfg.Secrets.AllowedKeys = []string{`^SIGNING_KEY_ID$`}
+
+	allRules := []rule.Rule{
+		rules.GD4001WithConfig(cfg),
+	}
+
+	t.Run("configured key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG SIGNING_PASSPHRASE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+
+	t.Run("allowed key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG SIGNING_KEY_ID=ABCDEF`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4001")
+	})
+
+	t.Run("built-in key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ARG API_TOKEN`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4001")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD4002Meta contains metadata for rule GD4002.
+var GD4002Meta = rule.Meta{
+	Code:     "GD4002",
+	Severity: rule.Error,
+	Message:  "Hard-coded secret, anyone with the Dockerfile or the image can read it. Remove and rotate it, and use RUN --mount=type=secret instead",
+}
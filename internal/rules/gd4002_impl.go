@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD4002Rule reports secrets written in the Dockerfile: credential formats
+// recognized by their shape (AWS, GitHub, GitLab, Slack, npm, Google, Stripe
+// keys and tokens, private keys) in ARG, ENV and LABEL values, ADD sources
+// and RUN scripts, and high-entropy literals assigned to a secret name.
+type GD4002Rule struct {
+	rule.StatefulRuleBase
+
+	secrets *secretDetector
+}
+
+// GD4002 creates the rule reporting hard-coded secrets.
+func GD4002() rule.Rule {
+	return GD4002WithConfig(config.Default())
+}
+
+// GD4002WithConfig creates the rule with the secret formats and names of
+// cfg.Secrets (Patterns, Keys, AllowedKeys) on top of the built-in ones.
+func GD4002WithConfig(cfg *config.Config) rule.Rule {
+	return &GD4002Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD4002Meta),
+		secrets:          newSecretDetector(cfg.Secrets),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD4002Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the first secret of each instruction.
+func (r *GD4002Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	if detail, ok := r.findSecret(instruction); ok {
+		return state.AddFailure(secretFailure(GD4002Meta, line, detail))
+	}
+
+	return state
+}
+
+func (r *GD4002Rule) findSecret(instruction syntax.Instruction) (string, bool) {
+	switch inst := instruction.(type) {
+	case *syntax.Arg:
+		if inst.Value != nil {
+			return r.secrets.findHardcodedSecret(inst.ArgName, dropQuotes(*inst.Value))
+		}
+
+	case *syntax.Env:
+		for _, pair := range inst.Pairs {
+			if detail, ok := r.secrets.findHardcodedSecret(pair.Key, dropQuotes(pair.Value)); ok {
+				return detail, true
+			}
+		}
+
+	case *syntax.Label:
+		for _, pair := range inst.Pairs {
+			if detail, ok := r.secrets.findToken(pair.Value); ok {
+				return detail, true
+			}
+		}
+
+	case *syntax.Add:
+		for _, source := range inst.Source {
+			if detail, ok := r.secrets.findToken(source); ok {
+				return detail, true
+			}
+		}
+
+	case *syntax.Run:
+		return r.findRunSecret(inst)
+	}
+
+	return "", false
+}
+
+// findRunSecret looks for credential formats in the script and its
+// here-documents, and for high-entropy assignments to secret names.
+func (r *GD4002Rule) findRunSecret(run *syntax.Run) (string, bool) {
+	if detail, ok := r.secrets.findToken(run.Command); ok {
+		return detail, true
+	}
+
+	for _, heredoc := range run.Heredocs {
+		if detail, ok := r.secrets.findToken(heredoc.Content); ok {
+			return detail, true
+		}
+	}
+
+	for _, assign := range runAssignments(run) {
+		if detail, ok := r.secrets.findHardcodedSecret(assign.Name, assign.Value); ok {
+			return detail, true
+		}
+	}
+
+	return "", false
+}
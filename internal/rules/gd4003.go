@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD4003Meta contains metadata for rule GD4003.
+var GD4003Meta = rule.Meta{
+	Code:     "GD4003",
+	Severity: rule.Error,
+	Message:  "Credentials in a command are kept in the image history. Use RUN --mount=type=secret instead",
+}
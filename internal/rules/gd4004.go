@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD4004Meta contains metadata for rule GD4004.
+var GD4004Meta = rule.Meta{
+	Code:     "GD4004",
+	Severity: rule.Warning,
+	Message:  "Secret file copied into the image, where any later layer removal leaves it readable. Use RUN --mount=type=secret instead",
+}
@@ -0,0 +1,56 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD4004Rule reports COPY and ADD of files holding credentials: SSH and TLS
+// private keys, keystores, .env files, package manager and cloud CLI
+// credentials. Removing them in a later layer does not help, GD2001 has the
+// details.
+type GD4004Rule struct {
+	rule.StatefulRuleBase
+
+	secrets *secretDetector
+}
+
+// GD4004 creates the rule reporting secret files copied into the image.
+func GD4004() rule.Rule {
+	return GD4004WithConfig(config.Default())
+}
+
+// GD4004WithConfig creates the rule with the secret files of
+// cfg.Secrets.Files on top of the built-in ones.
+func GD4004WithConfig(cfg *config.Config) rule.Rule {
+	return &GD4004Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD4004Meta),
+		secrets:          newSecretDetector(cfg.Secrets),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD4004Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports the secret sources of each COPY and ADD.
+func (r *GD4004Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	var sources []string
+
+	switch inst := instruction.(type) {
+	case *syntax.Copy:
+		sources = inst.Source
+	case *syntax.Add:
+		sources = inst.Source
+	}
+
+	for _, source := range sources {
+		if !isURL(source) && r.secrets.isSecretFile(source) {
+			state = state.AddFailure(secretFailure(GD4004Meta, line, source))
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,149 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD4004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD4004(),
+	}
+
+	t.Run("ssh key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY id_rsa /root/.ssh/id_rsa`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("ssh directory", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY .ssh /root/.ssh`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("dotenv", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY .env /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("aws credentials", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY config/.aws/credentials /root/.aws/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("tls key with others", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD cert.pem server.key /etc/tls/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("npmrc", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM node:20
+COPY package.json .npmrc ./`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("certificate", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY ca.pem /etc/tls/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4004")
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY id_rsa.pub /root/.ssh/authorized_keys`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4004")
+	})
+
+	t.Run("env example", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY .env.example /app/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4004")
+	})
+
+	t.Run("sources", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY . /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD4004")
+	})
+}
+
+func TestGD4004_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Secrets.Files = []string{"vault-token"}
+
+	allRules := []rule.Rule{
+		rules.GD4004WithConfig(cfg),
+	}
+
+	t.Run("configured file", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY deploy/vault-token /etc/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+
+	t.Run("built-in file", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY id_ed25519 /root/.ssh/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD4004")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD5001Meta contains metadata for rule GD5001.
+var GD5001Meta = rule.Meta{
+	Code:     "GD5001",
+	Severity: rule.Error,
+	Message:  "Do not pipe a download into an interpreter, it runs whatever the server sends. Download to a file, verify its checksum or signature, then run it",
+}
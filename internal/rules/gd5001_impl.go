@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD5001 creates the rule reporting downloads run by an interpreter without
+// ever landing on disk: curl ... | sh, wget -O- ... | sudo bash, and
+// bash -c "$(curl ...)" or bash <(curl ...). Nothing can be verified, and a
+// truncated download runs half a script.
+func GD5001() rule.Rule {
+	return rule.NewSimpleRule(
+		GD5001Meta.Code,
+		GD5001Meta.Severity,
+		GD5001Meta.Message,
+		checkGD5001,
+	)
+}
+
+func checkGD5001(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	piped := false
+
+	shell.Walk(runStatements(run), func(stmt *shell.Statement, _ shell.Context) bool {
+		switch node := stmt.Node.(type) {
+		case *shell.Pipeline:
+			piped = piped || pipesDownloadIntoInterpreter(node)
+		case *shell.Simple:
+			piped = piped || (isInterpreter(node.Command) && substitutesDownload(node))
+		}
+
+		return !piped
+	})
+
+	return !piped
+}
+
+// pipesDownloadIntoInterpreter reports whether an interpreter reads the
+// output of a download earlier in the pipeline.
+func pipesDownloadIntoInterpreter(pipeline *shell.Pipeline) bool {
+	downloaded := false
+
+	for _, stmt := range pipeline.Commands {
+		if downloaded && isInterpreter(stmt.Command()) {
+			return true
+		}
+
+		downloaded = downloaded || containsDownload([]*shell.Statement{stmt})
+	}
+
+	return false
+}
+
+// substitutesDownload reports whether a command's words substitute the
+// output of a download, as in bash -c "$(curl ...)".
+func substitutesDownload(simple *shell.Simple) bool {
+	for _, script := range simple.Substitutions {
+		if containsDownload(script) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsDownload reports whether the statements run curl or wget.
+func containsDownload(stmts []*shell.Statement) bool {
+	found := false
+
+	shell.Walk(stmts, func(stmt *shell.Statement, _ shell.Context) bool {
+		found = found || isDownload(stmt.Command())
+
+		return !found
+	})
+
+	return found
+}
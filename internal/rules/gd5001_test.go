@@ -0,0 +1,117 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD5001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD5001(),
+	}
+
+	t.Run("curl pipe sh", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSL https://get.example.com | sh`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("wget pipe sudo bash", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ubuntu
+RUN wget -qO- https://get.example.com/install.sh | sudo -E bash -`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("through tee", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSL https://get.example.com | tee /tmp/log | sh -s -- --yes`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("command substitution", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN sh -c "$(curl -fsSL https://get.example.com)"`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("process substitution", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN bash <(curl -fsSL https://get.example.com)`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("python", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12
+RUN curl -sSL https://install.python-poetry.org | python3 -`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("in a chain", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN apk add curl && curl -fsSL https://get.example.com | sh && rm -rf /tmp/*`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5001")
+	})
+
+	t.Run("pipe to tar", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSL https://example.com/app.tar.gz | tar -xz -C /opt`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5001")
+	})
+
+	t.Run("download then run", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLo /tmp/install.sh https://get.example.com && sh /tmp/install.sh`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5001")
+	})
+
+	t.Run("shell without download", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN echo 'echo hi' | sh`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5001")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD5002Meta contains metadata for rule GD5002.
+var GD5002Meta = rule.Meta{
+	Code:     "GD5002",
+	Severity: rule.Warning,
+	Message:  "Download over plain HTTP, which can be tampered with in transit. Use HTTPS",
+}
@@ -0,0 +1,42 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD5002 creates the rule reporting downloads over plain HTTP or FTP: the
+// URLs of curl, wget and git clone in RUN, and ADD sources. Local hosts are
+// spared.
+func GD5002() rule.Rule {
+	return rule.NewSimpleRule(
+		GD5002Meta.Code,
+		GD5002Meta.Severity,
+		GD5002Meta.Message,
+		checkGD5002,
+	)
+}
+
+func checkGD5002(instruction syntax.Instruction) bool {
+	switch inst := instruction.(type) {
+	case *syntax.Add:
+		return !slices.ContainsFunc(inst.Source, isPlainHTTP)
+
+	case *syntax.Run:
+		for _, simple := range runSimpleCommands(inst) {
+			cmd := *simple.Command
+			if !isDownload(&cmd) && (programName(cmd) != "git" || subcommand(cmd) != "clone") {
+				continue
+			}
+
+			if slices.ContainsFunc(shell.GetArgs(cmd), isPlainHTTP) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
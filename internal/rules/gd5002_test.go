@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD5002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD5002(),
+	}
+
+	t.Run("curl http", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLo app.tar.gz http://example.com/app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5002")
+	})
+
+	t.Run("wget ftp", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN wget ftp://ftp.example.com/pub/app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5002")
+	})
+
+	t.Run("git clone http", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN git clone http://git.example.com/acme/app.git`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5002")
+	})
+
+	t.Run("add http", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD http://example.com/app.tar.gz /opt/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5002")
+	})
+
+	t.Run("https", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLo app.tar.gz https://example.com/app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5002")
+	})
+
+	t.Run("localhost", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -f http://localhost:8080/health`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5002")
+	})
+
+	t.Run("not a download", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN echo http://example.com > /etc/mirror`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5002")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD5003Meta contains metadata for rule GD5003.
+var GD5003Meta = rule.Meta{
+	Code:     "GD5003",
+	Severity: rule.Error,
+	Message:  "Do not disable TLS certificate verification, it lets anyone in the path substitute the download",
+}
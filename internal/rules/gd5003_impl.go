@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD5003 creates the rule reporting downloads skipping TLS certificate
+// verification: curl -k (--insecure), wget --no-check-certificate, and git
+// with http.sslVerify=false or GIT_SSL_NO_VERIFY.
+func GD5003() rule.Rule {
+	return rule.NewSimpleRule(
+		GD5003Meta.Code,
+		GD5003Meta.Severity,
+		GD5003Meta.Message,
+		checkGD5003,
+	)
+}
+
+func checkGD5003(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	return !slices.ContainsFunc(runSimpleCommands(run), skipsCertificateVerification)
+}
+
+func skipsCertificateVerification(simple *shell.Simple) bool {
+	cmd := *simple.Command
+
+	switch programName(cmd) {
+	case "curl":
+		return shell.HasAnyFlag([]string{"k", "insecure"}, cmd)
+	case "wget":
+		return shell.HasFlag("no-check-certificate", cmd)
+	case "git":
+		if slices.ContainsFunc(simple.Assignments, func(assign shell.Assignment) bool {
+			return assign.Name == "GIT_SSL_NO_VERIFY" && assign.Value != "" && assign.Value != "0" && assign.Value != "false"
+		}) {
+			return true
+		}
+
+		return slices.ContainsFunc(shell.GetFlagArg("c", cmd), func(setting string) bool {
+			return strings.EqualFold(setting, "http.sslVerify=false")
+		})
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD5003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD5003(),
+	}
+
+	t.Run("curl insecure", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl --insecure -fsSLo app.tar.gz https://example.com/app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5003")
+	})
+
+	t.Run("curl combined k", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLk https://example.com/app.tar.gz -o app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5003")
+	})
+
+	t.Run("wget", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN wget --no-check-certificate https://example.com/app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5003")
+	})
+
+	t.Run("git config", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN git -c http.sslVerify=false clone https://git.example.com/acme/app.git`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5003")
+	})
+
+	t.Run("git env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN GIT_SSL_NO_VERIFY=1 git clone https://git.example.com/acme/app.git`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5003")
+	})
+
+	t.Run("verified curl", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSL https://example.com/app.tar.gz -o app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5003")
+	})
+
+	t.Run("wget k converts links", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN wget -k https://example.com/index.html`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5003")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD5004Meta contains metadata for rule GD5004.
+var GD5004Meta = rule.Meta{
+	Code:     "GD5004",
+	Severity: rule.Warning,
+	Message:  "ADD of a remote file without --checksum. Pin its content with ADD --checksum=sha256:<digest>",
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"net/url"
+	"path"
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD5004 creates the rule reporting ADD of an HTTP or HTTPS source without
+// --checksum: the build takes whatever the server returns that day. Git
+// sources are pinned by their ref, and DL3020 has the local ones.
+func GD5004() rule.Rule {
+	return rule.NewSimpleRule(
+		GD5004Meta.Code,
+		GD5004Meta.Severity,
+		GD5004Meta.Message,
+		checkGD5004,
+	)
+}
+
+func checkGD5004(instruction syntax.Instruction) bool {
+	add, ok := instruction.(*syntax.Add)
+	if !ok || add.Checksum != nil {
+		return true
+	}
+
+	return !slices.ContainsFunc(add.Source, isHTTPSource)
+}
+
+// isHTTPSource reports whether an ADD source is fetched over HTTP(S), not
+// cloned from a git repository.
+func isHTTPSource(source string) bool {
+	parsed, err := url.Parse(source)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+
+	return !isGitSource(parsed)
+}
+
+// isGitSource reports whether an ADD URL names a git repository:
+// https://host/repo.git, optionally with a #ref.
+func isGitSource(parsed *url.URL) bool {
+	return path.Ext(parsed.Path) == ".git"
+}
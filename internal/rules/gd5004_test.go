@@ -0,0 +1,67 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD5004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD5004(),
+	}
+
+	t.Run("https without checksum", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD https://example.com/app.tar.gz /opt/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5004")
+	})
+
+	t.Run("with checksum", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/app.tar.gz /opt/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5004")
+	})
+
+	t.Run("git source", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD https://github.com/acme/app.git#v1.2.3 /src`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5004")
+	})
+
+	t.Run("local file", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+ADD app.tar.gz /opt/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5004")
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY app.tar.gz /opt/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5004")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD5005Meta contains metadata for rule GD5005.
+var GD5005Meta = rule.Meta{
+	Code:     "GD5005",
+	Severity: rule.Warning,
+	Message:  "Downloaded file never verified in the same RUN. Check it with sha256sum -c or gpg --verify before use",
+}
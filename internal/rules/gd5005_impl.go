@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD5005 creates the rule reporting RUN instructions downloading files with
+// curl or wget that they never verify: no checksum check (sha256sum -c and
+// friends) nor signature check (gpg --verify, gpgv, cosign verify-blob,
+// minisign -V) in the same RUN.
+func GD5005() rule.Rule {
+	return rule.NewSimpleRule(
+		GD5005Meta.Code,
+		GD5005Meta.Severity,
+		GD5005Meta.Message,
+		checkGD5005,
+	)
+}
+
+func checkGD5005(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	commands := runSimpleCommands(run)
+
+	downloads := slices.ContainsFunc(commands, func(simple *shell.Simple) bool {
+		return len(downloadOutputs(*simple.Command)) > 0
+	})
+
+	return !downloads || slices.ContainsFunc(commands, func(simple *shell.Simple) bool {
+		return isVerification(*simple.Command)
+	})
+}
+
+// checksumTools are the programs checking files against a list of digests
+// with -c.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var checksumTools = []string{
+	"md5sum", "sha1sum", "sha224sum", "sha256sum", "sha384sum", "sha512sum", "b2sum", "shasum", "cksum",
+}
+
+// isVerification reports whether a command checks a file's checksum or
+// signature.
+func isVerification(cmd shell.Command) bool {
+	switch name := programName(cmd); {
+	case slices.Contains(checksumTools, name):
+		return shell.HasAnyFlag([]string{"c", "check"}, cmd)
+	case name == "gpg" || name == "gpg2":
+		return shell.HasFlag("verify", cmd)
+	case name == "gpgv":
+		return true
+	case name == "cosign":
+		sub := subcommand(cmd)
+
+		return sub == "verify-blob" || sub == "verify-blob-attestation"
+	case name == "minisign" || name == "signify":
+		return shell.HasFlag("V", cmd)
+	case name == "openssl":
+		return subcommand(cmd) == "dgst" && shell.HasFlag("verify", cmd)
+	}
+
+	return false
+}
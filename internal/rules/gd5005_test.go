@@ -0,0 +1,87 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD5005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD5005(),
+	}
+
+	t.Run("curl output", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLo /tmp/app.tar.gz https://example.com/app.tar.gz && tar -xzf /tmp/app.tar.gz -C /opt`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5005")
+	})
+
+	t.Run("wget", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN wget https://example.com/app.tar.gz && tar -xzf app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5005")
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLO https://example.com/app.tar.gz && echo "${APP_SHA256}  app.tar.gz" | sha256sum -c - && tar -xzf app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5005")
+	})
+
+	t.Run("gpg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN curl -fsSLO https://example.com/app.tar.gz && curl -fsSLO https://example.com/app.tar.gz.asc && gpg --batch --verify app.tar.gz.asc app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5005")
+	})
+
+	t.Run("cosign", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLO https://example.com/app && cosign verify-blob --bundle app.bundle app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5005")
+	})
+
+	t.Run("checksum without check", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSLO https://example.com/app.tar.gz && sha256sum app.tar.gz`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD5005")
+	})
+
+	t.Run("stdout only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN curl -fsSL https://example.com/version`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD5005")
+	})
+}
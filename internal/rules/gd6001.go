@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6001Meta contains metadata for rule GD6001.
+var GD6001Meta = rule.Meta{
+	Code:       "GD6001",
+	Severity:   rule.Warning,
+	Message:    "Do not make files or directories world-writable (chmod 777, o+w), give them to the user that writes them with COPY --chown or chown",
+	References: []string{"CWE-732: Incorrect Permission Assignment for Critical Resource"},
+}
@@ -0,0 +1,30 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6001 creates the rule reporting world-writable modes set by chmod,
+// install -m and mkdir -m: octal modes granting others write (777, 666),
+// and symbolic ones (o+w, a+rwx). Sticky directories (1777, +t), as /tmp,
+// pass.
+func GD6001() rule.Rule {
+	return rule.NewSimpleRule(
+		GD6001Meta.Code,
+		GD6001Meta.Severity,
+		GD6001Meta.Message,
+		checkGD6001,
+	).WithReferences(GD6001Meta.References...)
+}
+
+func checkGD6001(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	return !slices.ContainsFunc(runFileModes(run), func(mode fileMode) bool { return mode.worldWritable })
+}
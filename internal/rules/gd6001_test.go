@@ -0,0 +1,117 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6001(),
+	}
+
+	t.Run("chmod 777", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod -R 777 /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6001")
+	})
+
+	t.Run("chmod 0666", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod 0666 /app/data.db`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6001")
+	})
+
+	t.Run("symbolic others", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod o+w /app/logs`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6001")
+	})
+
+	t.Run("symbolic all", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod u+x,a+rwx /app/run`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6001")
+	})
+
+	t.Run("mkdir mode", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN mkdir -m 777 /data`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6001")
+	})
+
+	t.Run("sticky tmp", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod 1777 /tmp/cache`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6001")
+	})
+
+	t.Run("sticky symbolic", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod a+rwxt /tmp/cache`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6001")
+	})
+
+	t.Run("group write", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod 775 /app && chmod g+w /app/logs`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6001")
+	})
+
+	t.Run("umask write", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod +w /app/config`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6001")
+	})
+
+	t.Run("removal", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod o-w /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6001")
+	})
+}
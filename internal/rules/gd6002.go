@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6002Meta contains metadata for rule GD6002.
+var GD6002Meta = rule.Meta{
+	Code:       "GD6002",
+	Severity:   rule.Warning,
+	Message:    "Do not add setuid or setgid permissions, they let any user run the program with its owner privileges",
+	References: []string{"CIS Docker Benchmark 4.8"},
+}
@@ -0,0 +1,29 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6002 creates the rule reporting setuid and setgid bits set by chmod,
+// install -m and mkdir -m (u+s, g+s, 4755, 2755). Removing them (a-s) is the
+// remediation, and passes.
+func GD6002() rule.Rule {
+	return rule.NewSimpleRule(
+		GD6002Meta.Code,
+		GD6002Meta.Severity,
+		GD6002Meta.Message,
+		checkGD6002,
+	).WithReferences(GD6002Meta.References...)
+}
+
+func checkGD6002(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	return !slices.ContainsFunc(runFileModes(run), func(mode fileMode) bool { return mode.setID })
+}
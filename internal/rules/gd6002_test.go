@@ -0,0 +1,77 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6002(),
+	}
+
+	t.Run("setuid", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod u+s /usr/local/bin/helper`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6002")
+	})
+
+	t.Run("octal setuid", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod 4755 /usr/local/bin/helper`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6002")
+	})
+
+	t.Run("setgid", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod g+s /srv/shared`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6002")
+	})
+
+	t.Run("install mode", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN install -m 2755 helper /usr/local/bin/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6002")
+	})
+
+	t.Run("removal", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN find / -perm /6000 -type f -exec chmod a-s {} + || true`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6002")
+	})
+
+	t.Run("plain mode", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN chmod 0755 /usr/local/bin/helper`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6002")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6003Meta contains metadata for rule GD6003.
+var GD6003Meta = rule.Meta{
+	Code:       "GD6003",
+	Severity:   rule.Warning,
+	Message:    "Do not grant passwordless sudo, it makes any process of the user root",
+	References: []string{"CIS Docker Benchmark 4.1"},
+}
@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"regexp"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6003 creates the rule reporting sudoers entries written by a RUN, in
+// its script or here-documents, that grant commands without a password
+// (NOPASSWD:). DL3004 has the use of sudo itself.
+func GD6003() rule.Rule {
+	return rule.NewSimpleRule(
+		GD6003Meta.Code,
+		GD6003Meta.Severity,
+		GD6003Meta.Message,
+		checkGD6003,
+	).WithReferences(GD6003Meta.References...)
+}
+
+func checkGD6003(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	pattern := regexp.MustCompile(`\bNOPASSWD\s*:`)
+	if pattern.MatchString(run.Command) {
+		return false
+	}
+
+	for _, heredoc := range run.Heredocs {
+		if pattern.MatchString(heredoc.Content) {
+			return false
+		}
+	}
+
+	return true
+}
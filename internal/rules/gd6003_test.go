@@ -0,0 +1,61 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6003(),
+	}
+
+	t.Run("echo to sudoers", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ubuntu
+RUN echo 'app ALL=(ALL) NOPASSWD: ALL' >> /etc/sudoers`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6003")
+	})
+
+	t.Run("sudoers.d", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ubuntu
+RUN echo "app ALL=(ALL) NOPASSWD:ALL" > /etc/sudoers.d/app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6003")
+	})
+
+	t.Run("heredoc", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ubuntu
+RUN <<EOF
+cat > /etc/sudoers.d/app <<SUDO
+app ALL=(ALL) NOPASSWD: /usr/bin/apt-get
+SUDO
+EOF`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6003")
+	})
+
+	t.Run("password required", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM ubuntu
+RUN echo 'app ALL=(ALL) ALL' >> /etc/sudoers`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6003")
+	})
+}
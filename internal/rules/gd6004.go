@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6004Meta contains metadata for rule GD6004.
+var GD6004Meta = rule.Meta{
+	Code:       "GD6004",
+	Severity:   rule.Warning,
+	Message:    "USER resolves to root under another name",
+	References: []string{"CIS Docker Benchmark 4.1"},
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd6004State tracks the accounts created with UID 0, and the stage
+// environment USER expands.
+type gd6004State struct {
+	users       stageUsers
+	rootAliases map[string]bool
+}
+
+// GD6004Rule reports USER instructions running as root without saying so,
+// which DL3002 cannot see: an account created with UID 0 under another
+// name (useradd -o -u 0 admin, an /etc/passwd line), or a variable that
+// resolves to root or 0.
+type GD6004Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD6004 creates the rule reporting USER aliasing root.
+func GD6004() rule.Rule {
+	return &GD6004Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD6004Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD6004Rule) InitialState() rule.State {
+	return rule.EmptyState(gd6004State{})
+}
+
+// Check records the root aliases RUN creates, and reports the USER
+// resolving to root.
+func (*GD6004Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[gd6004State](state)
+	current.users = current.users.apply(line, instruction)
+
+	switch inst := instruction.(type) {
+	case *syntax.Run:
+		if names := rootAccounts(inst); len(names) > 0 {
+			current.rootAliases = maps.Clone(current.rootAliases)
+			if current.rootAliases == nil {
+				current.rootAliases = make(map[string]bool)
+			}
+
+			for _, name := range names {
+				current.rootAliases[name] = true
+			}
+		}
+
+	case *syntax.User:
+		resolved := current.users.current.user
+		if current.rootAliases[userName(resolved)] || (isRoot(resolved) && !isRoot(inst.User)) {
+			return state.ReplaceData(current).AddFailure(rule.CheckFailure{
+				Code:     GD6004Meta.Code,
+				Severity: GD6004Meta.Severity,
+				Message:  GD6004Meta.Message + ": " + inst.User,
+				Line:     line,
+				Column:   1,
+			})
+		}
+	}
+
+	return state.ReplaceData(current)
+}
+
+// accountCommands are the commands creating or modifying an account, named
+// by their last operand.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var accountCommands = []string{"useradd", "adduser", "usermod"}
+
+// passwdRootEntry matches an /etc/passwd entry with UID 0, capturing its
+// name.
+var passwdRootEntry = regexp.MustCompile(`(?:^|[\s'"])([a-z_][a-z0-9_.-]*):[^:\s'"]*:0:`)
+
+// rootAccounts returns the accounts a RUN gives UID 0: with useradd,
+// adduser or usermod -u 0, or by writing an /etc/passwd line.
+func rootAccounts(run *syntax.Run) []string {
+	var names []string
+
+	for _, simple := range runSimpleCommands(run) {
+		cmd := *simple.Command
+		if !slices.Contains(accountCommands, programName(cmd)) {
+			continue
+		}
+
+		uids := append(shell.GetFlagArg("u", cmd), shell.GetFlagArg("uid", cmd)...)
+		if args := shell.GetArgs(cmd); slices.Contains(uids, "0") && len(args) > 0 {
+			names = append(names, args[len(args)-1])
+		}
+	}
+
+	if strings.Contains(run.Command, "/etc/passwd") {
+		for _, match := range passwdRootEntry.FindAllStringSubmatch(run.Command, -1) {
+			if match[1] != "root" {
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,93 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6004(),
+	}
+
+	t.Run("useradd uid 0", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN useradd -o -u 0 -g 0 admin
+USER admin`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6004")
+	})
+
+	t.Run("passwd line", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN echo 'ops:x:0:0::/root:/bin/sh' >> /etc/passwd
+USER ops`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6004")
+	})
+
+	t.Run("variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG APP_USER=0
+USER ${APP_USER}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6004")
+	})
+
+	t.Run("env variable with group", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV RUN_AS=root
+USER $RUN_AS:app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6004")
+	})
+
+	t.Run("literal root", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+USER root`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6004")
+	})
+
+	t.Run("regular account", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN useradd -u 10001 app
+USER app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6004")
+	})
+
+	t.Run("variable to user", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG APP_USER=app
+USER ${APP_USER}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6004")
+	})
+}
@@ -0,0 +1,12 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6005Meta contains metadata for rule GD6005.
+var GD6005Meta = rule.Meta{
+	Code:        "GD6005",
+	Severity:    rule.Info,
+	Message:     "Final USER has a system UID (below 1000), which may match a privileged account of the host. Use a dedicated UID, such as 10001",
+	References:  []string{"CIS Docker Benchmark 4.1"},
+	StageScoped: true,
+}
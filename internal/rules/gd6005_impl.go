@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"strconv"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6005Rule reports a final stage running as a numeric system UID, from 1
+// to 999: the range distributions give to system accounts, on the host as
+// in images. Root (0) is DL3002's.
+type GD6005Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD6005 creates the rule reporting a final USER with a system UID.
+func GD6005() rule.Rule {
+	return &GD6005Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD6005Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD6005Rule) InitialState() rule.State {
+	return rule.EmptyState(stageUsers{})
+}
+
+// Check follows the user of each stage.
+func (*GD6005Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return state.ReplaceData(rule.Data[stageUsers](state).apply(line, instruction))
+}
+
+// Finalize reports the user of the final stage.
+func (*GD6005Rule) Finalize(state rule.State) rule.State {
+	final := rule.Data[stageUsers](state).current
+
+	uid, err := strconv.Atoi(userName(final.user))
+	if err != nil || uid <= 0 || uid >= 1000 {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD6005Meta.Code,
+		Severity: GD6005Meta.Severity,
+		Message:  GD6005Meta.Message,
+		Line:     final.line,
+		Column:   1,
+	})
+}
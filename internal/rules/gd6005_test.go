@@ -0,0 +1,80 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6005(),
+	}
+
+	t.Run("system uid", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+USER 101`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6005")
+	})
+
+	t.Run("system uid with group", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+USER 999:999`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6005")
+	})
+
+	t.Run("regular uid", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+USER 10001`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6005")
+	})
+
+	t.Run("name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+USER nginx`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6005")
+	})
+
+	t.Run("earlier stage only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS build
+USER 101
+FROM alpine
+USER 10001`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6005")
+	})
+
+	t.Run("inherited", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+USER 100
+FROM base`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6005")
+	})
+}
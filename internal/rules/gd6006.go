@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6006Meta contains metadata for rule GD6006.
+var GD6006Meta = rule.Meta{
+	Code:       "GD6006",
+	Severity:   rule.Error,
+	Message:    "RUN --security=insecure runs the command with full privileges on the build host",
+	References: []string{"CIS Docker Benchmark 5.4"},
+}
@@ -0,0 +1,28 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6006 creates the rule reporting RUN --security=insecure, the build time
+// equivalent of a privileged container.
+func GD6006() rule.Rule {
+	return rule.NewSimpleRule(
+		GD6006Meta.Code,
+		GD6006Meta.Severity,
+		GD6006Meta.Message,
+		checkGD6006,
+	).WithReferences(GD6006Meta.References...)
+}
+
+func checkGD6006(instruction syntax.Instruction) bool {
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		return true
+	}
+
+	return !slices.Contains(run.Flags, "--security=insecure")
+}
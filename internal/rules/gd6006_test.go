@@ -0,0 +1,47 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6006(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6006(),
+	}
+
+	t.Run("insecure", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN --security=insecure mount -t tmpfs none /mnt`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6006")
+	})
+
+	t.Run("sandbox", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN --security=sandbox make`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6006")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+RUN make`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6006")
+	})
+}
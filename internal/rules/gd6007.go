@@ -0,0 +1,12 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD6007Meta contains metadata for rule GD6007.
+var GD6007Meta = rule.Meta{
+	Code:        "GD6007",
+	Severity:    rule.Warning,
+	Message:     "Final stage never switches to a non-root USER. Create a user, COPY --chown the files it writes, and set USER",
+	References:  []string{"CIS Docker Benchmark 4.1"},
+	StageScoped: true,
+}
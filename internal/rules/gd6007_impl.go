@@ -0,0 +1,47 @@
+package rules
+
+import (
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD6007Rule reports a final stage that never sets USER, itself or through
+// the earlier stage it is built from: it runs as its base image's user,
+// root for most. Images tagged nonroot or rootless are trusted to run as
+// such. A USER root is DL3002's.
+type GD6007Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD6007 creates the rule reporting a final stage without USER.
+func GD6007() rule.Rule {
+	return &GD6007Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD6007Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD6007Rule) InitialState() rule.State {
+	return rule.EmptyState(stageUsers{})
+}
+
+// Check follows the user of each stage.
+func (*GD6007Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	return state.ReplaceData(rule.Data[stageUsers](state).apply(line, instruction))
+}
+
+// Finalize reports the FROM of a final stage without user.
+func (*GD6007Rule) Finalize(state rule.State) rule.State {
+	users := rule.Data[stageUsers](state)
+	if !users.started() || users.current.user != "" {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD6007Meta.Code,
+		Severity: GD6007Meta.Severity,
+		Message:  GD6007Meta.Message,
+		Line:     users.current.line,
+		Column:   1,
+	})
+}
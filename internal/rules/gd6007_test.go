@@ -0,0 +1,84 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD6007(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD6007(),
+	}
+
+	t.Run("no user", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY app /app
+CMD ["/app"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6007")
+	})
+
+	t.Run("user set", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+COPY --chown=10001:10001 app /app
+USER 10001
+CMD ["/app"]`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6007")
+	})
+
+	t.Run("user in builder only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang:1.23 AS build
+USER 10001
+FROM alpine
+COPY --from=build /app /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD6007")
+	})
+
+	t.Run("inherited", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine AS base
+USER app
+FROM base
+COPY app /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6007")
+	})
+
+	t.Run("nonroot tag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM gcr.io/distroless/static:nonroot
+COPY app /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6007")
+	})
+
+	t.Run("root user", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM alpine
+USER root`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD6007")
+	})
+}
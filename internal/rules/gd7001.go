@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD7001Meta contains metadata for rule GD7001.
+var GD7001Meta = rule.Meta{
+	Code:       "GD7001",
+	Severity:   rule.Warning,
+	Message:    "Variable is not defined where it is used, and expands to nothing",
+	References: []string{"https://docs.docker.com/reference/dockerfile/#scope"},
+}
@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd7001State follows the variables in scope, and the stage dialect.
+type gd7001State struct {
+	vars   buildVars
+	stages shell.StageDialects
+}
+
+// GD7001Rule reports the variables an instruction expands that nothing in
+// scope defines: in FROM, COPY, WORKDIR, USER and the other instructions
+// BuildKit expands itself, as BK1006 and BK1007 do, and in the scripts of
+// RUN. The base image's environment is unknown, so RUN variables the
+// official images set (GOPATH, JAVA_HOME...) are assumed defined.
+type GD7001Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD7001 creates the rule reporting undefined variables.
+func GD7001() rule.Rule {
+	return &GD7001Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD7001Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD7001Rule) InitialState() rule.State {
+	return rule.EmptyState(gd7001State{})
+}
+
+// runtimeVars are the variables a RUN sees without the Dockerfile defining
+// them: set by the shell or the login environment, and the proxy build
+// arguments BuildKit passes undeclared.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var runtimeVars = []string{
+	"HOME", "PATH", "PWD", "OLDPWD", "SHELL", "USER", "LOGNAME", "HOSTNAME", "TERM", "TMPDIR",
+	"IFS", "PS1", "PS2", "PS4", "PPID", "UID", "EUID", "RANDOM", "LINENO", "SECONDS",
+	"OPTARG", "OPTIND", "REPLY", "BASH", "BASH_SOURCE", "BASH_VERSION", "BASHPID", "FUNCNAME",
+	"PIPESTATUS", "HOSTTYPE", "MACHTYPE", "OSTYPE",
+	"HTTP_PROXY", "HTTPS_PROXY", "FTP_PROXY", "NO_PROXY", "ALL_PROXY",
+	"http_proxy", "https_proxy", "ftp_proxy", "no_proxy", "all_proxy",
+}
+
+// imageVars are the variables official base images set in their
+// environment, which a RUN may read without the Dockerfile defining them.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var imageVars = []string{
+	"LANG", "LC_ALL", "GPG_KEY", "GOSU_VERSION",
+	"GOLANG_VERSION", "GOPATH", "GOTOOLCHAIN",
+	"JAVA_HOME", "JAVA_VERSION", "MAVEN_HOME", "MAVEN_CONFIG", "GRADLE_HOME", "GRADLE_VERSION",
+	"PYTHON_VERSION", "PYTHON_SHA256", "PYTHON_PIP_VERSION",
+	"NODE_VERSION", "YARN_VERSION",
+	"RUBY_VERSION", "RUBY_DOWNLOAD_SHA256", "GEM_HOME", "BUNDLE_APP_CONFIG", "BUNDLE_SILENCE_ROOT_WARNING",
+	"RUSTUP_HOME", "CARGO_HOME", "RUST_VERSION",
+	"PHP_VERSION", "PHP_INI_DIR", "PHPIZE_DEPS",
+	"DOTNET_VERSION", "ASPNET_VERSION", "DOTNET_RUNNING_IN_CONTAINER",
+	"NGINX_VERSION", "HTTPD_PREFIX", "PG_MAJOR", "PG_VERSION", "PGDATA", "MYSQL_MAJOR", "MYSQL_VERSION",
+}
+
+// Check follows the variables in scope, and reports those an instruction
+// expands without defining.
+func (*GD7001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[gd7001State](state)
+	vars := current.vars
+
+	current.vars = vars.apply(instruction)
+
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		current.stages = current.stages.Directive(inst)
+	case *syntax.From:
+		current.stages = current.stages.From(inst)
+	case *syntax.Shell:
+		current.stages = current.stages.Shell(inst)
+	}
+
+	state = state.ReplaceData(current)
+
+	run, ok := instruction.(*syntax.Run)
+	if !ok {
+		_, from := instruction.(*syntax.From)
+
+		for _, variable := range vars.undefined(instruction) {
+			state = state.AddFailure(gd7001Failure(line, variable.name, undefinedHint(vars, variable, from)))
+		}
+
+		return state
+	}
+
+	if !vars.started {
+		return state
+	}
+
+	script, dialect, offset := shell.RunScript(run, current.stages.Dialect())
+	if !dialect.IsShell() {
+		return state
+	}
+
+	used, err := shell.ScriptVariables(script)
+	if err != nil {
+		// Unparsable scripts are not this rule's concern.
+		return state
+	}
+
+	defined := slices.Concat(used.Set, runtimeVars, imageVars, slices.Collect(maps.Keys(vars.stage)))
+	for _, mount := range runMounts(run.Flags) {
+		if mount.env != "" {
+			defined = append(defined, mount.env)
+		}
+	}
+
+	// Suggestions are limited to the variables the Dockerfile declares.
+	options := slices.DeleteFunc(slices.Sorted(maps.Keys(vars.stage)), func(name string) bool {
+		return slices.Contains(runtimeVars, name)
+	})
+
+	for _, reference := range used.Expanded {
+		if slices.Contains(defined, reference.Name) {
+			continue
+		}
+
+		variable := undefinedVar{reference.Name, suggestName(reference.Name, options)}
+		state = state.AddFailure(gd7001Failure(line+offset+reference.Line, variable.name,
+			undefinedHint(vars, variable, false)))
+	}
+
+	return state
+}
+
+// undefinedHint returns how to define a variable: declaring a global ARG in
+// the stage, or in FROM before the first FROM, or the name it likely
+// misspells.
+func undefinedHint(vars buildVars, variable undefinedVar, from bool) string {
+	_, global := vars.global[variable.name]
+
+	switch {
+	case global && !from:
+		return fmt.Sprintf("global ARG %s is only visible in a stage after `ARG %s`", variable.name, variable.name)
+	case variable.suggestion != "":
+		return fmt.Sprintf("did you mean $%s?", variable.suggestion)
+	case from:
+		return fmt.Sprintf("declare it with `ARG %s` before the first FROM", variable.name)
+	default:
+		return "declare it with ARG or ENV"
+	}
+}
+
+// gd7001Failure reports an undefined variable.
+func gd7001Failure(line int, name, hint string) rule.CheckFailure {
+	return rule.CheckFailure{
+		Code:     GD7001Meta.Code,
+		Severity: GD7001Meta.Severity,
+		Message:  fmt.Sprintf("%s: $%s, %s", GD7001Meta.Message, name, hint),
+		Line:     line,
+		Column:   1,
+	}
+}
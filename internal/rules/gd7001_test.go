@@ -0,0 +1,280 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD7001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD7001(),
+	}
+
+	t.Run("global arg not redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("global arg in heredoc", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+RUN <<EOF
+echo ${VERSION}
+EOF`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("global arg in second stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian AS build
+ARG VERSION
+FROM debian
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("near miss of env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV APP_DIR=/app
+RUN cd $APPDIR`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("near miss of arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG RELEASE
+RUN echo $RELEAS`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("undefined in run", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+RUN echo $RELEASE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("undefined in copy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+COPY app-$VERSION.tar.gz /srv/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("near miss in workdir", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV APP_DIR=/app
+WORKDIR $APPDIR`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("global arg in user", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG UID=10001
+FROM debian
+USER $UID`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("undeclared in from", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian:${DEBIAN_VERSION}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7001")
+	})
+
+	t.Run("declared in copy and from", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG DEBIAN_VERSION=12
+FROM debian:${DEBIAN_VERSION}
+ARG VERSION=1.0
+COPY app-$VERSION.tar.gz /srv/`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("global arg redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+ARG VERSION
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("inherited from stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian AS base
+ENV APP_DIR=/app
+FROM base
+RUN cd $APP_DIR`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("defined by the script", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV APP_DIR=/app
+RUN APPDIR=/srv; echo $APPDIR`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("loop variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG item=1
+FROM debian
+RUN for item in a b; do echo $item; done`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+RUN echo ${VERSION:-latest}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("single quoted", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+RUN echo '$VERSION'`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("base image variable", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM golang
+RUN echo $GOPATH`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("secret mount env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG TOKEN
+FROM debian
+RUN --mount=type=secret,id=token,env=TOKEN curl -H "$TOKEN" https://example.com`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("proxy argument", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG HTTP_PROXY
+FROM debian
+RUN curl --proxy $HTTP_PROXY https://example.com`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+
+	t.Run("powershell", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM mcr.microsoft.com/windows/servercore
+SHELL ["powershell", "-Command"]
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7001")
+	})
+}
+
+func TestGD7001_Message(t *testing.T) {
+	t.Parallel()
+
+	violations := testutils.LintDockerfile("FROM debian\nENV APP_DIR=/app\nRUN set -e \\\n  && cd $APPDIR", []rule.Rule{rules.GD7001()})
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %v", len(violations), violations)
+	}
+
+	if !strings.HasSuffix(violations[0].Message, "$APPDIR, did you mean $APP_DIR?") || violations[0].Line != 3 {
+		t.Errorf("got %q on line %d, want the suggestion on line 3", violations[0].Message, violations[0].Line)
+	}
+
+	violations = testutils.LintDockerfile("FROM debian:${DEBIAN_VERSION}\nRUN echo $RELEASE",
+		[]rule.Rule{rules.GD7001()})
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2: %v", len(violations), violations)
+	}
+
+	fromHint := "$DEBIAN_VERSION, declare it with `ARG DEBIAN_VERSION` before the first FROM"
+	if !strings.HasSuffix(violations[0].Message, fromHint) || violations[0].Line != 1 {
+		t.Errorf("got %q on line %d, want the ARG hint on line 1", violations[0].Message, violations[0].Line)
+	}
+
+	if !strings.HasSuffix(violations[1].Message, "$RELEASE, declare it with ARG or ENV") || violations[1].Line != 2 {
+		t.Errorf("got %q on line %d, want the ARG or ENV hint on line 2", violations[1].Message, violations[1].Line)
+	}
+}
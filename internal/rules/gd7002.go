@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD7002Meta contains metadata for rule GD7002.
+var GD7002Meta = rule.Meta{
+	Code:     "GD7002",
+	Severity: rule.Info,
+	Message:  "ARG is never used in its stage, nor in the stages built from it",
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd7002State follows the ARGs of each stage: unused holds the declarations
+// no instruction referenced yet, visible maps the names of the current
+// stage to their latest declaration, and stages keeps the visible
+// declarations of the stages named so far, which the stages built from them
+// inherit.
+type gd7002State struct {
+	started bool
+	alias   string
+	unused  map[declaredVar]bool
+	visible map[string]declaredVar
+	stages  map[string]map[string]declaredVar
+}
+
+// GD7002Rule reports the ARGs of a stage no instruction references, in the
+// stage or in those built from it, which inherit its build arguments. ARGs
+// tools read from the environment of RUN (DEBIAN_FRONTEND, the proxies,
+// CGO_ENABLED, GOFLAGS, PIP_INDEX_URL...) are left alone. Global ARGs are
+// GD7003's.
+type GD7002Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD7002 creates the rule reporting unused ARGs.
+func GD7002() rule.Rule {
+	return &GD7002Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD7002Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD7002Rule) InitialState() rule.State {
+	return rule.EmptyState(gd7002State{})
+}
+
+// Check marks the ARGs an instruction references as used, and records the
+// ones it declares.
+func (*GD7002Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[gd7002State](state)
+
+	if from, ok := instruction.(*syntax.From); ok {
+		return state.ReplaceData(current.from(from))
+	}
+
+	if !current.started {
+		return state
+	}
+
+	current.unused = maps.Clone(current.unused)
+	for name := range referencedVars(instruction) {
+		if declaration, ok := current.visible[name]; ok {
+			delete(current.unused, declaration)
+		}
+	}
+
+	if arg, ok := instruction.(*syntax.Arg); ok {
+		if current.unused == nil {
+			current.unused = make(map[declaredVar]bool)
+		}
+
+		current.visible = maps.Clone(current.visible)
+		if current.visible == nil {
+			current.visible = make(map[string]declaredVar)
+		}
+
+		for _, declaration := range argDeclarations(arg) {
+			declared := declaredVar{line, declaration.key}
+
+			current.visible[declaration.key] = declared
+			if !isEnvironmentArg(declaration.key) {
+				current.unused[declared] = true
+			}
+		}
+	}
+
+	return state.ReplaceData(current)
+}
+
+// from starts a stage, with the ARGs of the stage it is built from.
+func (s gd7002State) from(from *syntax.From) gd7002State {
+	s.stages = maps.Clone(s.stages)
+	if s.stages == nil {
+		s.stages = make(map[string]map[string]declaredVar)
+	}
+
+	if s.started && s.alias != "" {
+		s.stages[s.alias] = s.visible
+	}
+
+	s.started = true
+	s.alias = ""
+	s.visible = nil
+
+	if from.Image.Alias != nil {
+		s.alias = strings.ToLower(*from.Image.Alias)
+	}
+
+	if inherited, ok := s.stages[strings.ToLower(from.Image.Image)]; ok && from.Image.Tag == nil {
+		s.visible = inherited
+	}
+
+	return s
+}
+
+// Finalize reports the ARGs left unused.
+func (*GD7002Rule) Finalize(state rule.State) rule.State {
+	current := rule.Data[gd7002State](state)
+
+	for _, declared := range sortedDeclarations(current.unused) {
+		state = state.AddFailure(rule.CheckFailure{
+			Code:     GD7002Meta.Code,
+			Severity: GD7002Meta.Severity,
+			Message:  GD7002Meta.Message + ": " + declared.name,
+			Line:     declared.line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
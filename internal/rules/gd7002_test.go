@@ -0,0 +1,121 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD7002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD7002(),
+	}
+
+	t.Run("unused stage arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG VERSION=1.0
+RUN echo hello`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7002")
+	})
+
+	t.Run("one of two on a line", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG A B
+RUN echo $A`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7002")
+	})
+
+	t.Run("redeclared before use", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG A
+ARG A=1
+RUN echo $A`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7002")
+	})
+
+	t.Run("used in run", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG VERSION=1.0
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+
+	t.Run("used in copy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG APP=app
+COPY ${APP}.tar /`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+
+	t.Run("used in heredoc", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG NAME
+RUN <<EOF
+echo ${NAME:-x}
+EOF`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+
+	t.Run("used in child stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian AS base
+ARG VERSION
+FROM base
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+
+	t.Run("environment arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG DEBIAN_FRONTEND=noninteractive
+ARG CGO_ENABLED=0
+ARG GOFLAGS
+RUN apt-get update`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+
+	t.Run("global arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION
+FROM debian:$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7002")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD7003Meta contains metadata for rule GD7003.
+var GD7003Meta = rule.Meta{
+	Code:     "GD7003",
+	Severity: rule.Info,
+	Message:  "Global ARG is never used in a FROM, nor redeclared in a stage",
+}
@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"maps"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd7003State follows the global ARGs: visible maps their names to their
+// latest declaration, and unused holds the declarations nothing used yet.
+type gd7003State struct {
+	started bool
+	unused  map[declaredVar]bool
+	visible map[string]declaredVar
+}
+
+// GD7003Rule reports the ARGs declared before the first FROM that serve no
+// purpose: global ARGs are only visible in FROM, and in the stages that
+// redeclare them (ARG NAME). A global ARG used in the default of another
+// one counts as used.
+type GD7003Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD7003 creates the rule reporting unused global ARGs.
+func GD7003() rule.Rule {
+	return &GD7003Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD7003Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD7003Rule) InitialState() rule.State {
+	return rule.EmptyState(gd7003State{})
+}
+
+// Check records the global ARGs, and marks those FROM references or a
+// stage redeclares as used.
+func (*GD7003Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[gd7003State](state)
+
+	var used []string
+
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		current.started = true
+
+		for name := range referencedVars(inst) {
+			used = append(used, name)
+		}
+
+	case *syntax.Arg:
+		if current.started {
+			for _, declaration := range argDeclarations(inst) {
+				used = append(used, declaration.key)
+			}
+
+			break
+		}
+
+		return state.ReplaceData(current.declare(line, inst))
+
+	default:
+		return state
+	}
+
+	current.unused = maps.Clone(current.unused)
+	current.use(used...)
+
+	return state.ReplaceData(current)
+}
+
+// use marks the global ARGs of the given names as used; the map must not
+// be shared.
+func (s gd7003State) use(names ...string) {
+	for _, name := range names {
+		if declared, ok := s.visible[name]; ok {
+			delete(s.unused, declared)
+		}
+	}
+}
+
+// declare records the global ARGs an instruction declares, in order, after
+// marking those their defaults reference as used.
+func (s gd7003State) declare(line int, arg *syntax.Arg) gd7003State {
+	s.unused = maps.Clone(s.unused)
+	if s.unused == nil {
+		s.unused = make(map[declaredVar]bool)
+	}
+
+	s.visible = maps.Clone(s.visible)
+	if s.visible == nil {
+		s.visible = make(map[string]declaredVar)
+	}
+
+	for _, declaration := range argDeclarations(arg) {
+		if declaration.value != nil {
+			s.use(expandedNames(*declaration.value)...)
+		}
+
+		declared := declaredVar{line, declaration.key}
+
+		s.visible[declaration.key] = declared
+		if !isEnvironmentArg(declaration.key) {
+			s.unused[declared] = true
+		}
+	}
+
+	return s
+}
+
+// Finalize reports the global ARGs left unused.
+func (*GD7003Rule) Finalize(state rule.State) rule.State {
+	current := rule.Data[gd7003State](state)
+
+	for _, declared := range sortedDeclarations(current.unused) {
+		state = state.AddFailure(rule.CheckFailure{
+			Code:     GD7003Meta.Code,
+			Severity: GD7003Meta.Severity,
+			Message:  GD7003Meta.Message + ": " + declared.name,
+			Line:     declared.line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
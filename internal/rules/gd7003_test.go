@@ -0,0 +1,101 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD7003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD7003(),
+	}
+
+	t.Run("unused global", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7003")
+	})
+
+	t.Run("only used in stage without redeclaring", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7003")
+	})
+
+	t.Run("used in from", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian:${VERSION}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+
+	t.Run("used in platform", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG PLATFORM=linux/amd64
+FROM --platform=$PLATFORM debian`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+
+	t.Run("redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+ARG VERSION
+RUN echo $VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+
+	t.Run("used by another global", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG BASE=debian
+ARG IMAGE=${BASE}:12
+FROM $IMAGE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+
+	t.Run("used on the same line", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG BASE=debian IMAGE=${BASE}:12
+FROM $IMAGE`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+
+	t.Run("buildkit arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG BUILDKIT_INLINE_CACHE=1
+FROM debian`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7003")
+	})
+}
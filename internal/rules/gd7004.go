@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD7004Meta contains metadata for rule GD7004.
+var GD7004Meta = rule.Meta{
+	Code:     "GD7004",
+	Severity: rule.Warning,
+	Message:  "ENV is overwritten before anything reads it",
+}
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD7004Rule reports ENV values that are set again, in the same stage,
+// before any instruction reads them: by referencing the variable, or as a
+// RUN, whose commands see the whole environment.
+type GD7004Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD7004 creates the rule reporting ENV overwritten before use.
+func GD7004() rule.Rule {
+	return &GD7004Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD7004Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule: the line of the
+// ENV setting each variable of the stage no instruction read yet.
+func (*GD7004Rule) InitialState() rule.State {
+	return rule.EmptyState(map[string]int{})
+}
+
+// Check forgets the variables an instruction reads, and reports those an
+// ENV sets again unread.
+func (*GD7004Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	unread := rule.Data[map[string]int](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.From, *syntax.Run:
+		return state.ReplaceData(map[string]int{})
+
+	case *syntax.Env:
+		unread = maps.Clone(unread)
+
+		for _, declaration := range envDeclarations(inst) {
+			for _, name := range expandedNames(*declaration.value) {
+				delete(unread, name)
+			}
+
+			if previous, ok := unread[declaration.key]; ok {
+				state = state.AddFailure(rule.CheckFailure{
+					Code:     GD7004Meta.Code,
+					Severity: GD7004Meta.Severity,
+					Message:  fmt.Sprintf("%s: %s, set on line %d", GD7004Meta.Message, declaration.key, previous),
+					Line:     line,
+					Column:   1,
+				})
+			}
+
+			unread[declaration.key] = line
+		}
+
+		return state.ReplaceData(unread)
+
+	default:
+		if len(unread) == 0 {
+			return state
+		}
+
+		unread = maps.Clone(unread)
+		for name := range referencedVars(instruction) {
+			delete(unread, name)
+		}
+
+		return state.ReplaceData(unread)
+	}
+}
@@ -0,0 +1,86 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD7004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD7004(),
+	}
+
+	t.Run("overwritten", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV MODE=debug
+COPY . /app
+ENV MODE=release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7004")
+	})
+
+	t.Run("overwritten on the same line", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV MODE=debug MODE=release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7004")
+	})
+
+	t.Run("read by run", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV NODE_ENV=development
+RUN npm ci
+ENV NODE_ENV=production`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7004")
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV PATH=/opt/bin:$PATH
+ENV PATH=/usr/local/go/bin:$PATH`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7004")
+	})
+
+	t.Run("read by workdir", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV DIR=/a
+WORKDIR $DIR
+ENV DIR=/b`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7004")
+	})
+
+	t.Run("other stage", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian AS a
+ENV MODE=debug
+FROM debian
+ENV MODE=release`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7004")
+	})
+}
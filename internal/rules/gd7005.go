@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD7005Meta contains metadata for rule GD7005.
+var GD7005Meta = rule.Meta{
+	Code:       "GD7005",
+	Severity:   rule.Warning,
+	Message:    "ENV shadows the ARG of the same name, so --build-arg no longer changes it",
+	References: []string{"https://docs.docker.com/reference/dockerfile/#using-arg-variables"},
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// gd7005Scope maps the ARGs and ENVs of a stage to the line declaring them.
+type gd7005Scope struct {
+	args map[string]int
+	envs map[string]int
+}
+
+// gd7005State follows the scope of the current stage, and those of the
+// stages named so far, which the stages built from them inherit.
+type gd7005State struct {
+	alias  string
+	scope  gd7005Scope
+	stages map[string]gd7005Scope
+}
+
+// GD7005Rule reports ENV and ARG instructions of a stage naming the same
+// variable, where the ENV silently wins: an ENV setting a build argument to
+// another value, or an ARG declared after an ENV of the same name. The
+// ENV NAME=${NAME} idiom, persisting a build argument in the image, is
+// fine.
+type GD7005Rule struct {
+	rule.StatefulRuleBase
+}
+
+// GD7005 creates the rule reporting ENV shadowing ARG.
+func GD7005() rule.Rule {
+	return &GD7005Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD7005Meta),
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD7005Rule) InitialState() rule.State {
+	return rule.EmptyState(gd7005State{})
+}
+
+// Check records the ARGs and ENVs of the stage, and reports the collisions.
+func (*GD7005Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	current := rule.Data[gd7005State](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		return state.ReplaceData(current.from(inst))
+
+	case *syntax.Arg:
+		if current.stages == nil {
+			// Global ARGs are not visible in stages until redeclared.
+			return state
+		}
+
+		args := maps.Clone(current.scope.args)
+
+		for _, declaration := range argDeclarations(inst) {
+			if previous, ok := current.scope.envs[declaration.key]; ok {
+				state = state.AddFailure(gd7005Failure(line,
+					fmt.Sprintf("ARG %s is declared after ENV %s on line %d", declaration.key, declaration.key, previous)))
+			}
+
+			args[declaration.key] = line
+		}
+
+		current.scope.args = args
+
+	case *syntax.Env:
+		if current.stages == nil {
+			return state
+		}
+
+		envs := maps.Clone(current.scope.envs)
+
+		for _, declaration := range envDeclarations(inst) {
+			previous, ok := current.scope.args[declaration.key]
+			if ok && !slices.Contains(expandedNames(*declaration.value), declaration.key) {
+				state = state.AddFailure(gd7005Failure(line,
+					fmt.Sprintf("ENV %s overrides ARG %s of line %d", declaration.key, declaration.key, previous)))
+			}
+
+			envs[declaration.key] = line
+		}
+
+		current.scope.envs = envs
+	}
+
+	return state.ReplaceData(current)
+}
+
+// from starts a stage, with the scope of the stage it is built from.
+func (s gd7005State) from(from *syntax.From) gd7005State {
+	s.stages = maps.Clone(s.stages)
+	if s.stages == nil {
+		s.stages = make(map[string]gd7005Scope)
+	} else if s.alias != "" {
+		s.stages[s.alias] = s.scope
+	}
+
+	s.alias = ""
+	s.scope = gd7005Scope{args: map[string]int{}, envs: map[string]int{}}
+
+	if from.Image.Alias != nil {
+		s.alias = strings.ToLower(*from.Image.Alias)
+	}
+
+	if inherited, ok := s.stages[strings.ToLower(from.Image.Image)]; ok && from.Image.Tag == nil {
+		s.scope = inherited
+	}
+
+	return s
+}
+
+// gd7005Failure reports a collision, detailed.
+func gd7005Failure(line int, detail string) rule.CheckFailure {
+	return rule.CheckFailure{
+		Code:     GD7005Meta.Code,
+		Severity: GD7005Meta.Severity,
+		Message:  GD7005Meta.Message + ": " + detail,
+		Line:     line,
+		Column:   1,
+	}
+}
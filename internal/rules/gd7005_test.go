@@ -0,0 +1,96 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD7005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD7005(),
+	}
+
+	t.Run("env overrides arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG VERSION=1.0
+ENV VERSION=2.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7005")
+	})
+
+	t.Run("arg after env", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ENV VERSION=2.0
+ARG VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7005")
+	})
+
+	t.Run("inherited arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian AS base
+ARG VERSION
+FROM base
+ENV VERSION=2.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD7005")
+	})
+
+	t.Run("persist idiom", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG VERSION=1.0
+ENV VERSION=${VERSION}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7005")
+	})
+
+	t.Run("persist idiom with prefix", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian
+ARG VERSION=1.0
+ENV VERSION=v$VERSION`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7005")
+	})
+
+	t.Run("global arg not redeclared", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG VERSION=1.0
+FROM debian
+ENV VERSION=2.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7005")
+	})
+
+	t.Run("different stages", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM debian AS a
+ARG VERSION
+FROM debian
+ENV VERSION=2.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD7005")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8001Meta contains metadata for rule GD8001.
+var GD8001Meta = rule.Meta{
+	Code:        "GD8001",
+	Severity:    rule.Error,
+	Message:     "Base image is not allowed by the image policy",
+	StageScoped: true,
+}
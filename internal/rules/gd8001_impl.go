@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD8001Rule reports base images the policy does not allow: matching a
+// deny glob, or none of the allow globs when there are some.
+type GD8001Rule struct {
+	rule.StatefulRuleBase
+
+	allow []string
+	deny  []string
+}
+
+// GD8001 creates the rule reporting denied base images, with no policy.
+func GD8001() rule.Rule {
+	return GD8001WithConfig(config.Default())
+}
+
+// GD8001WithConfig creates the rule with the allow and deny globs of
+// cfg.Images.
+func GD8001WithConfig(cfg *config.Config) rule.Rule {
+	return &GD8001Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8001Meta),
+		allow:            cfg.Images.Allow,
+		deny:             cfg.Images.Deny,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8001Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the base images the policy denies.
+func (r *GD8001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	state, base, ok := baseImage(state, instruction)
+	if !ok {
+		return state
+	}
+
+	var reason string
+
+	denied := slices.IndexFunc(r.deny, base.Matches)
+
+	switch {
+	case denied >= 0:
+		reason = "denied by " + r.deny[denied]
+	case len(r.allow) > 0 && !slices.ContainsFunc(r.allow, base.Matches):
+		reason = "not in the allowed images"
+	default:
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD8001Meta.Code,
+		Severity: GD8001Meta.Severity,
+		Message:  GD8001Meta.Message + ": " + base.String() + ", " + reason,
+		Line:     line,
+		Column:   1,
+	})
+}
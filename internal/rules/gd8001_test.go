@@ -0,0 +1,140 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD8001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8001(),
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+}
+
+func TestGD8001_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Images.Allow = []string{"docker.io/library/*", "ghcr.io/acme/**", "registry.example.com/tools/*"}
+	cfg.Images.Deny = []string{"centos", "node:16*", "registry.example.com/tools/legacy:latest"}
+
+	allRules := []rule.Rule{
+		rules.GD8001WithConfig(cfg),
+	}
+
+	t.Run("denied image", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM centos:7"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8001")
+	})
+
+	t.Run("denied tag", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM node:16-alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8001")
+	})
+
+	t.Run("denied untagged as latest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM registry.example.com/tools/legacy"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8001")
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM quay.io/acme/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8001")
+	})
+
+	t.Run("not allowed through arg", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `ARG BASE=quay.io/acme/app:1.0
+FROM ${BASE}`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8001")
+	})
+
+	t.Run("allowed official", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+
+	t.Run("allowed full name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM docker.io/library/debian:12"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+
+	t.Run("allowed nested", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM ghcr.io/acme/team/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+
+	t.Run("allowed tag not denied", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM node:22-alpine"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+
+	t.Run("stage reference", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM python:3.12 AS build
+FROM build`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+
+	t.Run("scratch", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM scratch"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8001")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8002Meta contains metadata for rule GD8002.
+var GD8002Meta = rule.Meta{
+	Code:        "GD8002",
+	Severity:    rule.Error,
+	Message:     "Images of this registry must be pinned by digest (image@sha256:...)",
+	StageScoped: true,
+}
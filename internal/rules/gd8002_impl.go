@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD8002Rule reports base images of the registries the policy requires
+// digests for, pinned by tag only: a tag can be moved to other content, a
+// digest cannot.
+type GD8002Rule struct {
+	rule.StatefulRuleBase
+
+	registries []string
+}
+
+// GD8002 creates the rule reporting unpinned base images, with no policy.
+func GD8002() rule.Rule {
+	return GD8002WithConfig(config.Default())
+}
+
+// GD8002WithConfig creates the rule with the registries of
+// cfg.Images.RequireDigest.
+func GD8002WithConfig(cfg *config.Config) rule.Rule {
+	return &GD8002Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8002Meta),
+		registries:       cfg.Images.RequireDigest,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8002Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the base images lacking the digest their registry requires.
+func (r *GD8002Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	state, base, ok := baseImage(state, instruction)
+	if !ok || base.Digest != "" {
+		return state
+	}
+
+	registry := base.Registry()
+	if !slices.ContainsFunc(r.registries, func(glob string) bool { return image.MatchGlob(glob, registry) }) {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD8002Meta.Code,
+		Severity: GD8002Meta.Severity,
+		Message:  GD8002Meta.Message + ": " + base.String(),
+		Line:     line,
+		Column:   1,
+	})
+}
@@ -0,0 +1,92 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD8002(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8002(),
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM ghcr.io/acme/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8002")
+	})
+}
+
+func TestGD8002_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Images.RequireDigest = []string{"ghcr.io", "*.dkr.ecr.*.amazonaws.com", "docker.io"}
+
+	allRules := []rule.Rule{
+		rules.GD8002WithConfig(cfg),
+	}
+
+	t.Run("tag only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM ghcr.io/acme/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8002")
+	})
+
+	t.Run("untagged", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM 123456789012.dkr.ecr.eu-west-1.amazonaws.com/app"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8002")
+	})
+
+	t.Run("docker hub", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8002")
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM ghcr.io/acme/app:1.0@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8002")
+	})
+
+	t.Run("other registry", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM quay.io/acme/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8002")
+	})
+
+	t.Run("registry with port", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM localhost:5000/app:1.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8002")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8003Meta contains metadata for rule GD8003.
+var GD8003Meta = rule.Meta{
+	Code:        "GD8003",
+	Severity:    rule.Warning,
+	Message:     "Base image tag does not match the pattern the image policy requires",
+	StageScoped: true,
+}
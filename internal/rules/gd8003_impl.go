@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// tagPattern is a tag pattern of the image policy, for the images a glob
+// matches.
+type tagPattern struct {
+	glob    string
+	pattern *regexp.Regexp
+}
+
+// GD8003Rule reports base image tags that do not match the patterns the
+// policy sets for them, such as full versions with a variant
+// (^\d+\.\d+\.\d+-). Every pattern whose glob matches the image applies;
+// images pinned by digest alone are left alone.
+type GD8003Rule struct {
+	rule.StatefulRuleBase
+
+	patterns []tagPattern
+}
+
+// GD8003 creates the rule reporting unexpected tags, with no policy.
+func GD8003() rule.Rule {
+	return GD8003WithConfig(config.Default())
+}
+
+// GD8003WithConfig creates the rule with the patterns of
+// cfg.Images.TagPatterns; invalid expressions are skipped, config.Parse
+// rejects them.
+func GD8003WithConfig(cfg *config.Config) rule.Rule {
+	var patterns []tagPattern
+
+	for _, glob := range slices.Sorted(maps.Keys(cfg.Images.TagPatterns)) {
+		if pattern, err := regexp.Compile(cfg.Images.TagPatterns[glob]); err == nil {
+			patterns = append(patterns, tagPattern{glob, pattern})
+		}
+	}
+
+	return &GD8003Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8003Meta),
+		patterns:         patterns,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8003Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the tags failing a pattern of their image.
+func (r *GD8003Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	state, base, ok := baseImage(state, instruction)
+	if !ok || base.Tag == "" {
+		return state
+	}
+
+	for _, tag := range r.patterns {
+		if !base.Matches(tag.glob) || tag.pattern.MatchString(base.Tag) {
+			continue
+		}
+
+		state = state.AddFailure(rule.CheckFailure{
+			Code:     GD8003Meta.Code,
+			Severity: GD8003Meta.Severity,
+			Message:  fmt.Sprintf("%s: %s, want %s", GD8003Meta.Message, base, tag.pattern),
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
@@ -0,0 +1,92 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD8003(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8003(),
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8003")
+	})
+}
+
+func TestGD8003_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Images.TagPatterns = map[string]string{"docker.io/library/*": `^\d+\.\d+\.\d+-`}
+
+	allRules := []rule.Rule{
+		rules.GD8003WithConfig(cfg),
+	}
+
+	t.Run("major only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8003")
+	})
+
+	t.Run("no variant", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM node:22.11.0"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8003")
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM node"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8003")
+	})
+
+	t.Run("full version with variant", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12.7-slim"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8003")
+	})
+
+	t.Run("other image", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM ghcr.io/acme/app:main"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8003")
+	})
+
+	t.Run("digest only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM node@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8003")
+	})
+}
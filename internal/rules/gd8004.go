@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8004Meta contains metadata for rule GD8004.
+var GD8004Meta = rule.Meta{
+	Code:        "GD8004",
+	Severity:    rule.Warning,
+	Message:     "Base image release reached its end of life, and no longer gets security fixes",
+	StageScoped: true,
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD8004Rule reports base images of a release cycle past its end of life,
+// such as python:3.7 or ubuntu:18.04. It knows the official debian, ubuntu,
+// alpine, node, python and golang images, and the images of
+// cfg.Images.EOL; tags naming no cycle (latest, a digest) are left alone.
+type GD8004Rule struct {
+	rule.StatefulRuleBase
+
+	table map[string][]config.EOLCycle
+	now   func() time.Time
+}
+
+// GD8004 creates the rule reporting end-of-life base images, with the
+// built-in table.
+func GD8004() rule.Rule {
+	return GD8004WithConfig(config.Default())
+}
+
+// GD8004WithConfig creates the rule with the built-in table, extended by
+// cfg.Images.EOL.
+func GD8004WithConfig(cfg *config.Config) rule.Rule {
+	return GD8004WithClock(cfg, time.Now)
+}
+
+// GD8004WithClock creates the rule with the built-in table, extended by
+// cfg.Images.EOL, checking the end of life dates against now.
+func GD8004WithClock(cfg *config.Config, now func() time.Time) rule.Rule {
+	return &GD8004Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8004Meta),
+		table:            eolTable(cfg.Images.EOL),
+		now:              now,
+	}
+}
+
+// Identity is the current date, so that cached lint results change with
+// the images reaching their end of life.
+func (r *GD8004Rule) Identity() string {
+	return "today " + r.now().UTC().Format(time.DateOnly)
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8004Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the base images past their end of life.
+func (r *GD8004Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	state, base, ok := baseImage(state, instruction)
+	if !ok {
+		return state
+	}
+
+	name := base.OfficialName()
+	if _, known := r.table[base.Name]; known || name == "" {
+		name = base.Name
+	}
+
+	cycle, ok := releaseCycle(r.table[name], base.Tag)
+	if !ok || r.now().Before(cycle.EOL) {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD8004Meta.Code,
+		Severity: GD8004Meta.Severity,
+		Message: fmt.Sprintf("%s: %s %s, on %s", GD8004Meta.Message,
+			name, cycle.Cycle, cycle.EOL.Format(time.DateOnly)),
+		Line:   line,
+		Column: 1,
+	})
+}
@@ -0,0 +1,237 @@
+package rules_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+// today pins the clock of GD8004, so that its tests do not depend on the
+// day they run.
+func today() time.Time {
+	return time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestGD8004(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8004WithClock(config.Default(), today),
+	}
+
+	t.Run("python 3.7", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.7", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": python 3.7, on 2023-06-27")
+	})
+
+	t.Run("python 3.7 variant", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.7.17-slim-bookworm", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": python 3.7, on 2023-06-27")
+	})
+
+	t.Run("ubuntu 18.04", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM ubuntu:18.04", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": ubuntu 18.04, on 2023-05-31")
+	})
+
+	t.Run("ubuntu codename", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM docker.io/library/ubuntu:bionic-20230530", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": ubuntu 18.04, on 2023-05-31")
+	})
+
+	t.Run("debian buster", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM debian:buster-slim", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": debian 10, on 2024-06-30")
+	})
+
+	t.Run("node 14", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM node:14-alpine", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": node 14, on 2023-04-30")
+	})
+
+	t.Run("alpine 3.12", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM alpine:3.12.12", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": alpine 3.12, on 2022-05-01")
+	})
+
+	t.Run("golang 1.19", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM golang:1.19", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": golang 1.19, on 2023-08-08")
+	})
+
+	t.Run("through arg", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("ARG PYTHON_VERSION=3.6\nFROM python:${PYTHON_VERSION}", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 2, rules.GD8004Meta.Message+": python 3.6, on 2021-12-23")
+	})
+
+	t.Run("python 3.12", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.12", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+
+	t.Run("alpine 3.1 prefix", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM alpine:3.10x", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM acme/python:3.7", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+
+	t.Run("node 25", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM node:25-alpine", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": node 25, on 2026-06-01")
+	})
+}
+
+func TestGD8004_RecentCycles(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8004WithClock(config.Default(), func() time.Time {
+			return time.Date(2032, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}),
+	}
+
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"python:3.14-slim", "python 3.14, on 2030-10-31"},
+		{"golang:1.25", "golang 1.25, on 2026-08-11"},
+		{"golang:1.26.1-alpine", "golang 1.26, on 2027-02-09"},
+		{"alpine:3.23", "alpine 3.23, on 2027-11-01"},
+		{"ubuntu:questing", "ubuntu 25.10, on 2026-07-09"},
+		{"ubuntu:26.04", "ubuntu 26.04, on 2031-05-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			t.Parallel()
+
+			violations := testutils.LintDockerfile("FROM "+tt.image, allRules)
+			testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": "+tt.want)
+		})
+	}
+
+	t.Run("unknown cycle", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.15", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+}
+
+func TestGD8004_Clock(t *testing.T) {
+	t.Parallel()
+
+	before := rules.GD8004WithClock(config.Default(), func() time.Time {
+		return time.Date(2026, time.October, 30, 0, 0, 0, 0, time.UTC)
+	})
+	after := rules.GD8004WithClock(config.Default(), func() time.Time {
+		return time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC)
+	})
+
+	t.Run("before end of life", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.10", []rule.Rule{before})
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+
+	t.Run("on end of life", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.10", []rule.Rule{after})
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": python 3.10, on 2026-10-31")
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		t.Parallel()
+
+		identity := func(r rule.Rule) string {
+			return r.(interface{ Identity() string }).Identity()
+		}
+
+		if identity(before) == identity(after) {
+			t.Errorf("Identity() = %q on both days, want the date", identity(before))
+		}
+	})
+}
+
+func TestGD8004_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Images.EOL = map[string][]config.EOLCycle{
+		"ghcr.io/acme/base": {{Cycle: "2.4", EOL: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+		"python": {
+			{Cycle: "3.7", EOL: time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{Cycle: "3.15", EOL: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	allRules := []rule.Rule{
+		rules.GD8004WithClock(cfg, today),
+	}
+
+	t.Run("cycle from file", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM ghcr.io/acme/base:2.4-slim", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1,
+			rules.GD8004Meta.Message+": ghcr.io/acme/base 2.4, on 2024-01-01")
+	})
+
+	t.Run("cycle added to a known image", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.15", allRules)
+		testutils.AssertViolation(t, violations, "GD8004", 1, rules.GD8004Meta.Message+": python 3.15, on 2026-01-01")
+	})
+
+	t.Run("cycle overridden", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.7", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8004")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8005Meta contains metadata for rule GD8005.
+var GD8005Meta = rule.Meta{
+	Code:        "GD8005",
+	Severity:    rule.Info,
+	Message:     "Image not pinned by digest, builds are not reproducible. Pin it with `godolint pin`",
+	StageScoped: true,
+}
@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD8005Rule reports the images of FROM and COPY --from pinned by tag
+// only, but those of cfg.Images.AllowUnpinned. GD8002 requires digests
+// for some registries only; this rule asks for them everywhere, which is
+// why it is only informational, and only once the image policy pins
+// images: with a lock file or required digests.
+type GD8005Rule struct {
+	rule.StatefulRuleBase
+
+	enabled bool
+	allowed []string
+}
+
+// GD8005 creates the rule reporting images not pinned by digest, which
+// reports nothing without a lock file or required digests.
+func GD8005() rule.Rule {
+	return GD8005WithConfig(config.Default())
+}
+
+// GD8005WithConfig creates the rule with the exceptions of
+// cfg.Images.AllowUnpinned, enabled when cfg.Images has a lock or
+// registries requiring digests.
+func GD8005WithConfig(cfg *config.Config) rule.Rule {
+	return &GD8005Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8005Meta),
+		enabled:          len(cfg.Images.Lock) > 0 || len(cfg.Images.RequireDigest) > 0,
+		allowed:          cfg.Images.AllowUnpinned,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8005Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the images lacking a digest.
+func (r *GD8005Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	if !r.enabled {
+		return state
+	}
+
+	state, pinned, ok := pinnableImage(state, instruction)
+	if !ok || pinned.Digest != "" || slices.ContainsFunc(r.allowed, pinned.Matches) {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD8005Meta.Code,
+		Severity: GD8005Meta.Severity,
+		Message:  GD8005Meta.Message + ": " + pinned.String(),
+		Line:     line,
+		Column:   1,
+	})
+}
@@ -0,0 +1,152 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+// pinningConfig is an image policy pinning images: registries requiring
+// digests, which enable GD8005.
+func pinningConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Images.RequireDigest = []string{"registry.example.com"}
+
+	return cfg
+}
+
+func TestGD8005(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8005WithConfig(pinningConfig()),
+	}
+
+	t.Run("tag only", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.12", allRules)
+		testutils.AssertViolation(t, violations, "GD8005", 1, rules.GD8005Meta.Message+": python:3.12")
+	})
+
+	t.Run("implicit latest", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python", allRules)
+		testutils.AssertViolation(t, violations, "GD8005", 1, rules.GD8005Meta.Message+": python:latest")
+	})
+
+	t.Run("copy from image", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM scratch\nCOPY --from=ghcr.io/acme/tool:1.4 /bin/tool /bin/tool", allRules)
+		testutils.AssertViolation(t, violations, "GD8005", 2, rules.GD8005Meta.Message+": ghcr.io/acme/tool:1.4")
+	})
+
+	t.Run("variable expanded", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("ARG BASE=node:22\nFROM $BASE", allRules)
+		testutils.AssertViolation(t, violations, "GD8005", 2, rules.GD8005Meta.Message+": node:22")
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(
+			"FROM python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("digest only", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(
+			"FROM python@sha256:0000000000000000000000000000000000000000000000000000000000000000", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("scratch", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM scratch", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("earlier stage", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000 AS build
+FROM build
+COPY --from=build /app /app`, allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("stage index", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile(`FROM python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000
+COPY --from=0 /app /app`, allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("unresolved variable", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM $BASE", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+}
+
+func TestGD8005_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := pinningConfig()
+	cfg.Images.AllowUnpinned = []string{"localhost:5000/**", "python"}
+
+	allRules := []rule.Rule{
+		rules.GD8005WithConfig(cfg),
+	}
+
+	t.Run("allowed registry", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM localhost:5000/app:dev", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("allowed image", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM python:3.12", allRules)
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("other image", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM node:22", allRules)
+		testutils.AssertViolation(t, violations, "GD8005", 1, rules.GD8005Meta.Message+": node:22")
+	})
+
+	t.Run("without pinning policy", func(t *testing.T) {
+		t.Parallel()
+
+		violations := testutils.LintDockerfile("FROM node:22", []rule.Rule{rules.GD8005()})
+		testutils.AssertNoViolation(t, violations, "GD8005")
+	})
+
+	t.Run("with a lock", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Images.Lock = map[string]string{"python:3.12": "sha256:" + strings.Repeat("a", 64)}
+
+		violations := testutils.LintDockerfile("FROM node:22", []rule.Rule{rules.GD8005WithConfig(cfg)})
+		testutils.AssertViolation(t, violations, "GD8005", 1, rules.GD8005Meta.Message+": node:22")
+	})
+}
@@ -0,0 +1,11 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD8006Meta contains metadata for rule GD8006.
+var GD8006Meta = rule.Meta{
+	Code:        "GD8006",
+	Severity:    rule.Error,
+	Message:     "Image digest does not match the lock file",
+	StageScoped: true,
+}
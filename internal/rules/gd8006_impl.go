@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// GD8006Rule reports the images of FROM and COPY --from pinned to another
+// digest than the one cfg.Images.Lock has for their tag: an outdated pin,
+// or a tag moved since the lock was made. Images pinned by digest alone,
+// and tags the lock does not have, are left alone.
+type GD8006Rule struct {
+	rule.StatefulRuleBase
+
+	lock image.Lock
+}
+
+// GD8006 creates the rule verifying digests, with no lock.
+func GD8006() rule.Rule {
+	return GD8006WithConfig(config.Default())
+}
+
+// GD8006WithConfig creates the rule with the lock of cfg.Images.Lock.
+func GD8006WithConfig(cfg *config.Config) rule.Rule {
+	return &GD8006Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD8006Meta),
+		lock:             cfg.Images.Lock,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD8006Rule) InitialState() rule.State {
+	return rule.EmptyState(buildVars{})
+}
+
+// Check reports the digests differing from the lock.
+func (r *GD8006Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	state, pinned, ok := pinnableImage(state, instruction)
+	if !ok || pinned.Digest == "" || pinned.Tag == "" {
+		return state
+	}
+
+	locked, ok := r.lock.Digest(pinned)
+	if !ok || locked == pinned.Digest {
+		return state
+	}
+
+	return state.AddFailure(rule.CheckFailure{
+		Code:     GD8006Meta.Code,
+		Severity: GD8006Meta.Severity,
+		Message:  fmt.Sprintf("%s: %s:%s is locked to %s", GD8006Meta.Message, pinned.Name, pinned.Tag, locked),
+		Line:     line,
+		Column:   1,
+	})
+}
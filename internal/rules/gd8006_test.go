@@ -0,0 +1,109 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD8006(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD8006(),
+	}
+
+	t.Run("no lock", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8006")
+	})
+}
+
+func TestGD8006_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+
+	lock, err := image.ParseLock([]byte("python:3.12 sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"))
+	if err != nil {
+		t.Fatalf("ParseLock() error = %v", err)
+	}
+
+	cfg.Images.Lock = lock
+
+	allRules := []rule.Rule{
+		rules.GD8006WithConfig(cfg),
+	}
+
+	t.Run("matches lock", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8006")
+	})
+
+	t.Run("differs from lock", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8006")
+	})
+
+	t.Run("full name", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM docker.io/library/python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8006")
+	})
+
+	t.Run("copy from image", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+COPY --from=python:3.12@sha256:0000000000000000000000000000000000000000000000000000000000000000 /app /app`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD8006")
+	})
+
+	t.Run("tag not locked", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.13@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8006")
+	})
+
+	t.Run("tag only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python:3.12"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8006")
+	})
+
+	t.Run("digest only", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := "FROM python@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD8006")
+	})
+}
@@ -0,0 +1,10 @@
+package rules
+
+import "github.com/farcloser/godolint/internal/rule"
+
+// GD9001Meta contains metadata for rule GD9001.
+var GD9001Meta = rule.Meta{
+	Code:     "GD9001",
+	Severity: rule.Info,
+	Message:  "label-schema.org labels are deprecated in favor of the OCI image annotations",
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// labelSchemaOCI maps the label-schema.org labels to the OCI annotations
+// superseding them, per the OCI image specification's annotations.md.
+// Labels missing from it have no OCI equivalent.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var labelSchemaOCI = map[string]string{
+	"org.label-schema.build-date":  "org.opencontainers.image.created",
+	"org.label-schema.url":         "org.opencontainers.image.url",
+	"org.label-schema.vcs-url":     "org.opencontainers.image.source",
+	"org.label-schema.version":     "org.opencontainers.image.version",
+	"org.label-schema.vcs-ref":     "org.opencontainers.image.revision",
+	"org.label-schema.vendor":      "org.opencontainers.image.vendor",
+	"org.label-schema.name":        "org.opencontainers.image.title",
+	"org.label-schema.description": "org.opencontainers.image.description",
+	"org.label-schema.usage":       "org.opencontainers.image.documentation",
+}
+
+// GD9001Rule reports the org.label-schema.* labels, with the OCI annotation
+// to use instead. Labels of the configured label schema are left alone:
+// requiring them, e.g. with the label-schema.org preset, is a choice.
+type GD9001Rule struct {
+	rule.StatefulRuleBase
+
+	schema map[string]config.LabelType
+}
+
+// GD9001 creates the rule reporting deprecated label-schema.org labels.
+func GD9001() rule.Rule {
+	return GD9001WithConfig(config.Default())
+}
+
+// GD9001WithConfig creates the rule sparing the labels of cfg.LabelSchema.
+func GD9001WithConfig(cfg *config.Config) rule.Rule {
+	return &GD9001Rule{
+		StatefulRuleBase: rule.NewStatefulRuleBase(GD9001Meta),
+		schema:           cfg.LabelSchema,
+	}
+}
+
+// InitialState returns the initial state for this rule.
+func (*GD9001Rule) InitialState() rule.State {
+	return rule.EmptyState(nil)
+}
+
+// Check reports each deprecated label of LABEL.
+func (r *GD9001Rule) Check(line int, state rule.State, instruction syntax.Instruction) rule.State {
+	label, ok := instruction.(*syntax.Label)
+	if !ok {
+		return state
+	}
+
+	for _, pair := range label.Pairs {
+		if _, required := r.schema[pair.Key]; required || !strings.HasPrefix(pair.Key, "org.label-schema.") {
+			continue
+		}
+
+		detail := pair.Key + " has no OCI equivalent, drop it"
+		if oci, ok := labelSchemaOCI[pair.Key]; ok {
+			detail = fmt.Sprintf("use %s instead of %s", oci, pair.Key)
+		}
+
+		state = state.AddFailure(rule.CheckFailure{
+			Code:     GD9001Meta.Code,
+			Severity: GD9001Meta.Severity,
+			Message:  GD9001Meta.Message + ": " + detail,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
@@ -0,0 +1,120 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+func TestGD9001(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.GD9001(),
+	}
+
+	t.Run("deprecated with equivalent", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.label-schema.vcs-ref=0123abc`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD9001")
+	})
+
+	t.Run("deprecated without equivalent", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.label-schema.schema-version=1.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD9001")
+	})
+
+	t.Run("among other labels", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL maintainer=me org.label-schema.build-date=2024-01-01T00:00:00Z`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD9001")
+	})
+
+	t.Run("oci labels", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.opencontainers.image.revision=0123abc`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD9001")
+	})
+
+	t.Run("other labels", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.label-schemas.version=1`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD9001")
+	})
+}
+
+func TestGD9001_Config(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.LabelSchema["org.label-schema.version"] = config.LabelTypeSemVer
+
+	allRules := []rule.Rule{
+		rules.GD9001WithConfig(cfg),
+	}
+
+	t.Run("required by the schema", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.label-schema.version=1.0.0`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertNoViolation(t, violations, "GD9001")
+	})
+
+	t.Run("other deprecated label", func(t *testing.T) {
+		t.Parallel()
+
+		dockerfile := `FROM scratch
+LABEL org.label-schema.url=https://example.com`
+		violations := testutils.LintDockerfile(dockerfile, allRules)
+
+		testutils.AssertContainsViolation(t, violations, "GD9001")
+	})
+}
+
+func TestGD9001_Message(t *testing.T) {
+	t.Parallel()
+
+	violations := testutils.LintDockerfile(
+		"FROM scratch\nLABEL org.label-schema.vcs-url=https://example.com/repo.git org.label-schema.schema-version=1.0",
+		[]rule.Rule{rules.GD9001()},
+	)
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2: %v", len(violations), violations)
+	}
+
+	if !strings.HasSuffix(violations[0].Message, "use org.opencontainers.image.source instead of org.label-schema.vcs-url") {
+		t.Errorf("got %q, want the OCI equivalent", violations[0].Message)
+	}
+
+	if !strings.HasSuffix(violations[1].Message, "org.label-schema.schema-version has no OCI equivalent, drop it") {
+		t.Errorf("got %q, want no equivalent", violations[1].Message)
+	}
+}
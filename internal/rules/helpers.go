@@ -25,3 +25,10 @@ func isWindowsAbsolute(path string) bool {
 
 	return unicode.IsLetter(rune(path[0])) && path[1] == ':'
 }
+
+// isWindowsRooted checks if a path is rooted without a drive letter, as
+// Windows reads it: \app on the current drive, or a \\server\share UNC
+// path. Elsewhere the backslash is an ordinary file name character.
+func isWindowsRooted(path string) bool {
+	return strings.HasPrefix(path, `\`)
+}
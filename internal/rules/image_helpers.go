@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/image"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// baseImage follows the variables FROM expands, and returns the external
+// image an instruction builds from: none for other instructions, scratch,
+// earlier stages, and names with variables that do not expand.
+func baseImage(state rule.State, instruction syntax.Instruction) (rule.State, image.Reference, bool) {
+	vars := rule.Data[buildVars](state)
+	next := vars.apply(instruction)
+	state = state.ReplaceData(next)
+
+	from, ok := instruction.(*syntax.From)
+	if !ok {
+		return state, image.Reference{}, false
+	}
+
+	ref := from.Image.Image
+	if raw := from.Raw().Args; len(raw) > 0 {
+		ref = vars.expand(raw[0], vars.fromEnv())
+	}
+
+	if ref == "" || ref == "scratch" || strings.Contains(ref, "$") {
+		return state, image.Reference{}, false
+	}
+
+	base := image.Parse(ref)
+
+	if _, stage := next.stages[strings.ToLower(base.Name)]; stage && !strings.ContainsAny(ref, ":@") {
+		return state, image.Reference{}, false
+	}
+
+	return state, base, true
+}
+
+// pinnableImage follows the variables of the Dockerfile, and returns the
+// external image an instruction uses: the base image of FROM, or the image
+// COPY --from copies from, rather than an earlier stage.
+func pinnableImage(state rule.State, instruction syntax.Instruction) (rule.State, image.Reference, bool) {
+	vars := rule.Data[buildVars](state)
+
+	copyInst, ok := instruction.(*syntax.Copy)
+	if !ok {
+		return baseImage(state, instruction)
+	}
+
+	state = state.ReplaceData(vars.apply(instruction))
+	if copyInst.From == nil {
+		return state, image.Reference{}, false
+	}
+
+	ref := vars.expand(*copyInst.From, vars.stageEnv())
+	if _, stage := vars.stages[strings.ToLower(ref)]; stage || ref == "" || strings.Contains(ref, "$") || isStageIndex(ref) {
+		return state, image.Reference{}, false
+	}
+
+	return state, image.Parse(ref), true
+}
+
+// isStageIndex reports whether COPY --from names a stage by its index.
+func isStageIndex(ref string) bool {
+	return ref != "" && strings.Trim(ref, "0123456789") == ""
+}
+
+// baseImageEOL is the built-in end-of-life table of common base images,
+// with the end of their (LTS) support; the dates of cycles still supported
+// are those announced, or the schedule's (golang: two releases later).
+// Cycles released after it are unknown to GD8004, until
+// config.ImagePolicy.EOL adds them.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var baseImageEOL = map[string][]config.EOLCycle{
+	"alpine": {
+		{Cycle: "3.7", EOL: eolDay("2019-11-01")},
+		{Cycle: "3.8", EOL: eolDay("2020-05-01")},
+		{Cycle: "3.9", EOL: eolDay("2020-11-01")},
+		{Cycle: "3.10", EOL: eolDay("2021-05-01")},
+		{Cycle: "3.11", EOL: eolDay("2021-11-01")},
+		{Cycle: "3.12", EOL: eolDay("2022-05-01")},
+		{Cycle: "3.13", EOL: eolDay("2022-11-01")},
+		{Cycle: "3.14", EOL: eolDay("2023-05-01")},
+		{Cycle: "3.15", EOL: eolDay("2023-11-01")},
+		{Cycle: "3.16", EOL: eolDay("2024-05-23")},
+		{Cycle: "3.17", EOL: eolDay("2024-11-22")},
+		{Cycle: "3.18", EOL: eolDay("2025-05-09")},
+		{Cycle: "3.19", EOL: eolDay("2025-11-01")},
+		{Cycle: "3.20", EOL: eolDay("2026-04-01")},
+		{Cycle: "3.21", EOL: eolDay("2026-11-01")},
+		{Cycle: "3.22", EOL: eolDay("2027-05-01")},
+		{Cycle: "3.23", EOL: eolDay("2027-11-01")},
+	},
+	"debian": {
+		{Cycle: "8", Aliases: []string{"jessie"}, EOL: eolDay("2020-06-30")},
+		{Cycle: "9", Aliases: []string{"stretch"}, EOL: eolDay("2022-06-30")},
+		{Cycle: "10", Aliases: []string{"buster"}, EOL: eolDay("2024-06-30")},
+		{Cycle: "11", Aliases: []string{"bullseye"}, EOL: eolDay("2026-08-31")},
+		{Cycle: "12", Aliases: []string{"bookworm"}, EOL: eolDay("2028-06-30")},
+		{Cycle: "13", Aliases: []string{"trixie"}, EOL: eolDay("2030-06-30")},
+	},
+	"ubuntu": {
+		{Cycle: "14.04", Aliases: []string{"trusty"}, EOL: eolDay("2019-04-30")},
+		{Cycle: "16.04", Aliases: []string{"xenial"}, EOL: eolDay("2021-04-30")},
+		{Cycle: "18.04", Aliases: []string{"bionic"}, EOL: eolDay("2023-05-31")},
+		{Cycle: "20.04", Aliases: []string{"focal"}, EOL: eolDay("2025-05-31")},
+		{Cycle: "22.04", Aliases: []string{"jammy"}, EOL: eolDay("2027-06-01")},
+		{Cycle: "23.04", Aliases: []string{"lunar"}, EOL: eolDay("2024-01-25")},
+		{Cycle: "23.10", Aliases: []string{"mantic"}, EOL: eolDay("2024-07-11")},
+		{Cycle: "24.04", Aliases: []string{"noble"}, EOL: eolDay("2029-05-31")},
+		{Cycle: "24.10", Aliases: []string{"oracular"}, EOL: eolDay("2025-07-10")},
+		{Cycle: "25.04", Aliases: []string{"plucky"}, EOL: eolDay("2026-01-15")},
+		{Cycle: "25.10", Aliases: []string{"questing"}, EOL: eolDay("2026-07-09")},
+		{Cycle: "26.04", Aliases: []string{"resolute"}, EOL: eolDay("2031-05-31")},
+	},
+	"node": {
+		{Cycle: "10", Aliases: []string{"dubnium"}, EOL: eolDay("2021-04-30")},
+		{Cycle: "12", Aliases: []string{"erbium"}, EOL: eolDay("2022-04-30")},
+		{Cycle: "14", Aliases: []string{"fermium"}, EOL: eolDay("2023-04-30")},
+		{Cycle: "15", EOL: eolDay("2021-06-01")},
+		{Cycle: "16", Aliases: []string{"gallium"}, EOL: eolDay("2023-09-11")},
+		{Cycle: "17", EOL: eolDay("2022-06-01")},
+		{Cycle: "18", Aliases: []string{"hydrogen"}, EOL: eolDay("2025-04-30")},
+		{Cycle: "19", EOL: eolDay("2023-06-01")},
+		{Cycle: "20", Aliases: []string{"iron"}, EOL: eolDay("2026-04-30")},
+		{Cycle: "21", EOL: eolDay("2024-06-01")},
+		{Cycle: "22", Aliases: []string{"jod"}, EOL: eolDay("2027-04-30")},
+		{Cycle: "23", EOL: eolDay("2025-06-01")},
+		{Cycle: "24", Aliases: []string{"krypton"}, EOL: eolDay("2028-04-30")},
+		{Cycle: "25", EOL: eolDay("2026-06-01")},
+	},
+	"python": {
+		{Cycle: "2.7", EOL: eolDay("2020-01-01")},
+		{Cycle: "3.5", EOL: eolDay("2020-09-13")},
+		{Cycle: "3.6", EOL: eolDay("2021-12-23")},
+		{Cycle: "3.7", EOL: eolDay("2023-06-27")},
+		{Cycle: "3.8", EOL: eolDay("2024-10-07")},
+		{Cycle: "3.9", EOL: eolDay("2025-10-31")},
+		{Cycle: "3.10", EOL: eolDay("2026-10-31")},
+		{Cycle: "3.11", EOL: eolDay("2027-10-31")},
+		{Cycle: "3.12", EOL: eolDay("2028-10-31")},
+		{Cycle: "3.13", EOL: eolDay("2029-10-31")},
+		{Cycle: "3.14", EOL: eolDay("2030-10-31")},
+	},
+	"golang": {
+		{Cycle: "1.18", EOL: eolDay("2023-02-01")},
+		{Cycle: "1.19", EOL: eolDay("2023-08-08")},
+		{Cycle: "1.20", EOL: eolDay("2024-02-06")},
+		{Cycle: "1.21", EOL: eolDay("2024-08-13")},
+		{Cycle: "1.22", EOL: eolDay("2025-02-11")},
+		{Cycle: "1.23", EOL: eolDay("2025-08-12")},
+		{Cycle: "1.24", EOL: eolDay("2026-02-10")},
+		{Cycle: "1.25", EOL: eolDay("2026-08-11")},
+		{Cycle: "1.26", EOL: eolDay("2027-02-09")},
+	},
+}
+
+// eolDay parses a day of the built-in end-of-life table.
+func eolDay(day string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, day)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// eolTable merges end-of-life cycles over the built-in table: a cycle
+// listed in both takes the value of extra.
+func eolTable(extra map[string][]config.EOLCycle) map[string][]config.EOLCycle {
+	table := make(map[string][]config.EOLCycle, len(baseImageEOL)+len(extra))
+
+	for image, cycles := range baseImageEOL {
+		table[image] = slices.Clone(cycles)
+	}
+
+	for image, cycles := range extra {
+		for _, cycle := range cycles {
+			table[image] = slices.DeleteFunc(table[image], func(known config.EOLCycle) bool {
+				return known.Cycle == cycle.Cycle
+			})
+			table[image] = append(table[image], cycle)
+		}
+	}
+
+	return table
+}
+
+// releaseCycle returns the cycle of a table a tag belongs to: the one it
+// names, or starts with followed by a dot or dash (3.7.17, 3.7-slim). Tags
+// of a cycle the table lacks belong to none.
+func releaseCycle(cycles []config.EOLCycle, tag string) (config.EOLCycle, bool) {
+	for _, cycle := range cycles {
+		for _, prefix := range append([]string{cycle.Cycle}, cycle.Aliases...) {
+			if tag == prefix || strings.HasPrefix(tag, prefix+".") || strings.HasPrefix(tag, prefix+"-") {
+				return cycle, true
+			}
+		}
+	}
+
+	return config.EOLCycle{}, false
+}
@@ -0,0 +1,100 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+// Label type cases for DL3052-DL3058, which hadolint's suite (the generated
+// tests) covers with a single value each. They follow the standards of the
+// validators hadolint calls: RFC 3986 absolute URIs, RFC 3339 timestamps,
+// SPDX license expressions, Semantic Versioning 2.0.0 and RFC 5322
+// addr-specs. The cases are hand-picked from those standards: they are not
+// hadolint's test corpus, nor Cabal's Distribution.SPDX parser cases.
+func TestLabelTypes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		LabelSchema: map[string]config.LabelType{
+			"url":     config.LabelTypeURL,
+			"created": config.LabelTypeRFC3339,
+			"license": config.LabelTypeSPDX,
+			"version": config.LabelTypeSemVer,
+			"commit":  config.LabelTypeGitHash,
+			"contact": config.LabelTypeEmail,
+		},
+	}
+
+	tests := []struct {
+		code      rule.Code
+		rule      rule.Rule
+		label     string
+		value     string
+		violation bool
+	}{
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "https://github.com/acme/app", false},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "git+ssh://git@github.com/acme/app.git", false},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "mailto:ops@example.com", false},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "https://example.com/a%20b?q=1#top", false},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "github.com/acme/app", true},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "/relative/path", true},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "https://example.com/a b", true},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "https://example.com/{app}", true},
+		{"DL3052", rules.DL3052WithConfig(cfg), "url", "https://example.com/%zz", true},
+
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-05-01T10:26:33Z", false},
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-05-01T10:26:33.5+02:00", false},
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-05-01t10:26:33z", false},
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-05-01", true},
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-05-01T10:26:33", true},
+		{"DL3053", rules.DL3053WithConfig(cfg), "created", "2024-13-01T10:26:33Z", true},
+
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "MIT", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "Apache-2.0 OR MIT", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "(MIT AND BSD-2-Clause) OR GPL-2.0+", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "GPL-2.0-only WITH Classpath-exception-2.0", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "LicenseRef-acme", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "NONE", false},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "MIT-9.9", true},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "mit", true},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "MIT or Apache-2.0", true},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "GPL-3.0 WITH MIT", true},
+		{"DL3054", rules.DL3054WithConfig(cfg), "license", "(MIT", true},
+
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "1.2.3", false},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "1.0.0-rc.1+build.5", false},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "0.0.0-alpha-2", false},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "v1.2.3", true},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "1.2", true},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "1.2.3-", true},
+		{"DL3056", rules.DL3056WithConfig(cfg), "version", "1.2.3-rc..1", true},
+
+		{"DL3055", rules.DL3055WithConfig(cfg), "commit", "2DBFAE9", false},
+		{"DL3055", rules.DL3055WithConfig(cfg), "commit", "2dbfae91", true},
+		{"DL3055", rules.DL3055WithConfig(cfg), "commit", "2dbfae", true},
+
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "ops@example.com", false},
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "ops+images@mail.example.com", false},
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "Ops <ops@example.com>", true},
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "<ops@example.com>", true},
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "ops.example.com", true},
+		{"DL3058", rules.DL3058WithConfig(cfg), "contact", "ops@", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code)+" "+tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			violations := testutils.LintDockerfile(`LABEL `+tt.label+`="`+tt.value+`"`, []rule.Rule{tt.rule})
+			if tt.violation {
+				testutils.AssertContainsViolation(t, violations, string(tt.code))
+			} else {
+				testutils.AssertNoViolation(t, violations, string(tt.code))
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package rules
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// layerPath is a path created by a layer of the stage: a file, or a
+// directory standing for its contents.
+type layerPath struct {
+	path    string // Absolute and clean
+	origin  string // What created it: "apt-get update", "curl", "COPY"...
+	line    int    // Line of the instruction creating it
+	archive bool   // An archive copied in as is, to be extracted
+}
+
+// layerRemoval is the removal, by a RUN, of a path an earlier layer created.
+type layerRemoval struct {
+	created   layerPath
+	extracted bool // The RUN also extracts the archive
+}
+
+// stageFiles is what a stage's layers hold so far.
+type stageFiles struct {
+	workdir string // "" when unknown (a WORKDIR with variables)
+	created []layerPath
+}
+
+// stageLayers tracks the paths the layers of each stage create, so that
+// rules can tell a cleanup in the layer creating the files, which keeps
+// them out of the image, from one in a later layer, which does not. A stage
+// built FROM another inherits its files. The zero value is ready to use;
+// methods return updated copies, so it can be kept in rule state.
+type stageLayers struct {
+	current stageFiles
+	alias   string
+	stages  map[string]stageFiles
+}
+
+// from starts a new stage.
+func (s stageLayers) from(from *syntax.From) stageLayers {
+	next := stageLayers{stages: s.stages}
+
+	if parent, ok := s.stages[strings.ToLower(from.Image.Image)]; ok {
+		next.current = parent
+	} else {
+		next.current = stageFiles{workdir: "/"}
+	}
+
+	if from.Image.Alias != nil {
+		next.alias = strings.ToLower(*from.Image.Alias)
+		next.record()
+	}
+
+	return next
+}
+
+// workdir applies a WORKDIR instruction.
+func (s stageLayers) workdir(workdir *syntax.Workdir) stageLayers {
+	s.current.workdir = s.resolve(dropQuotes(workdir.Directory))
+	s.record()
+
+	return s
+}
+
+// copyFiles records the files a COPY or ADD creates. ADD extracts local
+// archives, which leaves none behind; remote ones and zip files are copied
+// as is.
+func (s stageLayers) copyFiles(line int, origin string, sources []string, destination string) stageLayers {
+	destination = dropQuotes(destination)
+	toDirectory := len(sources) > 1 || strings.HasSuffix(destination, "/") || destination == "."
+
+	var created []layerPath
+
+	for _, source := range sources {
+		source = dropQuotes(source)
+		remote := isURL(source)
+
+		if origin == "ADD" && !remote && isArchive(source) {
+			continue
+		}
+
+		target := destination
+		if toDirectory {
+			target = path.Join(destination, path.Base(source))
+		}
+
+		if target = s.resolve(target); target != "" {
+			created = append(created, layerPath{path: target, origin: origin, line: line, archive: isExtractable(source)})
+		}
+	}
+
+	s.current.created = append(slices.Clip(s.current.created), created...)
+	s.record()
+
+	return s
+}
+
+// run records the paths a RUN creates, and returns the removals of paths
+// earlier layers created.
+func (s stageLayers) run(line int, run *syntax.Run) (stageLayers, []layerRemoval) {
+	script, dialect, _ := shell.RunScript(run, shell.DialectSh)
+	if !dialect.IsShell() {
+		return s, nil
+	}
+
+	parsed, err := shell.ParseShell(script)
+	if err != nil {
+		return s, nil
+	}
+
+	earlier := s.current.created
+
+	var (
+		created   []layerPath
+		removed   []string
+		extracted []string
+	)
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, _ shell.Context) bool {
+		cmd := stmt.Command()
+		if cmd == nil {
+			return true
+		}
+
+		for _, target := range createdPaths(*cmd) {
+			if target = s.resolve(target); target != "" && !isUnderCacheOrTmpfsMount(run.Flags, target) {
+				created = append(created, layerPath{path: target, origin: cmd.Name, line: line})
+			}
+		}
+
+		for _, target := range removedPaths(*cmd) {
+			if target = s.resolve(target); target != "" {
+				removed = append(removed, target)
+			}
+		}
+
+		for _, target := range extractedArchives(*cmd) {
+			if target = s.resolve(target); target != "" {
+				extracted = append(extracted, target)
+			}
+		}
+
+		return true
+	})
+
+	var removals []layerRemoval
+
+	kept := make([]layerPath, 0, len(earlier)+len(created))
+
+	for _, file := range earlier {
+		if !slices.ContainsFunc(removed, func(target string) bool { return pathCovers(target, file.path) }) {
+			kept = append(kept, file)
+
+			continue
+		}
+
+		removals = append(removals, layerRemoval{
+			created:   file,
+			extracted: file.archive && slices.Contains(extracted, file.path),
+		})
+	}
+
+	// Files removed by the RUN creating them never make it to a layer.
+	for _, file := range created {
+		if !slices.ContainsFunc(removed, func(target string) bool { return pathCovers(target, file.path) }) {
+			kept = append(kept, file)
+		}
+	}
+
+	s.current.created = kept
+	s.record()
+
+	return s, removals
+}
+
+// resolve makes a path absolute against the working directory, and returns
+// "" for paths that cannot be resolved statically.
+func (s stageLayers) resolve(target string) string {
+	if target == "" || strings.Contains(target, "$") || strings.HasPrefix(target, "~") {
+		return ""
+	}
+
+	if !path.IsAbs(target) {
+		if s.current.workdir == "" {
+			return ""
+		}
+
+		target = path.Join(s.current.workdir, target)
+	}
+
+	return path.Clean(target)
+}
+
+// record saves the current stage under its alias, copying the map so that
+// earlier copies of s are unaffected.
+func (s *stageLayers) record() {
+	if s.alias == "" {
+		return
+	}
+
+	stages := maps.Clone(s.stages)
+	if stages == nil {
+		stages = make(map[string]stageFiles)
+	}
+
+	stages[s.alias] = s.current
+	s.stages = stages
+}
+
+// pathCovers reports whether removing target, which may end in a glob,
+// removes the created path or part of it.
+func pathCovers(target, created string) bool {
+	target = strings.TrimSuffix(target, "/*")
+
+	if strings.ContainsAny(target, "*?[") {
+		matched, err := path.Match(target, created)
+
+		return err == nil && matched
+	}
+
+	return target == created || strings.HasPrefix(created, target+"/") || strings.HasPrefix(target, created+"/")
+}
+
+// Caches and lists package managers leave behind, by command and
+// subcommand.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var packageManagerPaths = map[string]map[string]string{
+	"apt":      {"update": "/var/lib/apt/lists"},
+	"apt-get":  {"update": "/var/lib/apt/lists"},
+	"aptitude": {"update": "/var/lib/apt/lists"},
+	"apk":      {"add": "/var/cache/apk", "update": "/var/cache/apk", "upgrade": "/var/cache/apk"},
+	"dnf":      {"install": "/var/cache/dnf", "update": "/var/cache/dnf", "upgrade": "/var/cache/dnf"},
+	"microdnf": {"install": "/var/cache/yum", "update": "/var/cache/yum", "upgrade": "/var/cache/yum"},
+	"yum":      {"install": "/var/cache/yum", "update": "/var/cache/yum", "upgrade": "/var/cache/yum"},
+	"zypper":   {"install": "/var/cache/zypp", "in": "/var/cache/zypp", "refresh": "/var/cache/zypp"},
+	"pip":      {"install": "/root/.cache/pip"},
+	"pip3":     {"install": "/root/.cache/pip"},
+	"npm":      {"install": "/root/.npm", "ci": "/root/.npm", "i": "/root/.npm"},
+	"yarn":     {"install": "/usr/local/share/.cache/yarn", "add": "/usr/local/share/.cache/yarn"},
+}
+
+// createdPaths returns the paths a command creates: package manager caches
+// and lists, and downloaded files.
+func createdPaths(cmd shell.Command) []string {
+	args := shell.GetArgsNoFlags(cmd)
+
+	switch cmd.Name {
+	case "curl":
+		return curlOutputs(cmd)
+	case "wget":
+		return wgetOutputs(cmd)
+	case "git":
+		return gitCloneDirectory(args)
+	}
+
+	subcommands, ok := packageManagerPaths[cmd.Name]
+	if !ok || len(args) == 0 {
+		return nil
+	}
+
+	created, ok := subcommands[args[0]]
+	if !ok || shell.HasAnyFlag([]string{"no-cache", "no-cache-dir"}, cmd) {
+		return nil
+	}
+
+	return []string{created}
+}
+
+// gitCloneDirectory returns the directory `git clone` creates: the one
+// following the repository, or the repository's name.
+func gitCloneDirectory(args []string) []string {
+	if len(args) == 0 || args[0] != "clone" {
+		return nil
+	}
+
+	for i, arg := range args[1:] {
+		if !strings.Contains(arg, "://") && !strings.HasPrefix(arg, "git@") {
+			continue
+		}
+
+		if i+2 < len(args) {
+			return []string{args[i+2]}
+		}
+
+		return []string{strings.TrimSuffix(path.Base(arg), ".git")}
+	}
+
+	return nil
+}
+
+// shortFlagArg returns the values of a single letter flag, also where it
+// ends a cluster (curl -fsSLo file) or has its value attached (wget -qO-).
+func shortFlagArg(flag rune, cmd shell.Command) []string {
+	var values []string
+
+	args := shell.GetArgs(cmd)
+
+	for i, arg := range args {
+		cluster, ok := strings.CutPrefix(arg, "-")
+		if !ok || strings.HasPrefix(cluster, "-") {
+			continue
+		}
+
+		idx := strings.IndexRune(cluster, flag)
+		if idx == -1 {
+			continue
+		}
+
+		switch value := cluster[idx+1:]; {
+		case value != "":
+			values = append(values, value)
+		case i+1 < len(args):
+			values = append(values, args[i+1])
+		}
+	}
+
+	return values
+}
+
+func curlOutputs(cmd shell.Command) []string {
+	outputs := append(shortFlagArg('o', cmd), shell.GetFlagArg("output", cmd)...)
+
+	if shell.HasAnyFlag([]string{"O", "remote-name"}, cmd) {
+		for _, arg := range shell.GetArgs(cmd) {
+			if isURL(arg) {
+				outputs = append(outputs, path.Base(arg))
+			}
+		}
+	}
+
+	return slices.DeleteFunc(outputs, func(output string) bool { return output == "-" || output == "/dev/null" })
+}
+
+func wgetOutputs(cmd shell.Command) []string {
+	outputs := append(shortFlagArg('O', cmd), shell.GetFlagArg("output-document", cmd)...)
+	if len(outputs) > 0 {
+		return slices.DeleteFunc(outputs, func(output string) bool { return output == "-" })
+	}
+
+	prefix := ""
+	if dirs := append(shell.GetFlagArg("P", cmd), shell.GetFlagArg("directory-prefix", cmd)...); len(dirs) > 0 {
+		prefix = dirs[len(dirs)-1]
+	}
+
+	for _, arg := range shell.GetArgs(cmd) {
+		if isURL(arg) {
+			outputs = append(outputs, path.Join(prefix, path.Base(arg)))
+		}
+	}
+
+	return outputs
+}
+
+// removedPaths returns the paths a command removes: rm arguments, and the
+// caches cleaned by package manager commands.
+func removedPaths(cmd shell.Command) []string {
+	args := shell.GetArgsNoFlags(cmd)
+
+	switch {
+	case cmd.Name == "rm":
+		return args
+	case (cmd.Name == "apt-get" || cmd.Name == "apt") && len(args) > 0 && args[0] == "clean":
+		return []string{"/var/cache/apt"}
+	case (cmd.Name == "dnf" || cmd.Name == "yum" || cmd.Name == "microdnf") && len(args) > 1 && args[0] == "clean":
+		return []string{"/var/cache/" + cmd.Name, "/var/cache/yum"}
+	case cmd.Name == "apk" && len(args) > 1 && args[0] == "cache" && args[1] == "clean":
+		return []string{"/var/cache/apk"}
+	case cmd.Name == "zypper" && len(args) > 0 && (args[0] == "clean" || args[0] == "cc"):
+		return []string{"/var/cache/zypp"}
+	case (cmd.Name == "pip" || cmd.Name == "pip3") && len(args) > 1 && args[0] == "cache" && args[1] == "purge":
+		return []string{"/root/.cache/pip"}
+	case (cmd.Name == "npm" || cmd.Name == "yarn") && len(args) > 1 && args[0] == "cache" && args[1] == "clean":
+		if cmd.Name == "npm" {
+			return []string{"/root/.npm"}
+		}
+
+		return []string{"/usr/local/share/.cache/yarn"}
+	default:
+		return nil
+	}
+}
+
+// extractedArchives returns the archives a tar, unzip or 7z command
+// extracts.
+func extractedArchives(cmd shell.Command) []string {
+	switch cmd.Name {
+	case "tar", "bsdtar":
+		if archives := append(shell.GetFlagArg("f", cmd), shell.GetFlagArg("file", cmd)...); len(archives) > 0 {
+			return archives
+		}
+
+		// Old style: tar xzf archive.tar.gz
+		return slices.DeleteFunc(shell.GetArgs(cmd), func(arg string) bool { return !isExtractable(arg) })
+	case "unzip", "7z", "gunzip", "xz", "bunzip2", "zstd":
+		return slices.DeleteFunc(shell.GetArgs(cmd), func(arg string) bool { return !isExtractable(arg) })
+	default:
+		return nil
+	}
+}
+
+// isExtractable reports whether a file name is an archive tar, unzip or 7z
+// extract: the archives ADD extracts, plus zip and 7z.
+func isExtractable(name string) bool {
+	lower := strings.ToLower(name)
+
+	return isArchive(name) || strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".7z")
+}
+
+// checkLayerRemovals follows the layers of each stage, and reports the
+// removals message picks, with the details it returns.
+func checkLayerRemovals(
+	meta rule.Meta,
+	line int,
+	state rule.State,
+	instruction syntax.Instruction,
+	message func(removal layerRemoval) (string, bool),
+) rule.State {
+	layers := rule.Data[stageLayers](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		return state.ReplaceData(layers.from(inst))
+
+	case *syntax.Workdir:
+		return state.ReplaceData(layers.workdir(inst))
+
+	case *syntax.Copy:
+		origin := "COPY"
+		if inst.From != nil {
+			origin = "COPY --from=" + *inst.From
+		}
+
+		return state.ReplaceData(layers.copyFiles(line, origin, inst.Source, inst.Destination))
+
+	case *syntax.Add:
+		return state.ReplaceData(layers.copyFiles(line, "ADD", inst.Source, inst.Destination))
+
+	case *syntax.Run:
+		layers, removals := layers.run(line, inst)
+		state = state.ReplaceData(layers)
+
+		for _, removal := range removals {
+			details, ok := message(removal)
+			if !ok {
+				continue
+			}
+
+			state = state.AddFailure(rule.CheckFailure{
+				Code:     meta.Code,
+				Severity: meta.Severity,
+				Message:  meta.Message + ": " + details,
+				Line:     line,
+				Column:   1,
+			})
+		}
+
+		return state
+	}
+
+	return state
+}
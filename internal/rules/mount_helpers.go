@@ -1,10 +1,22 @@
 package rules
 
-import "strings"
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// runMount is a --mount flag of a RUN instruction.
+type runMount struct {
+	mountType string // bind when unset
+	target    string
+	env       string // variable a secret mount sets (env=NAME)
+}
+
+// runMounts returns the --mount flags of a RUN instruction.
+func runMounts(flags []string) []runMount {
+	var mounts []runMount
 
-// hasCacheOrTmpfsMount checks if RUN flags contain a cache or tmpfs mount
-// for the specified path.
-func hasCacheOrTmpfsMount(flags []string, path string) bool {
 	for _, flag := range flags {
 		if !strings.HasPrefix(flag, "--mount=") {
 			continue
@@ -13,8 +25,7 @@ func hasCacheOrTmpfsMount(flags []string, path string) bool {
 		mountSpec := strings.TrimPrefix(flag, "--mount=")
 		parts := strings.Split(mountSpec, ",")
 
-		mountType := ""
-		target := ""
+		mount := runMount{mountType: "bind"}
 
 		for _, part := range parts {
 			keyVal := strings.SplitN(part, "=", 2)
@@ -27,24 +38,61 @@ func hasCacheOrTmpfsMount(flags []string, path string) bool {
 
 			switch key {
 			case "type":
-				mountType = value
-			case "target":
-				target = value
+				mount.mountType = value
+			case "target", "dst", "destination":
+				mount.target = value
+			case "env":
+				mount.env = value
 			default:
 				// Other mount options are irrelevant here.
 			}
 		}
 
-		// Check if it's a cache or tmpfs mount
-		if mountType != "cache" && mountType != "tmpfs" {
-			continue
-		}
+		mounts = append(mounts, mount)
+	}
+
+	return mounts
+}
 
-		// Check if target matches the path
-		if target == path || strings.HasPrefix(target, path+"/") {
-			return true
+// cacheOrTmpfsTargets returns the targets of the cache and tmpfs mounts in
+// RUN flags, whose contents stay out of the layer.
+func cacheOrTmpfsTargets(flags []string) []string {
+	var targets []string
+
+	for _, mount := range runMounts(flags) {
+		// Check if it's a cache or tmpfs mount
+		if mount.mountType == "cache" || mount.mountType == "tmpfs" {
+			targets = append(targets, mount.target)
 		}
 	}
 
-	return false
+	return targets
+}
+
+// hasCacheOrTmpfsMount checks if RUN flags contain a cache or tmpfs mount
+// for the specified path.
+func hasCacheOrTmpfsMount(flags []string, path string) bool {
+	// Check if target matches the path
+	return slices.ContainsFunc(cacheOrTmpfsTargets(flags), func(target string) bool {
+		return target == path || strings.HasPrefix(target, path+"/")
+	})
+}
+
+// isUnderCacheOrTmpfsMount checks if a cache or tmpfs mount of the RUN holds
+// the specified path: a mount of the path itself or of a parent directory.
+func isUnderCacheOrTmpfsMount(flags []string, target string) bool {
+	return slices.ContainsFunc(cacheOrTmpfsTargets(flags), func(mount string) bool {
+		mount = path.Clean(mount)
+
+		return target == mount || strings.HasPrefix(target, strings.TrimSuffix(mount, "/")+"/")
+	})
+}
+
+// hasCacheOrTmpfsMountNamed checks if RUN flags contain a cache or tmpfs
+// mount on a directory with one of the names, wherever it is (such as
+// target, for a build output directory).
+func hasCacheOrTmpfsMountNamed(flags []string, names ...string) bool {
+	return slices.ContainsFunc(cacheOrTmpfsTargets(flags), func(target string) bool {
+		return slices.Contains(names, path.Base(target))
+	})
 }
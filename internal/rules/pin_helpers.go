@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+// packageArgs returns the operands of a command once its first words
+// subcommand words are dropped: the arguments that are neither flags nor the
+// values of valueFlags. valueFlags are given without dashes, as GetFlagArg
+// takes them; a --flag=value argument carries its own value.
+func packageArgs(cmd shell.Command, words int, valueFlags ...string) []string {
+	// The values of separate value flags are the only arguments that are
+	// neither flags nor operands.
+	values := make(map[string]int)
+
+	for _, flag := range valueFlags {
+		for _, value := range shell.GetFlagArg(flag, cmd) {
+			if !shell.HasArg("--"+flag+"="+value, cmd) {
+				values[value]++
+			}
+		}
+	}
+
+	var operands []string
+
+	for _, arg := range shell.GetArgsNoFlags(cmd) {
+		switch {
+		case arg == "--":
+		case values[arg] > 0:
+			values[arg]--
+		default:
+			operands = append(operands, arg)
+		}
+	}
+
+	if len(operands) < words {
+		return nil
+	}
+
+	return operands[words:]
+}
+
+// hasFlagValue reports whether any of the flags is given a value, as in
+// --version 1.2.3 or --version=1.2.3.
+func hasFlagValue(cmd shell.Command, flags ...string) bool {
+	return slices.ContainsFunc(flags, func(flag string) bool {
+		return len(shell.GetFlagArg(flag, cmd)) > 0
+	})
+}
+
+// isFloatingVersion reports whether an explicit version still follows the
+// registry: latest, or the empty version of a trailing @.
+func isFloatingVersion(version string) bool {
+	return version == "" || version == "latest"
+}
+
+// splitVersionAt splits a package@version operand at its last @, leaving
+// the @ of an npm scope (@types/node) or of a URL's user (git@host) in the
+// name. ok is false when there is no version.
+func splitVersionAt(pkg string) (name, version string, ok bool) {
+	idx := strings.LastIndexByte(pkg, '@')
+	if idx <= 0 || strings.ContainsAny(pkg[idx+1:], ":/") {
+		return pkg, "", false
+	}
+
+	return pkg[:idx], pkg[idx+1:], true
+}
+
+// isLocalPath reports whether an operand names a local directory or file
+// rather than a registry package.
+func isLocalPath(pkg string) bool {
+	return pkg == "." || pkg == ".." || slices.ContainsFunc(npmPathPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(pkg, prefix)
+	})
+}
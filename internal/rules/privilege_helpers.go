@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// fileMode is what a mode given to chmod, install -m or mkdir -m grants.
+type fileMode struct {
+	worldWritable bool // others may write, without the sticky bit
+	setID         bool // setuid or setgid
+}
+
+// fileModeArgs returns the modes a command sets: the first operand of chmod,
+// and the -m (--mode) of install and mkdir.
+func fileModeArgs(cmd shell.Command) []string {
+	switch programName(cmd) {
+	case "chmod":
+		if modes := packageArgs(cmd, 0, "reference"); len(modes) > 0 {
+			return modes[:1]
+		}
+	case "install", "mkdir":
+		return append(shell.GetFlagArg("m", cmd), shell.GetFlagArg("mode", cmd)...)
+	}
+
+	return nil
+}
+
+// parseFileMode reads an octal (0777, 4755) or symbolic (a+w, u+s,go-w)
+// mode. Symbolic modes without a who (+w) follow the umask, which keeps
+// others from writing.
+func parseFileMode(mode string) fileMode {
+	if value, err := strconv.ParseUint(mode, 8, 32); err == nil {
+		return fileMode{
+			worldWritable: value&0o002 != 0 && value&0o1000 == 0,
+			setID:         value&0o6000 != 0,
+		}
+	}
+
+	var parsed fileMode
+
+	for clause := range strings.SplitSeq(mode, ",") {
+		actions := strings.TrimLeft(clause, "ugoa")
+		who := clause[:len(clause)-len(actions)]
+
+		for _, action := range regexp.MustCompile(`[-+=][rwxXst]*`).FindAllString(actions, -1) {
+			if action[0] == '-' {
+				continue
+			}
+
+			perms := action[1:]
+
+			if strings.Contains(perms, "w") && strings.ContainsAny(who, "oa") && !strings.Contains(perms, "t") {
+				parsed.worldWritable = true
+			}
+
+			if strings.Contains(perms, "s") {
+				parsed.setID = true
+			}
+		}
+	}
+
+	return parsed
+}
+
+// runFileModes returns the modes set by the commands of a RUN.
+func runFileModes(run *syntax.Run) []fileMode {
+	var modes []fileMode
+
+	for _, simple := range runSimpleCommands(run) {
+		for _, mode := range fileModeArgs(*simple.Command) {
+			modes = append(modes, parseFileMode(mode))
+		}
+	}
+
+	return modes
+}
+
+// stageUser is the user a stage runs as, and the line setting it: a USER,
+// or the FROM of a stage inheriting its base image's user ("").
+type stageUser struct {
+	user string
+	line int
+}
+
+// stageUsers follows the user of each stage, through FROM of an earlier
+// stage, and the environment USER expands. Methods return updated copies,
+// so it can be kept in rule state.
+type stageUsers struct {
+	current stageUser
+	alias   string
+	env     stageEnv
+	stages  map[string]stageUser
+}
+
+// from starts a stage, inheriting the user of the earlier stage it is built
+// from, if any. Images tagged nonroot or rootless (distroless) run as such.
+func (s stageUsers) from(from *syntax.From, line int) stageUsers {
+	s.stages = maps.Clone(s.stages)
+	if s.stages == nil {
+		s.stages = make(map[string]stageUser)
+	}
+
+	if s.alias != "" {
+		s.stages[s.alias] = s.current
+	}
+
+	s.alias = ""
+	if from.Image.Alias != nil {
+		s.alias = *from.Image.Alias
+	}
+
+	s.env = s.env.apply(from)
+	s.current = stageUser{line: line}
+
+	if inherited, ok := s.stages[from.Image.Image]; ok {
+		s.current = inherited
+	} else if tag := from.Image.Tag; tag != nil && (strings.Contains(*tag, "nonroot") || strings.Contains(*tag, "rootless")) {
+		s.current.user = *tag
+	}
+
+	return s
+}
+
+// apply follows FROM, USER, ARG and ENV.
+func (s stageUsers) apply(line int, instruction syntax.Instruction) stageUsers {
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		return s.from(inst, line)
+	case *syntax.User:
+		s.current = stageUser{user: s.expand(inst.User), line: line}
+	case *syntax.Arg, *syntax.Env:
+		s.env = s.env.apply(instruction)
+	}
+
+	return s
+}
+
+// started reports whether a FROM was seen.
+func (s stageUsers) started() bool {
+	return s.stages != nil
+}
+
+// expand replaces the variables of a USER with their values, when known.
+func (s stageUsers) expand(user string) string {
+	return regexp.MustCompile(`\$\{?(\w+)\}?`).ReplaceAllStringFunc(user, func(ref string) string {
+		name := strings.Trim(ref, "${}")
+		if value, ok := s.env[name]; ok {
+			return value
+		}
+
+		return ref
+	})
+}
+
+// userName returns the user part of a USER user[:group] value.
+func userName(user string) string {
+	name, _, _ := strings.Cut(user, ":")
+
+	return name
+}
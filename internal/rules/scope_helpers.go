@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"cmp"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// declaredVar is a variable declared by an ARG or ENV instruction.
+type declaredVar struct {
+	line int
+	name string
+}
+
+// variableReference matches the variables a text expands, $NAME and
+// ${NAME...}.
+var variableReference = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// referencedVars returns the names of the variables an instruction
+// references, as written: in any quoting, and in the here-documents of RUN.
+// Instructions the parser did not read (ONBUILD triggers) reference none.
+func referencedVars(instruction syntax.Instruction) map[string]bool {
+	text := instruction.Raw().Original
+	if run, ok := instruction.(*syntax.Run); ok {
+		for _, heredoc := range run.Heredocs {
+			text += "\n" + heredoc.Content
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, name := range expandedNames(text) {
+		names[name] = true
+	}
+
+	return names
+}
+
+// expandedNames returns the names of the variables a value expands.
+func expandedNames(value string) []string {
+	var names []string
+	for _, match := range variableReference.FindAllStringSubmatch(value, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+// environmentArg matches the build arguments read from the environment by
+// the tools RUN executes, or by BuildKit itself, without the Dockerfile
+// referencing them: DEBIAN_FRONTEND, the proxies, compiler and toolchain
+// settings (CGO_ENABLED, GOFLAGS, PIP_INDEX_URL, NPM_CONFIG_REGISTRY...).
+var environmentArg = regexp.MustCompile(`^(?:DEBIAN_FRONTEND|SOURCE_DATE_EPOCH|TZ|LANG|LC_[A-Z]+|` +
+	`CC|CXX|[A-Z]*FLAGS|NODE_ENV|NODE_OPTIONS|GO[A-Z0-9]+|` +
+	`(?:HTTP|HTTPS|FTP|NO|ALL)_PROXY|` +
+	`(?:BUILDKIT|CGO|GO|PIP|UV|POETRY|PYTHON|NPM_CONFIG|YARN|PNPM|CARGO|RUSTUP|MAVEN|GRADLE|COMPOSER|BUNDLE)_[A-Z0-9_]+)$`)
+
+// isEnvironmentArg reports whether a build argument may be read from the
+// environment of RUN commands, unreferenced.
+func isEnvironmentArg(name string) bool {
+	return environmentArg.MatchString(strings.ToUpper(name))
+}
+
+// sortedDeclarations returns declarations in the order of the Dockerfile.
+func sortedDeclarations(declarations map[declaredVar]bool) []declaredVar {
+	return slices.SortedFunc(maps.Keys(declarations), func(a, b declaredVar) int {
+		return cmp.Or(cmp.Compare(a.line, b.line), cmp.Compare(a.name, b.name))
+	})
+}
@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/config"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// secretToken is a recognizable credential format.
+type secretToken struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// secretDetector holds the heuristics of the secret detection rules: the
+// built-in ones, extended by config.Secrets.
+type secretDetector struct {
+	keys        []*regexp.Regexp
+	allowedKeys []*regexp.Regexp
+	tokens      []secretToken
+	files       []string
+}
+
+// builtinSecretTokens are the credential formats recognized by their shape.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var builtinSecretTokens = []struct{ name, expr string }{
+	{"AWS access key ID", `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{"GitHub token", `\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}\b`},
+	{"GitHub fine-grained token", `\bgithub_pat_[A-Za-z0-9_]{82}\b`},
+	{"GitLab token", `\bglpat-[A-Za-z0-9_-]{20}\b`},
+	{"Slack token", `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{"Slack webhook", `https://hooks\.slack\.com/services/[A-Za-z0-9/]+`},
+	{"npm token", `\bnpm_[A-Za-z0-9]{36}\b`},
+	{"Google API key", `\bAIza[0-9A-Za-z_-]{35}\b`},
+	{"Stripe secret key", `\b[rs]k_live_[0-9A-Za-z]{24,}\b`},
+	{"private key", `-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`},
+}
+
+// builtinSecretFiles are the globs of files holding credentials, see
+// config.Secrets.Files for their matching.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var builtinSecretFiles = []string{
+	"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", ".ssh",
+	"*.key", "*-key.pem", "key.pem", "privkey.pem", "*.p12", "*.pfx", "*.jks", "*.keystore",
+	".env", ".netrc", ".npmrc", ".pypirc", ".pgpass", ".git-credentials", ".htpasswd",
+	".aws/credentials", ".docker/config.json", ".kube/config", "*.tfstate",
+}
+
+// newSecretDetector builds the detector from the built-in heuristics and the
+// configured ones. Keys match BuildKit's SecretsUsedInArgOrEnv check: a word
+// of the name, between underscores, is a secret word, and none is public.
+func newSecretDetector(cfg config.Secrets) *secretDetector {
+	detector := &secretDetector{
+		keys: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)(?:_|^)(?:apikey|auth|credential|credentials|key|password|pword|passwd|secret|token)(?:_|$)`),
+		},
+		allowedKeys: []*regexp.Regexp{regexp.MustCompile(`(?i)(?:_|^)public(?:_|$)`)},
+		files:       slices.Concat(builtinSecretFiles, cfg.Files),
+	}
+
+	detector.keys = append(detector.keys, compilePatterns(cfg.Keys)...)
+	detector.allowedKeys = append(detector.allowedKeys, compilePatterns(cfg.AllowedKeys)...)
+
+	for _, token := range builtinSecretTokens {
+		detector.tokens = append(detector.tokens, secretToken{token.name, regexp.MustCompile(token.expr)})
+	}
+
+	for _, pattern := range compilePatterns(cfg.Patterns) {
+		detector.tokens = append(detector.tokens, secretToken{"value matching " + pattern.String(), pattern})
+	}
+
+	return detector
+}
+
+// compilePatterns compiles the expressions, skipping invalid ones.
+func compilePatterns(exprs []string) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+
+	for _, expr := range exprs {
+		if pattern, err := regexp.Compile(expr); err == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// isSecretKey reports whether a variable name suggests it holds a secret.
+func (d *secretDetector) isSecretKey(name string) bool {
+	matches := func(pattern *regexp.Regexp) bool { return pattern.MatchString(name) }
+
+	return slices.ContainsFunc(d.keys, matches) && !slices.ContainsFunc(d.allowedKeys, matches)
+}
+
+// findToken returns the name of the first credential format found in text.
+func (d *secretDetector) findToken(text string) (string, bool) {
+	for _, token := range d.tokens {
+		if token.pattern.MatchString(text) {
+			return token.name, true
+		}
+	}
+
+	return "", false
+}
+
+// isSecretFile reports whether a COPY or ADD source names a secret file.
+func (d *secretDetector) isSecretFile(source string) bool {
+	elements := strings.Split(path.Clean(strings.ReplaceAll(source, `\`, "/")), "/")
+
+	return slices.ContainsFunc(d.files, func(glob string) bool {
+		count := strings.Count(glob, "/") + 1
+		if count > len(elements) {
+			return false
+		}
+
+		matched, err := path.Match(glob, path.Join(elements[len(elements)-count:]...))
+
+		return err == nil && matched
+	})
+}
+
+// findHardcodedSecret returns a description of the secret a value holds: a
+// credential format, or for a secret key a high-entropy literal.
+func (d *secretDetector) findHardcodedSecret(key, value string) (string, bool) {
+	if name, ok := d.findToken(value); ok {
+		return name, true
+	}
+
+	if key != "" && d.isSecretKey(key) && isHighEntropy(value) {
+		return "high-entropy value of " + key, true
+	}
+
+	return "", false
+}
+
+// Values shorter than minSecretLength, or with fewer bits of entropy per
+// character than minSecretEntropy, are not taken for generated secrets. A
+// random hex string has 4 bits per character, base64 up to 6, words about 3.
+const (
+	minSecretLength  = 16
+	minSecretEntropy = 3.5
+)
+
+// isHighEntropy reports whether a literal value looks generated rather than
+// written: long, and of high Shannon entropy. Expansions and paths are not.
+func isHighEntropy(value string) bool {
+	if len(value) < minSecretLength || isUnresolved(value) || strings.HasPrefix(value, "/") {
+		return false
+	}
+
+	counts := make(map[rune]int)
+	for _, char := range value {
+		counts[char]++
+	}
+
+	entropy := 0.0
+	length := float64(len([]rune(value)))
+
+	for _, count := range counts {
+		frequency := float64(count) / length
+		entropy -= frequency * math.Log2(frequency)
+	}
+
+	return entropy >= minSecretEntropy
+}
+
+// runAssignments returns the variable assignments a RUN makes, inline
+// (TOKEN=... cmd), standalone or exported.
+func runAssignments(run *syntax.Run) []shell.Assignment {
+	var assignments []shell.Assignment
+
+	shell.Walk(runStatements(run), func(stmt *shell.Statement, _ shell.Context) bool {
+		if simple, ok := stmt.Node.(*shell.Simple); ok {
+			assignments = append(assignments, simple.Assignments...)
+		}
+
+		return true
+	})
+
+	return assignments
+}
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// stageEnv is the environment the RUN instructions of the current stage
+// see: its ENV, and ARG defaults. Methods return updated copies, so it can
+// be kept in rule state.
+type stageEnv map[string]string
+
+// apply follows the instructions changing the environment.
+func (e stageEnv) apply(instruction syntax.Instruction) stageEnv {
+	switch inst := instruction.(type) {
+	case *syntax.From:
+		return nil
+
+	case *syntax.Env:
+		env := maps.Clone(e)
+		if env == nil {
+			env = make(stageEnv)
+		}
+
+		for _, pair := range inst.Pairs {
+			env[pair.Key] = dropQuotes(pair.Value)
+		}
+
+		return env
+
+	case *syntax.Arg:
+		if inst.Value == nil {
+			return e
+		}
+
+		env := maps.Clone(e)
+		if env == nil {
+			env = make(stageEnv)
+		}
+
+		env[inst.ArgName] = dropQuotes(*inst.Value)
+
+		return env
+	}
+
+	return e
+}
+
+// lookup returns the value of a variable for a command: its own assignment
+// (GOCACHE=/cache go build), else the stage's. It returns "" when unset.
+func (e stageEnv) lookup(simple *shell.Simple, name string) string {
+	for _, assign := range slices.Backward(simple.Assignments) {
+		if assign.Name == name {
+			return assign.Value
+		}
+	}
+
+	return e[name]
+}
+
+// runStatements returns the structured model of a RUN's script, nil when it
+// is not a shell script or does not parse.
+func runStatements(run *syntax.Run) []*shell.Statement {
+	script, dialect, _ := shell.RunScript(run, shell.DialectSh)
+	if !dialect.IsShell() {
+		return nil
+	}
+
+	parsed, err := shell.ParseShell(script)
+	if err != nil {
+		return nil
+	}
+
+	return parsed.Statements
+}
+
+// runSimpleCommands returns the commands a RUN executes, in order, with
+// their assignments.
+func runSimpleCommands(run *syntax.Run) []*shell.Simple {
+	var commands []*shell.Simple
+
+	shell.Walk(runStatements(run), func(stmt *shell.Statement, _ shell.Context) bool {
+		if simple, ok := stmt.Node.(*shell.Simple); ok && simple.Command != nil {
+			commands = append(commands, simple)
+		}
+
+		return true
+	})
+
+	return commands
+}
+
+// subcommand returns the first argument of a command that is not a flag,
+// such as build in `go build -o app .`.
+func subcommand(cmd shell.Command) string {
+	if args := shell.GetArgsNoFlags(cmd); len(args) > 0 {
+		return args[0]
+	}
+
+	return ""
+}
+
+// programName returns the name of a command's program, without directory:
+// mvnw for ./mvnw.
+func programName(cmd shell.Command) string {
+	return path.Base(cmd.Name)
+}
+
+// isGoBuild reports whether a command builds Go binaries: go build or go
+// install.
+func isGoBuild(cmd shell.Command) bool {
+	sub := subcommand(cmd)
+
+	return programName(cmd) == "go" && (sub == "build" || sub == "install")
+}
+
+// isUnresolved reports whether a value holds expansions that cannot be
+// resolved statically.
+func isUnresolved(value string) bool {
+	return strings.Contains(value, "$")
+}
@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// powershellScript returns the PowerShell a RUN executes, along with the
+// SHELL command line that runs before it: the command of a RUN in a
+// PowerShell stage, or the arguments of a RUN starting powershell or pwsh
+// itself. ok is false for any other RUN.
+func powershellScript(run *syntax.Run, stages shell.StageDialects) (script, preamble string, ok bool) {
+	if stages.Dialect() == shell.DialectPowerShell {
+		return run.Command, stages.Interpreter(), true
+	}
+
+	program, rest, _ := strings.Cut(strings.TrimSpace(run.Command), " ")
+	if shell.InterpreterDialect(program) == shell.DialectPowerShell {
+		return rest, "", true
+	}
+
+	return "", "", false
+}
+
+// checkPowerShellPreference follows the stage dialect and reports the
+// PowerShell RUN instructions where neither the SHELL nor the script sets a
+// preference variable as pattern describes.
+func checkPowerShellPreference(
+	meta rule.Meta,
+	pattern *regexp.Regexp,
+	line int,
+	state rule.State,
+	instruction syntax.Instruction,
+) rule.State {
+	stages := rule.Data[shell.StageDialects](state)
+
+	switch inst := instruction.(type) {
+	case *syntax.Directive:
+		return state.ReplaceData(stages.Directive(inst))
+
+	case *syntax.From:
+		return state.ReplaceData(stages.From(inst))
+
+	case *syntax.Shell:
+		return state.ReplaceData(stages.Shell(inst))
+
+	case *syntax.Run:
+		script, preamble, ok := powershellScript(inst, stages)
+		if !ok || pattern.MatchString(preamble) || pattern.MatchString(script) {
+			return state
+		}
+
+		return state.AddFailure(rule.CheckFailure{
+			Code:     meta.Code,
+			Severity: meta.Severity,
+			Message:  meta.Message,
+			Line:     line,
+			Column:   1,
+		})
+	}
+
+	return state
+}
+
+// windowsCommands splits a cmd or PowerShell command line into its commands,
+// on the ; & | and newline separators and the parentheses and braces of
+// blocks and conditions, and each command into words with their quotes
+// removed. It does not know either language's quoting, which the package
+// managers it serves do not need.
+func windowsCommands(script string) [][]string {
+	var commands [][]string
+
+	for part := range strings.FieldsFuncSeq(script, func(r rune) bool {
+		return strings.ContainsRune(";&|\n(){}", r)
+	}) {
+		words := strings.Fields(part)
+		for i, word := range words {
+			words[i] = strings.Trim(word, `"'`)
+		}
+
+		if len(words) > 0 {
+			commands = append(commands, words)
+		}
+	}
+
+	return commands
+}
+
+// windowsScriptCommands returns the commands a cmd or PowerShell script
+// runs, each as its program followed by its arguments, with those of
+// `powershell -Command` in place of it. Commands are found as shell.Walk
+// finds those of a POSIX script, which simple cmd and PowerShell command
+// lines read as; windowsCommands splits the scripts it cannot parse.
+func windowsScriptCommands(script string) [][]string {
+	parsed, err := shell.ParseShell(script)
+	if err != nil {
+		return windowsCommands(script)
+	}
+
+	var commands [][]string
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, _ shell.Context) bool {
+		simple, ok := stmt.Node.(*shell.Simple)
+		if !ok || simple.Command == nil {
+			return true
+		}
+
+		words := append([]string{simple.Command.Name}, shell.GetArgs(*simple.Command)...)
+		if shell.InterpreterDialect(words[0]) == shell.DialectPowerShell {
+			commands = append(commands, windowsScriptCommands(strings.Join(powershellCommand(words[1:]), " "))...)
+
+			return true
+		}
+
+		commands = append(commands, words)
+
+		return true
+	})
+
+	return commands
+}
+
+// powershellCommand returns the words of the command powershell or pwsh
+// runs, those after -Command or -c, or none.
+func powershellCommand(args []string) []string {
+	for i, arg := range args {
+		if strings.EqualFold(arg, "-Command") || strings.EqualFold(arg, "-c") {
+			return args[i+1:]
+		}
+	}
+
+	return nil
+}
+
+// chocoValueOptions are the choco install options taking their value as the
+// next word, which is then not a package name.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var chocoValueOptions = []string{
+	"-s", "--source", "--params", "--package-parameters", "--ia", "--install-arguments",
+	"-u", "--user", "-p", "--password", "--cache-location", "--execution-timeout",
+}
+
+// chocoUnpinnedPackages returns the packages a `choco install` command
+// installs without a version. Package lists (packages.config) and local
+// .nupkg files pin their own versions.
+func chocoUnpinnedPackages(words []string) []string {
+	args, ok := chocoInstallArgs(words)
+	if !ok {
+		return nil
+	}
+
+	var packages []string
+
+	for i := 0; i < len(args); i++ {
+		arg := strings.ToLower(args[i])
+
+		switch {
+		case arg == "--version" || arg == "-version" || strings.HasPrefix(arg, "--version="):
+			return nil
+		case slices.Contains(chocoValueOptions, arg):
+			i++
+		case strings.HasPrefix(arg, "-"):
+		case strings.HasSuffix(arg, ".config") || strings.HasSuffix(arg, ".nupkg"):
+		default:
+			packages = append(packages, args[i])
+		}
+	}
+
+	return packages
+}
+
+// chocoInstallArgs returns the arguments of a `choco install` or `cinst`
+// command, its program being the first word.
+func chocoInstallArgs(words []string) ([]string, bool) {
+	if len(words) == 0 {
+		return nil, false
+	}
+
+	switch windowsProgram(words[0]) {
+	case "cinst":
+		return words[1:], true
+	case "choco":
+		if len(words) > 1 && strings.EqualFold(words[1], "install") {
+			return words[2:], true
+		}
+	}
+
+	return nil, false
+}
+
+// windowsProgram returns the lowercase name of a program word, without its
+// directory and .exe extension.
+func windowsProgram(word string) string {
+	name := strings.ToLower(word)
+	name = name[strings.LastIndexAny(name, `/\`)+1:]
+
+	return strings.TrimSuffix(name, ".exe")
+}
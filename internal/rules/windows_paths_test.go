@@ -0,0 +1,82 @@
+package rules_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/rules"
+	"github.com/farcloser/godolint/internal/testutils"
+)
+
+// Windows path cases for DL3000 and DL3045, which hadolint's suite (the
+// generated tests) does not cover.
+func TestWindowsPaths(t *testing.T) {
+	t.Parallel()
+
+	allRules := []rule.Rule{
+		rules.DL3000(),
+		rules.DL3045(),
+	}
+
+	tests := []struct {
+		name       string
+		dockerfile string
+		want       []rule.Code
+	}{
+		{
+			name: "rooted paths on a Windows image",
+			dockerfile: `FROM mcr.microsoft.com/windows/nanoserver:ltsc2022
+COPY app.exe \app\
+WORKDIR \app`,
+		},
+		{
+			name: "UNC and forward slash drive paths",
+			dockerfile: `FROM mcr.microsoft.com/windows/servercore:ltsc2022
+COPY app.exe C:/app/
+WORKDIR \\server\share`,
+		},
+		{
+			name: "rooted paths under escape=` and an unknown image",
+			dockerfile: "# escape=`\n" + `FROM registry.example.com/base:1.0
+COPY app.exe \app\
+WORKDIR \app`,
+		},
+		{
+			name: "rooted paths under a Windows PowerShell SHELL",
+			dockerfile: `FROM registry.example.com/base:1.0
+SHELL ["powershell", "-Command"]
+COPY app.exe \app\
+WORKDIR \app`,
+		},
+		{
+			name: "relative Windows paths",
+			dockerfile: "# escape=`\n" + `FROM mcr.microsoft.com/windows/nanoserver:ltsc2022
+COPY app.exe app\
+WORKDIR app`,
+			want: []rule.Code{"DL3045", "DL3000"},
+		},
+		{
+			name: "backslash paths on Linux",
+			dockerfile: `FROM debian:bookworm
+COPY app \app
+WORKDIR \app`,
+			want: []rule.Code{"DL3045", "DL3000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := testutils.LintDockerfile(tt.dockerfile, allRules)
+			for _, code := range []rule.Code{"DL3000", "DL3045"} {
+				if slices.Contains(tt.want, code) {
+					testutils.AssertContainsViolation(t, violations, string(code))
+				} else {
+					testutils.AssertNoViolation(t, violations, string(code))
+				}
+			}
+		})
+	}
+}
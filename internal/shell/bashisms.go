@@ -0,0 +1,197 @@
+// This file finds bash features in scripts run by a POSIX sh. The package
+// godoc lives in parser.go.
+
+package shell
+
+import (
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Bashism is a bash feature used in a script.
+type Bashism struct {
+	// Feature names the construct, e.g. "[[ ]]".
+	Feature string
+	// Alternative is the portable way to write it.
+	Alternative string
+	// Line is the 0-based line offset within the script.
+	Line int
+}
+
+// bashism describes a feature, and whether busybox ash implements it (its
+// bash compatibility options are enabled in alpine's build).
+type bashism struct {
+	feature     string
+	alternative string
+	ash         bool
+}
+
+// Bash features missing from dash, and maybe from ash.
+//
+//nolint:gochecknoglobals // read-only descriptions.
+var (
+	bashTest        = bashism{"[[ ]]", "[ ] or test", true}
+	bashArithmetic  = bashism{"(( ))", "[ $((expr)) -ne 0 ]", false}
+	bashArray       = bashism{"arrays", "positional parameters or separate variables", false}
+	bashFunction    = bashism{"the function keyword", "name() { ...; }", true}
+	bashRedirAll    = bashism{"&> redirection", "> file 2>&1", true}
+	bashHereString  = bashism{"<<< here-strings", "printf '%s\\n' ... | command", false}
+	bashProcSubst   = bashism{"process substitution", "a temporary file or a pipe", false}
+	bashDollarQuote = bashism{"$'...' strings", "printf", true}
+	bashSubstring   = bashism{"${var:offset:length}", "cut or expr", true}
+	bashReplace     = bashism{"${var/pattern/replacement}", "sed", true}
+	bashIndirect    = bashism{"${!var} indirection", "eval", false}
+	bashCaseModify  = bashism{"${var^^} case modification", "tr", false}
+	bashSource      = bashism{"source", ".", true}
+	bashDeclare     = bashism{"declare and typeset", "plain assignments, or local", false}
+	bashTestEquals  = bashism{"== in [ ]", "=", true}
+	bashEchoEscapes = bashism{"echo -e", "printf", true}
+	bashSelect      = bashism{"select loops", "a while read loop", false}
+	bashCoprocess   = bashism{"coproc", "a background job and a fifo", false}
+	bashLetArith    = bashism{"let", "$((expr))", true}
+	bashPipestatus  = bashism{"$PIPESTATUS", "separate commands", false}
+)
+
+// Bashisms returns the bash features a script uses that the dialect lacks.
+// It returns nothing unless the dialect is a POSIX sh; busybox ash is
+// spared the features it implements. Scripts that do not parse even as bash
+// yield an error.
+func Bashisms(script string, dialect Dialect) ([]Bashism, error) {
+	if !dialect.IsPOSIX() {
+		return nil, nil
+	}
+
+	file, err := parseDialect(script, DialectBash)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Bashism
+
+	add := func(kind bashism, pos syntax.Pos) {
+		if dialect == DialectAsh && kind.ash {
+			return
+		}
+
+		use := Bashism{Feature: kind.feature, Alternative: kind.alternative, Line: int(pos.Line()) - 1}
+		if !slices.Contains(found, use) {
+			found = append(found, use)
+		}
+	}
+
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch node := node.(type) {
+		case *syntax.TestClause:
+			add(bashTest, node.Pos())
+		case *syntax.ArithmCmd:
+			add(bashArithmetic, node.Pos())
+		case *syntax.Assign:
+			if node.Array != nil || node.Index != nil || node.Append {
+				add(bashArray, node.Pos())
+			}
+		case *syntax.FuncDecl:
+			if node.RsrvWord {
+				add(bashFunction, node.Pos())
+			}
+		case *syntax.Redirect:
+			checkRedirect(node, add)
+		case *syntax.ProcSubst:
+			add(bashProcSubst, node.Pos())
+		case *syntax.SglQuoted:
+			if node.Dollar {
+				add(bashDollarQuote, node.Pos())
+			}
+		case *syntax.ParamExp:
+			checkParamExp(node, add)
+		case *syntax.DeclClause:
+			if node.Variant.Value == "declare" || node.Variant.Value == "typeset" {
+				add(bashDeclare, node.Pos())
+			}
+		case *syntax.LetClause:
+			add(bashLetArith, node.Pos())
+		case *syntax.ForClause:
+			if node.Select {
+				add(bashSelect, node.Pos())
+			}
+		case *syntax.CoprocClause:
+			add(bashCoprocess, node.Pos())
+		case *syntax.CallExpr:
+			checkBashismCall(node, add)
+		}
+
+		return true
+	})
+
+	return found, nil
+}
+
+func checkRedirect(redirect *syntax.Redirect, add func(bashism, syntax.Pos)) {
+	//nolint:exhaustive // only the bash-only operators matter.
+	switch redirect.Op {
+	case syntax.RdrAll, syntax.AppAll:
+		add(bashRedirAll, redirect.Pos())
+	case syntax.WordHdoc:
+		add(bashHereString, redirect.Pos())
+	}
+}
+
+func checkParamExp(param *syntax.ParamExp, add func(bashism, syntax.Pos)) {
+	switch {
+	case param.Slice != nil:
+		add(bashSubstring, param.Pos())
+	case param.Repl != nil:
+		add(bashReplace, param.Pos())
+	case param.Excl:
+		add(bashIndirect, param.Pos())
+	case param.Index != nil:
+		add(bashArray, param.Pos())
+	case param.Exp != nil && isCaseModification(param.Exp.Op):
+		add(bashCaseModify, param.Pos())
+	case param.Param != nil && param.Param.Value == "PIPESTATUS":
+		add(bashPipestatus, param.Pos())
+	}
+}
+
+func isCaseModification(op syntax.ParExpOperator) bool {
+	//nolint:exhaustive // every other operator is POSIX.
+	switch op {
+	case syntax.UpperFirst, syntax.UpperAll, syntax.LowerFirst, syntax.LowerAll:
+		return true
+	default:
+		return false
+	}
+}
+
+func checkBashismCall(call *syntax.CallExpr, add func(bashism, syntax.Pos)) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	args := make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = arg.Lit()
+	}
+
+	switch args[0] {
+	case "source":
+		add(bashSource, call.Pos())
+	case "[", "test":
+		if slices.Contains(args[1:], "==") {
+			add(bashTestEquals, call.Pos())
+		}
+	case "echo":
+		if len(args) > 1 && isEchoEscapeFlag(args[1]) {
+			add(bashEchoEscapes, call.Pos())
+		}
+	}
+}
+
+// isEchoEscapeFlag reports whether arg is an echo option enabling escapes,
+// such as -e or -ne. dash's echo prints it, and always interprets escapes.
+func isEchoEscapeFlag(arg string) bool {
+	flags, ok := strings.CutPrefix(arg, "-")
+
+	return ok && flags != "" && strings.Trim(flags, "neE") == "" && strings.Contains(flags, "e")
+}
@@ -0,0 +1,136 @@
+// This file provides the batching shellchecker, which checks all the RUN
+// instructions of a Dockerfile with a single shellcheck process. The package
+// godoc lives in parser.go.
+
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+// Script is one RUN instruction's command, with the shell options of its
+// stage.
+type Script struct {
+	Text string
+	Opts Opts
+}
+
+// BatchShellchecker checks many scripts at once. ShellcheckRule defers the
+// RUN instructions of a Dockerfile to a single CheckBatch call when its
+// checker implements it.
+type BatchShellchecker interface {
+	Shellchecker
+	// CheckBatch returns the failures of each script, in order, with the
+	// same line offsets as Shellchecker.Check.
+	CheckBatch(scripts []Script) ([][]rule.CheckFailure, error)
+}
+
+// BatchingShellchecker runs shellcheck once per batch of scripts, instead of
+// once per script. Its worker pool bounds the concurrent shellcheck processes
+// across every Dockerfile linted with it.
+type BatchingShellchecker struct {
+	binary *BinaryShellchecker
+	pool   chan struct{}
+}
+
+// NewBatchingShellchecker creates a batching checker running the binary
+// checker's shellcheck, with at most workers processes at a time.
+func NewBatchingShellchecker(binary *BinaryShellchecker, workers int) *BatchingShellchecker {
+	return &BatchingShellchecker{
+		binary: binary,
+		pool:   make(chan struct{}, max(workers, 1)),
+	}
+}
+
+// Check checks a single script, as a batch of one.
+func (c *BatchingShellchecker) Check(script string, opts Opts) ([]rule.CheckFailure, error) {
+	results, err := c.CheckBatch([]Script{{Text: script, Opts: opts}})
+	if err != nil {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+// CheckBatch writes each script to its own file in a temp dir, runs one
+// shellcheck process over all of them, and maps the findings back by file
+// name.
+func (c *BatchingShellchecker) CheckBatch(scripts []Script) ([][]rule.CheckFailure, error) {
+	results := make([][]rule.CheckFailure, len(scripts))
+
+	if err := c.binary.checkOptions(); err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "shellcheck-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var files []string
+
+	index := make(map[string]int)
+	shells := make(map[string]bool)
+
+	for i, script := range scripts {
+		shell := script.Opts.dialect().shellcheckShell(c.binary.Version)
+		if skipScript(script.Text, script.Opts) || shell == "" {
+			continue
+		}
+
+		name := filepath.Join(dir, "run-"+strconv.Itoa(i)+".sh")
+		if err := os.WriteFile(name, []byte(buildScript(script.Text, script.Opts, shell)), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write script: %w", err)
+		}
+
+		files = append(files, name)
+		index[name] = i
+		shells[shell] = true
+	}
+
+	if len(files) == 0 {
+		return results, nil
+	}
+
+	// A batch in a single dialect is checked with --shell; a mixed one
+	// relies on each file's shebang.
+	shell := ""
+	if len(shells) == 1 {
+		for name := range shells {
+			shell = name
+		}
+	}
+
+	c.pool <- struct{}{}
+	scResults, err := c.binary.run(shell, files...)
+	<-c.pool
+
+	if err != nil {
+		return nil, err
+	}
+
+	byScript := make([][]shellcheckOutput, len(scripts))
+
+	for _, finding := range scResults {
+		i, ok := index[finding.File]
+		if !ok {
+			// shellcheck echoes the paths it was given; anything else is
+			// not ours to anchor.
+			continue
+		}
+
+		byScript[i] = append(byScript[i], finding)
+	}
+
+	for i, findings := range byScript {
+		results[i] = toFailures(findings, scripts[i].Opts)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,207 @@
+package shell_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// fakeShellcheck reports SC2164 on the first `cd` line of each script, and
+// logs one line per invocation.
+const fakeShellcheck = `#!/bin/sh
+echo run >> "$FAKE_SHELLCHECK_LOG"
+printf '['
+sep=''
+for f in "$@"; do
+  case "$f" in *.sh) ;; *) continue ;; esac
+  n=$(grep -n 'cd ' "$f" | head -n 1 | cut -d: -f1)
+  if [ -n "$n" ]; then
+    printf '%s{"file":"%s","line":%s,"endLine":%s,"column":3,"level":"warning","code":2164,"message":"cd"}' "$sep" "$f" "$n" "$n"
+    sep=','
+  fi
+done
+printf ']'
+exit 1
+`
+
+// Not parallel: it swaps PATH for a fake shellcheck.
+func TestBatchingShellchecker_CheckBatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "shellcheck"), []byte(fakeShellcheck), 0o700); err != nil {
+		t.Fatalf("writing fake shellcheck: %v", err)
+	}
+
+	log := filepath.Join(dir, "invocations")
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_SHELLCHECK_LOG", log)
+
+	withEnv := shell.DefaultOpts()
+	pwsh := shell.Opts{ShellName: "pwsh -c", EnvVars: map[string]string{}}
+
+	results, err := shell.NewBatchingShellchecker(shell.NewBinaryShellchecker(), 2).CheckBatch([]shell.Script{
+		{Text: "true", Opts: shell.Opts{ShellName: "/bin/sh -c", EnvVars: map[string]string{}}},
+		{Text: "true\n  cd /app", Opts: withEnv},
+		{Text: "cd /app", Opts: pwsh},
+		{Text: "cd /app", Opts: shell.Opts{ShellName: "/bin/bash -c", EnvVars: map[string]string{}}},
+	})
+	if err != nil {
+		t.Fatalf("CheckBatch() error = %v", err)
+	}
+
+	if len(results) != 4 || len(results[0]) != 0 || len(results[2]) != 0 {
+		t.Fatalf("CheckBatch() = %v, want findings for the second and last scripts only", results)
+	}
+
+	// Offsets are relative to each script, past its own header.
+	if got := results[1]; len(got) != 1 || got[0].Line != 1 || got[0].Column != 3 {
+		t.Errorf("second script failures = %+v, want SC2164 at offset 1, column 3", got)
+	}
+
+	if got := results[3]; len(got) != 1 || got[0].Code != "SC2164" || got[0].Line != 0 {
+		t.Errorf("last script failures = %+v, want SC2164 at offset 0", got)
+	}
+
+	invocations, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatalf("reading invocation log: %v", err)
+	}
+
+	if n := strings.Count(string(invocations), "run"); n != 1 {
+		t.Errorf("shellcheck ran %d times, want 1", n)
+	}
+}
+
+// recordingBatcher answers every script with one failure on its first line.
+type recordingBatcher struct {
+	batches [][]shell.Script
+}
+
+func (b *recordingBatcher) Check(string, shell.Opts) ([]rule.CheckFailure, error) {
+	panic("Check called on a batching checker")
+}
+
+func (b *recordingBatcher) CheckBatch(scripts []shell.Script) ([][]rule.CheckFailure, error) {
+	b.batches = append(b.batches, scripts)
+
+	results := make([][]rule.CheckFailure, len(scripts))
+	for i := range scripts {
+		results[i] = []rule.CheckFailure{{Code: "SC2086", Severity: rule.Info, Line: 0, Column: 1}}
+	}
+
+	return results, nil
+}
+
+func TestShellcheckRule_Batching(t *testing.T) {
+	t.Parallel()
+
+	batcher := &recordingBatcher{}
+	scRule := shell.NewShellcheckRule(batcher)
+
+	state := scRule.InitialState()
+	state = scRule.Check(1, state, &syntax.From{Image: syntax.BaseImage{Image: "debian"}})
+	state = scRule.Check(2, state, &syntax.Env{Pairs: []syntax.EnvPair{{Key: "STAGE1", Value: "1"}}})
+	state = scRule.Check(3, state, &syntax.Run{Command: "echo one"})
+	state = scRule.Check(4, state, &syntax.From{Image: syntax.BaseImage{Image: "alpine"}})
+	state = scRule.Check(5, state, &syntax.Run{Command: "echo two"})
+	state = scRule.Finalize(state)
+
+	if len(batcher.batches) != 1 || len(batcher.batches[0]) != 2 {
+		t.Fatalf("batches = %v, want a single batch of both RUNs", batcher.batches)
+	}
+
+	if _, ok := batcher.batches[0][0].Opts.EnvVars["STAGE1"]; !ok {
+		t.Error("first RUN checked without its stage's ENV")
+	}
+
+	if _, ok := batcher.batches[0][1].Opts.EnvVars["STAGE1"]; ok {
+		t.Error("second stage RUN checked with the first stage's ENV")
+	}
+
+	if len(state.Failures) != 2 || state.Failures[0].Line != 3 || state.Failures[1].Line != 5 {
+		t.Errorf("failures = %+v, want one anchored on each RUN line", state.Failures)
+	}
+}
+
+var errBroken = errors.New("shellcheck crashed")
+
+// brokenChecker fails every check, batched or not.
+type brokenChecker struct{}
+
+func (brokenChecker) Check(string, shell.Opts) ([]rule.CheckFailure, error) {
+	return nil, errBroken
+}
+
+type brokenBatcher struct{ brokenChecker }
+
+func (brokenBatcher) CheckBatch([]shell.Script) ([][]rule.CheckFailure, error) {
+	return nil, errBroken
+}
+
+func TestShellcheckRule_Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, checker := range map[string]shell.Shellchecker{
+		"per RUN": brokenChecker{},
+		"batched": brokenBatcher{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scRule := shell.NewShellcheckRule(checker)
+
+			state := scRule.InitialState()
+			state = scRule.Check(1, state, &syntax.From{Image: syntax.BaseImage{Image: "debian"}})
+			state = scRule.Check(2, state, &syntax.Run{Command: "echo one"})
+			state = scRule.Check(3, state, &syntax.Run{Command: "echo two"})
+			state = scRule.Finalize(state)
+
+			if len(state.Errors) != 2 || state.Errors[0].Line != 2 || state.Errors[1].Line != 3 {
+				t.Fatalf("errors = %+v, want one on each RUN line", state.Errors)
+			}
+
+			if !errors.Is(state.Errors[0], errBroken) {
+				t.Errorf("error = %v, want the checker's", state.Errors[0])
+			}
+		})
+	}
+}
+
+func TestShellcheckRule_Dialects(t *testing.T) {
+	t.Parallel()
+
+	batcher := &recordingBatcher{}
+	scRule := shell.NewShellcheckRule(batcher)
+	tag := "3.20"
+
+	state := scRule.InitialState()
+	state = scRule.Check(1, state, &syntax.From{Image: syntax.BaseImage{Image: "alpine", Tag: &tag}})
+	state = scRule.Check(2, state, &syntax.Run{Command: "echo one"})
+	state = scRule.Check(3, state, &syntax.Run{
+		Command:  "<<EOF",
+		Heredocs: []syntax.Heredoc{{Name: "EOF", Content: "#!/bin/bash\necho two\n"}},
+	})
+	state = scRule.Check(6, state, &syntax.From{Image: syntax.BaseImage{Image: "debian"}})
+	state = scRule.Check(7, state, &syntax.Run{Command: "echo three"})
+	state = scRule.Finalize(state)
+
+	if len(batcher.batches) != 1 || len(batcher.batches[0]) != 3 {
+		t.Fatalf("batches = %v, want a single batch of the three RUNs", batcher.batches)
+	}
+
+	for i, want := range []shell.Dialect{shell.DialectAsh, shell.DialectBash, shell.DialectDash} {
+		if got := batcher.batches[0][i].Opts.Dialect; got != want {
+			t.Errorf("RUN %d checked as %q, want %q", i+1, got, want)
+		}
+	}
+
+	// The heredoc body starts on the line after its RUN.
+	if len(state.Failures) != 3 || state.Failures[1].Line != 4 {
+		t.Errorf("failures = %+v, want the heredoc's anchored on line 4", state.Failures)
+	}
+}
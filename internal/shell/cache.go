@@ -0,0 +1,178 @@
+// This file provides the caching shellchecker. The package godoc lives in
+// parser.go.
+
+package shell
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+// Identifier is implemented by shellcheckers whose results depend on more
+// than the script and its options: the shellcheck version, the rcfile...
+// Identity must change whenever those do.
+type Identifier interface {
+	Identity() string
+}
+
+// Identity returns the identity of a shellchecker: its Identity when it
+// implements Identifier, its type otherwise.
+func Identity(checker Shellchecker) string {
+	if identifier, ok := checker.(Identifier); ok {
+		return identifier.Identity()
+	}
+
+	return fmt.Sprintf("%T", checker)
+}
+
+// Identity covers the shellcheck version, the settings and the rcfile
+// content. The version is detected unless known already.
+func (c *BinaryShellchecker) Identity() string {
+	version := c.Version
+	if version.IsZero() {
+		version, _ = DetectVersion()
+	}
+
+	identity := "shellcheck " + version.String() + "\n" + c.Settings.String()
+
+	if c.RCFile != "" {
+		rcfile, err := os.ReadFile(c.RCFile)
+		if err != nil {
+			rcfile = []byte("unreadable " + c.RCFile)
+		}
+
+		sum := sha256.Sum256(rcfile)
+		identity += "\nrcfile " + hex.EncodeToString(sum[:])
+	}
+
+	return identity
+}
+
+// Identity is the wrapped binary checker's.
+func (c *BatchingShellchecker) Identity() string {
+	return c.binary.Identity()
+}
+
+// Identity covers the settings: changes to the built-in checks come with a
+// new build, which cache.BuildID covers.
+func (c *NativeShellchecker) Identity() string {
+	return "native\n" + c.settings.String()
+}
+
+// CachingShellchecker serves the results of a shellchecker from a cache,
+// keyed on the script, its options and the checker's identity.
+type CachingShellchecker struct {
+	checker  Shellchecker
+	store    cache.Cache
+	identity string
+}
+
+// NewCachingShellchecker wraps checker with the store. The checker's
+// identity is computed once, here.
+func NewCachingShellchecker(checker Shellchecker, store cache.Cache) *CachingShellchecker {
+	return &CachingShellchecker{
+		checker:  checker,
+		store:    store,
+		identity: Identity(checker),
+	}
+}
+
+// Identity is the wrapped checker's.
+func (c *CachingShellchecker) Identity() string {
+	return c.identity
+}
+
+func (c *CachingShellchecker) key(script Script) string {
+	var env strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(script.Opts.EnvVars)) {
+		_, _ = env.WriteString(name + "=" + script.Opts.EnvVars[name] + "\n")
+	}
+
+	return cache.Key("shellcheck", cache.BuildID(), c.identity,
+		script.Opts.ShellName, string(script.Opts.dialect()), env.String(), script.Text)
+}
+
+// Check checks a single script, as a batch of one.
+func (c *CachingShellchecker) Check(script string, opts Opts) ([]rule.CheckFailure, error) {
+	results, err := c.CheckBatch([]Script{{Text: script, Opts: opts}})
+	if err != nil {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+// CheckBatch serves cached scripts, and checks the others with the wrapped
+// checker: in one batch when it is a BatchShellchecker, one by one
+// otherwise.
+func (c *CachingShellchecker) CheckBatch(scripts []Script) ([][]rule.CheckFailure, error) {
+	results := make([][]rule.CheckFailure, len(scripts))
+	keys := make([]string, len(scripts))
+
+	var (
+		missed    []Script
+		missedIdx []int
+	)
+
+	for i, script := range scripts {
+		keys[i] = c.key(script)
+
+		if cached, ok := c.store.Get(keys[i]); ok && json.Unmarshal(cached, &results[i]) == nil {
+			continue
+		}
+
+		missed = append(missed, script)
+		missedIdx = append(missedIdx, i)
+	}
+
+	if len(missed) == 0 {
+		return results, nil
+	}
+
+	checked, err := c.check(missed)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, failures := range checked {
+		i := missedIdx[j]
+		results[i] = failures
+
+		if encoded, err := json.Marshal(failures); err == nil {
+			// Best effort: a failed write only costs a later recheck.
+			_ = c.store.Put(keys[i], encoded)
+		}
+	}
+
+	return results, nil
+}
+
+func (c *CachingShellchecker) check(scripts []Script) ([][]rule.CheckFailure, error) {
+	if batcher, ok := c.checker.(BatchShellchecker); ok {
+		//nolint:wrapcheck // the wrapped checker's errors are already descriptive.
+		return batcher.CheckBatch(scripts)
+	}
+
+	results := make([][]rule.CheckFailure, len(scripts))
+
+	for i, script := range scripts {
+		failures, err := c.checker.Check(script.Text, script.Opts)
+		if err != nil {
+			//nolint:wrapcheck // the wrapped checker's errors are already descriptive.
+			return nil, err
+		}
+
+		results[i] = failures
+	}
+
+	return results, nil
+}
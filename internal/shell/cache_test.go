@@ -0,0 +1,52 @@
+package shell_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+// countingChecker reports SC2086 on every script, counting the scripts it
+// was asked to check.
+type countingChecker struct {
+	checked int
+}
+
+func (c *countingChecker) Check(string, shell.Opts) ([]rule.CheckFailure, error) {
+	c.checked++
+
+	return []rule.CheckFailure{{Code: "SC2086", Severity: rule.Info, Line: 0, Column: 1}}, nil
+}
+
+func TestCachingShellchecker(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingChecker{}
+	checker := shell.NewCachingShellchecker(inner, cache.NewDir(t.TempDir()))
+	opts := shell.DefaultOpts()
+
+	scripts := []shell.Script{{Text: "echo $A", Opts: opts}, {Text: "echo $B", Opts: opts}}
+
+	if _, err := checker.CheckBatch(scripts); err != nil {
+		t.Fatalf("CheckBatch() error = %v", err)
+	}
+
+	otherShell := shell.Opts{ShellName: "/bin/bash -c", EnvVars: opts.EnvVars}
+
+	results, err := checker.CheckBatch(append(scripts, shell.Script{Text: "echo $A", Opts: otherShell}))
+	if err != nil {
+		t.Fatalf("CheckBatch() error = %v", err)
+	}
+
+	if inner.checked != 3 {
+		t.Errorf("inner checker saw %d scripts, want 3 (cached ones served, other options checked)", inner.checked)
+	}
+
+	for i, failures := range results {
+		if len(failures) != 1 || failures[0].Code != "SC2086" || failures[0].Severity != rule.Info {
+			t.Errorf("results[%d] = %+v, want the SC2086 failure", i, failures)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// This file infers the dialect RUN scripts are written in. The package godoc
+// lives in parser.go.
+
+package shell
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Dialect is the shell language a script runs in.
+type Dialect string
+
+// Dialects. DialectSh is a POSIX /bin/sh of unknown implementation; the
+// empty Dialect is unknown, and derived from Opts.ShellName.
+const (
+	DialectSh         Dialect = "sh"
+	DialectBash       Dialect = "bash"
+	DialectDash       Dialect = "dash"
+	DialectAsh        Dialect = "ash" // busybox ash, alpine's /bin/sh
+	DialectKsh        Dialect = "ksh"
+	DialectZsh        Dialect = "zsh"
+	DialectPowerShell Dialect = "powershell"
+	DialectCmd        Dialect = "cmd"
+	// DialectOther is an interpreter that is not a shell (python, node...).
+	DialectOther Dialect = "other"
+)
+
+// BusyboxVersion introduced --shell=busybox.
+//
+//nolint:gochecknoglobals // read-only version constant, see RCFileVersion.
+var BusyboxVersion = Version{Major: 0, Minor: 10, Patch: 0}
+
+// interpreters maps the executable names of interpreters to their dialect.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var interpreters = map[string]Dialect{
+	"sh":             DialectSh,
+	"bash":           DialectBash,
+	"dash":           DialectDash,
+	"ash":            DialectAsh,
+	"busybox":        DialectAsh,
+	"ksh":            DialectKsh,
+	"ksh93":          DialectKsh,
+	"mksh":           DialectKsh,
+	"pdksh":          DialectKsh,
+	"zsh":            DialectZsh,
+	"pwsh":           DialectPowerShell,
+	"pwsh.exe":       DialectPowerShell,
+	"powershell":     DialectPowerShell,
+	"powershell.exe": DialectPowerShell,
+	"cmd":            DialectCmd,
+	"cmd.exe":        DialectCmd,
+}
+
+// InterpreterDialect returns the dialect of an interpreter command line,
+// such as a SHELL instruction's ("/bin/bash -o pipefail -c") or a shebang's
+// ("/usr/bin/env bash"). Unknown interpreters are DialectOther.
+func InterpreterDialect(command string) Dialect {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	name := path.Base(strings.ReplaceAll(fields[0], `\`, "/"))
+	if name == "env" {
+		// Skip env's own flags and assignments to reach the interpreter.
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				return InterpreterDialect(field)
+			}
+		}
+
+		return DialectOther
+	}
+
+	if dialect, ok := interpreters[strings.ToLower(name)]; ok {
+		return dialect
+	}
+
+	return DialectOther
+}
+
+// ScriptDialect returns the dialect named by the script's shebang, if any.
+func ScriptDialect(script string) (Dialect, bool) {
+	if !strings.HasPrefix(script, "#!") {
+		return "", false
+	}
+
+	line, _, _ := strings.Cut(script[2:], "\n")
+
+	return InterpreterDialect(line), true
+}
+
+// Distributions whose /bin/sh is known, by image name.
+//
+//nolint:gochecknoglobals // read-only lookup tables.
+var (
+	ashImages  = []string{"alpine", "busybox"}
+	dashImages = []string{
+		"debian", "ubuntu",
+		// Official images built on Debian or Ubuntu unless their tag says
+		// otherwise.
+		"buildpack-deps", "eclipse-temurin", "gcc", "golang", "httpd", "nginx", "node",
+		"perl", "php", "postgres", "python", "redis", "ruby", "rust",
+	}
+	bashImages = []string{
+		"almalinux", "amazonlinux", "archlinux", "centos", "fedora", "mageia",
+		"oraclelinux", "photon", "rockylinux", "ubi", "ubi-minimal", "ubi8", "ubi9",
+	}
+	dashTags = []string{
+		"bookworm", "bullseye", "buster", "jammy", "focal", "noble", "slim", "stretch", "trixie",
+	}
+	windowsImages = []string{"nanoserver", "servercore", "windows"}
+)
+
+// ImageDialect returns the dialect of /bin/sh in a base image, from its name
+// and tag: ash on alpine, dash on debian and ubuntu, bash on the Red Hat
+// family. The default shell of Windows images is cmd. Unknown images are
+// DialectSh.
+func ImageDialect(image, tag string) Dialect {
+	image = strings.ToLower(image)
+	tag = strings.ToLower(tag)
+	name := path.Base(image)
+
+	switch {
+	case slices.Contains(windowsImages, name) || strings.Contains(image, "/windows/"):
+		return DialectCmd
+	case slices.Contains(ashImages, name) || strings.Contains(tag, "alpine") || strings.Contains(name, "alpine"):
+		return DialectAsh
+	case slices.ContainsFunc(dashTags, func(distro string) bool { return strings.Contains(tag, distro) }):
+		return DialectDash
+	case slices.Contains(dashImages, name):
+		return DialectDash
+	case slices.Contains(bashImages, name):
+		return DialectBash
+	default:
+		return DialectSh
+	}
+}
+
+// IsShell reports whether the dialect is a POSIX-family shell, which
+// godolint can parse and check.
+func (d Dialect) IsShell() bool {
+	switch d {
+	case DialectSh, DialectBash, DialectDash, DialectAsh, DialectKsh, DialectZsh:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPOSIX reports whether the dialect is a POSIX sh without the bash
+// extensions (dash, ash, or an unknown /bin/sh).
+func (d Dialect) IsPOSIX() bool {
+	return d == DialectSh || d == DialectDash || d == DialectAsh
+}
+
+// Lang returns the mvdan.cc/sh parser variant for the dialect.
+func (d Dialect) Lang() syntax.LangVariant {
+	switch d {
+	case DialectBash:
+		return syntax.LangBash
+	case DialectKsh:
+		return syntax.LangMirBSDKorn
+	case DialectZsh:
+		return syntax.LangZsh
+	default:
+		return syntax.LangPOSIX
+	}
+}
+
+// shellcheckShell returns the --shell value for the dialect, or "" when
+// shellcheck does not support it (zsh, non-POSIX shells).
+func (d Dialect) shellcheckShell(version Version) string {
+	switch d {
+	case DialectSh, DialectBash, DialectDash, DialectKsh:
+		return string(d)
+	case DialectAsh:
+		if version.AtLeast(BusyboxVersion) {
+			return "busybox"
+		}
+
+		return string(DialectSh)
+	default:
+		return ""
+	}
+}
+
+// dialect returns the script dialect of the options: Dialect when set,
+// the dialect of ShellName otherwise.
+func (o Opts) dialect() Dialect {
+	if o.Dialect != "" {
+		return o.Dialect
+	}
+
+	if dialect := InterpreterDialect(o.ShellName); dialect != "" {
+		return dialect
+	}
+
+	return DialectSh
+}
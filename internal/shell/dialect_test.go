@@ -0,0 +1,249 @@
+package shell_test
+
+import (
+	"testing"
+
+	"github.com/farcloser/godolint/internal/shell"
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+func TestInterpreterDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		command string
+		want    shell.Dialect
+	}{
+		{"/bin/sh -c", shell.DialectSh},
+		{"/bin/bash -o pipefail -c", shell.DialectBash},
+		{"/usr/bin/env bash", shell.DialectBash},
+		{"/usr/bin/env -S LC_ALL=C dash -e", shell.DialectDash},
+		{"/bin/busybox sh", shell.DialectAsh},
+		{"/bin/mksh", shell.DialectKsh},
+		{"pwsh -Command", shell.DialectPowerShell},
+		{`C:\Windows\System32\cmd.exe /S /C`, shell.DialectCmd},
+		{"/usr/bin/python3", shell.DialectOther},
+	}
+
+	for _, tt := range tests {
+		if got := shell.InterpreterDialect(tt.command); got != tt.want {
+			t.Errorf("InterpreterDialect(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestImageDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		image, tag string
+		want       shell.Dialect
+	}{
+		{"alpine", "3.20", shell.DialectAsh},
+		{"python", "3.12-alpine", shell.DialectAsh},
+		{"debian", "bookworm", shell.DialectDash},
+		{"node", "20", shell.DialectDash},
+		{"docker.io/library/ubuntu", "24.04", shell.DialectDash},
+		{"registry.example.com/app", "1.0-bookworm-slim", shell.DialectDash},
+		{"fedora", "40", shell.DialectBash},
+		{"registry.access.redhat.com/ubi9/ubi-minimal", "", shell.DialectBash},
+		{"mcr.microsoft.com/windows/servercore", "ltsc2022", shell.DialectCmd},
+		{"registry.example.com/app", "1.0", shell.DialectSh},
+	}
+
+	for _, tt := range tests {
+		if got := shell.ImageDialect(tt.image, tt.tag); got != tt.want {
+			t.Errorf("ImageDialect(%q, %q) = %q, want %q", tt.image, tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestStageDialects(t *testing.T) {
+	t.Parallel()
+
+	alias := "builder"
+	tag := "3.20"
+
+	var stages shell.StageDialects
+	if got := stages.Dialect(); got != shell.DialectSh {
+		t.Errorf("zero StageDialects = %q, want sh", got)
+	}
+
+	stages = stages.From(&syntax.From{Image: syntax.BaseImage{Image: "alpine", Tag: &tag, Alias: &alias}})
+	if got := stages.Dialect(); got != shell.DialectAsh {
+		t.Errorf("alpine stage = %q, want ash", got)
+	}
+
+	before := stages
+	stages = stages.Shell(&syntax.Shell{Arguments: []string{"/bin/bash", "-c"}})
+
+	if got := stages.Dialect(); got != shell.DialectBash {
+		t.Errorf("after SHELL bash = %q, want bash", got)
+	}
+
+	if got := before.Dialect(); got != shell.DialectAsh {
+		t.Errorf("earlier copy changed to %q by SHELL", got)
+	}
+
+	// A stage built FROM another inherits its SHELL.
+	stages = stages.From(&syntax.From{Image: syntax.BaseImage{Image: "builder"}})
+	if got := stages.Dialect(); got != shell.DialectBash {
+		t.Errorf("stage FROM builder = %q, want bash", got)
+	}
+
+	// SHELL ["/bin/sh", "-c"] is the image's sh again.
+	stages = stages.Shell(&syntax.Shell{Arguments: []string{"/bin/sh", "-c"}})
+	if got := stages.Dialect(); got != shell.DialectAsh {
+		t.Errorf("after SHELL sh = %q, want ash", got)
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		run        syntax.Run
+		wantScript string
+		wantDial   shell.Dialect
+		wantOffset int
+	}{
+		{
+			name:       "plain command",
+			run:        syntax.Run{Command: "echo hi"},
+			wantScript: "echo hi",
+			wantDial:   shell.DialectDash,
+		},
+		{
+			name: "heredoc run by the shell",
+			run: syntax.Run{
+				Command:  "<<EOF",
+				Heredocs: []syntax.Heredoc{{Name: "EOF", Content: "set -e\necho hi\n"}},
+			},
+			wantScript: "set -e\necho hi\n",
+			wantDial:   shell.DialectDash,
+			wantOffset: 1,
+		},
+		{
+			name: "heredoc with a shebang",
+			run: syntax.Run{
+				Command:  "<<-'EOT'",
+				Heredocs: []syntax.Heredoc{{Name: "EOT", Content: "#!/usr/bin/env bash\necho hi\n"}},
+			},
+			wantScript: "\necho hi\n",
+			wantDial:   shell.DialectBash,
+			wantOffset: 1,
+		},
+		{
+			name: "heredoc fed to an interpreter",
+			run: syntax.Run{
+				Command:  "<<EOF python3",
+				Heredocs: []syntax.Heredoc{{Name: "EOF", Content: "print('hi')\n"}},
+			},
+			wantScript: "print('hi')\n",
+			wantDial:   shell.DialectOther,
+			wantOffset: 1,
+		},
+		{
+			name: "heredoc as a command's input",
+			run: syntax.Run{
+				Command:  "cat <<EOF > /etc/motd",
+				Heredocs: []syntax.Heredoc{{Name: "EOF", Content: "hello\n"}},
+			},
+			wantScript: "cat <<EOF > /etc/motd",
+			wantDial:   shell.DialectDash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			script, dialect, offset := shell.RunScript(&tt.run, shell.DialectDash)
+			if script != tt.wantScript || dialect != tt.wantDial || offset != tt.wantOffset {
+				t.Errorf("RunScript() = %q, %q, %d, want %q, %q, %d",
+					script, dialect, offset, tt.wantScript, tt.wantDial, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestBashisms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		script  string
+		dialect shell.Dialect
+		want    []string
+	}{
+		{"posix script", `[ "$a" = b ] && echo "${x%.tar}"`, shell.DialectDash, nil},
+		{"bash is not checked", "[[ -f x ]]", shell.DialectBash, nil},
+		{"test clause under dash", "[[ -f x ]] && echo y", shell.DialectDash, []string{"[[ ]]"}},
+		{"test clause under ash", "[[ -f x ]] && echo y", shell.DialectAsh, nil},
+		{"arrays under ash", "a=(1 2); echo ${a[0]}", shell.DialectAsh, []string{"arrays"}},
+		{"here-string", "read x <<< $y", shell.DialectSh, []string{"<<< here-strings"}},
+		{"source and ==", `source /etc/profile; [ "$a" == b ]`, shell.DialectDash, []string{"source", "== in [ ]"}},
+		{"echo -e", `echo -e "a\tb"; echo -n x`, shell.DialectDash, []string{"echo -e"}},
+		{"process substitution", "diff <(ls a) <(ls b)", shell.DialectAsh, []string{"process substitution"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bashisms, err := shell.Bashisms(tt.script, tt.dialect)
+			if err != nil {
+				t.Fatalf("Bashisms() error = %v", err)
+			}
+
+			var got []string
+			for _, bashism := range bashisms {
+				got = append(got, bashism.Feature)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Bashisms() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Bashisms() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStageDialects_Windows(t *testing.T) {
+	t.Parallel()
+
+	var stages shell.StageDialects
+	if stages = stages.From(&syntax.From{Image: syntax.BaseImage{Image: "registry.example.com/base"}}); stages.Windows() {
+		t.Error("unknown image is Windows without escape=`")
+	}
+
+	stages = stages.Shell(&syntax.Shell{Arguments: []string{"pwsh", "-Command"}})
+	if stages.Windows() || stages.Dialect() != shell.DialectPowerShell {
+		t.Errorf("SHELL pwsh = windows %v, %q, want PowerShell on any platform", stages.Windows(), stages.Dialect())
+	}
+
+	stages = stages.Shell(&syntax.Shell{Arguments: []string{"powershell", "-Command", "$ErrorActionPreference = 'Stop';"}})
+	if !stages.Windows() || stages.Interpreter() != "powershell -Command $ErrorActionPreference = 'Stop';" {
+		t.Errorf("SHELL powershell = windows %v, interpreter %q", stages.Windows(), stages.Interpreter())
+	}
+
+	stages = stages.Directive(&syntax.Directive{Key: "escape", Value: "`"})
+
+	stages = stages.From(&syntax.From{Image: syntax.BaseImage{Image: "registry.example.com/base"}})
+	if !stages.Windows() || stages.Dialect() != shell.DialectCmd {
+		t.Errorf("unknown image under escape=` = windows %v, %q, want cmd", stages.Windows(), stages.Dialect())
+	}
+
+	tag := "bookworm"
+
+	stages = stages.From(&syntax.From{Image: syntax.BaseImage{Image: "debian", Tag: &tag}})
+	if stages.Windows() {
+		t.Error("debian is Windows under escape=`")
+	}
+}
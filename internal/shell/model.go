@@ -0,0 +1,503 @@
+// This file builds the structured model of a shell script: its statements,
+// with their operators, pipelines, subshells, control flow, assignments and
+// redirections. The package godoc lives in parser.go.
+
+package shell
+
+import (
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Operator joins two statements in an and-or list.
+type Operator string
+
+// And-or list operators.
+const (
+	OpAnd Operator = "&&"
+	OpOr  Operator = "||"
+)
+
+// Node is a command of the structured model: *Simple, *Binary, *Pipeline,
+// *Subshell, *Block, *Conditional, *Loop, *Case, *Function or *Other.
+type Node interface {
+	node()
+}
+
+// Statement is a command in a list, with its modifiers.
+type Statement struct {
+	Node       Node
+	Line       int  // 0-based line offset within the script
+	Negated    bool // ! command
+	Background bool // command &
+	Redirects  []Redirect
+}
+
+// Assignment is a variable assignment: a bare one (FOO=bar), or a prefix
+// setting the environment of a single command (FOO=bar cmd).
+type Assignment struct {
+	Name   string
+	Value  string // Oversimplified like arguments, expansions masked
+	Append bool   // FOO+=bar
+}
+
+// Redirect is a redirection, such as 2>&1 or >/dev/null.
+type Redirect struct {
+	Fd     string // Explicit file descriptor, "" for the operator's default
+	Op     string // Operator (>, >>, <, >&, <<, <<<...)
+	Target string // File, descriptor or here-document word
+}
+
+// Simple is a simple command, or a declaration (export, local, readonly...),
+// with its assignments. Command is nil for bare assignments.
+type Simple struct {
+	Assignments []Assignment
+	Command     *Command
+	// Substitutions are the scripts of the command substitutions ($(...)
+	// and backticks) and process substitutions in its words.
+	Substitutions [][]*Statement
+}
+
+// Binary is an and-or list: Right runs depending on Left's exit status.
+type Binary struct {
+	Op    Operator
+	Left  *Statement
+	Right *Statement
+}
+
+// Pipeline is a pipeline of two or more commands, each in a subshell.
+type Pipeline struct {
+	Commands []*Statement
+	Stderr   bool // |& also pipes standard error
+}
+
+// Subshell is a list run in a subshell: ( list ).
+type Subshell struct {
+	Body []*Statement
+}
+
+// Block is a list grouped in the current shell: { list; }.
+type Block struct {
+	Body []*Statement
+}
+
+// Conditional is an if statement. An elif is a Conditional alone in Else.
+type Conditional struct {
+	Cond []*Statement
+	Then []*Statement
+	Else []*Statement
+}
+
+// Loop is a for, select, while or until loop. Cond is empty for for and
+// select loops.
+type Loop struct {
+	Keyword string
+	Cond    []*Statement
+	Body    []*Statement
+}
+
+// Case is a case statement.
+type Case struct {
+	Word     string
+	Branches [][]*Statement
+}
+
+// Function is a function declaration; its body runs when it is called.
+type Function struct {
+	Name string
+	Body *Statement
+}
+
+// Other is a command the model does not break down: [[ ]], (( )), let,
+// coproc or a test declaration. Kind names it.
+type Other struct {
+	Kind string
+}
+
+func (*Simple) node()      {}
+func (*Binary) node()      {}
+func (*Pipeline) node()    {}
+func (*Subshell) node()    {}
+func (*Block) node()       {}
+func (*Conditional) node() {}
+func (*Loop) node()        {}
+func (*Case) node()        {}
+func (*Function) node()    {}
+func (*Other) node()       {}
+
+// Command returns the command of a simple statement, nil otherwise.
+func (s *Statement) Command() *Command {
+	if simple, ok := s.Node.(*Simple); ok {
+		return simple.Command
+	}
+
+	return nil
+}
+
+// Context describes where a statement runs, as Walk reaches it.
+type Context struct {
+	// Subshell is set inside ( ), pipelines, substitutions and background
+	// jobs: changes to the shell state (cd, set, variables) do not last.
+	Subshell bool
+	// Condition is set when the exit status is tested: the conditions of if,
+	// while and until, the left of && and ||, and negated statements. A
+	// failure there does not stop a `set -e` script.
+	Condition bool
+	// OnFailure is set for statements that only run when another failed:
+	// the right of || and the else branch of an if.
+	OnFailure bool
+	// Branch is set for statements that only run on some paths: if and case
+	// branches, loop bodies and function bodies. The right of && is not one:
+	// it runs whenever the script keeps going.
+	Branch bool
+}
+
+// Walk calls fn for the statements of the list and, unless fn returns false,
+// the statements nested in them, in the order they run.
+func Walk(stmts []*Statement, fn func(stmt *Statement, ctx Context) bool) {
+	walkList(stmts, Context{}, fn)
+}
+
+func walkList(stmts []*Statement, ctx Context, fn func(*Statement, Context) bool) {
+	for _, stmt := range stmts {
+		walkStatement(stmt, ctx, fn)
+	}
+}
+
+func walkStatement(stmt *Statement, ctx Context, fn func(*Statement, Context) bool) {
+	if stmt.Negated {
+		ctx.Condition = true
+	}
+
+	if stmt.Background {
+		ctx.Subshell = true
+	}
+
+	if !fn(stmt, ctx) {
+		return
+	}
+
+	switch node := stmt.Node.(type) {
+	case *Simple:
+		inner := ctx
+		inner.Subshell = true
+
+		for _, script := range node.Substitutions {
+			walkList(script, inner, fn)
+		}
+
+	case *Binary:
+		left := ctx
+		left.Condition = true
+		walkStatement(node.Left, left, fn)
+
+		right := ctx
+		right.OnFailure = ctx.OnFailure || node.Op == OpOr
+		walkStatement(node.Right, right, fn)
+
+	case *Pipeline:
+		inner := ctx
+		inner.Subshell = true
+
+		walkList(node.Commands, inner, fn)
+
+	case *Subshell:
+		inner := ctx
+		inner.Subshell = true
+
+		walkList(node.Body, inner, fn)
+
+	case *Block:
+		walkList(node.Body, ctx, fn)
+
+	case *Conditional:
+		cond := ctx
+		cond.Condition = true
+		walkList(node.Cond, cond, fn)
+
+		then := ctx
+		then.Branch = true
+		walkList(node.Then, then, fn)
+
+		otherwise := then
+		otherwise.OnFailure = true
+		walkList(node.Else, otherwise, fn)
+
+	case *Loop:
+		cond := ctx
+		cond.Condition = true
+		walkList(node.Cond, cond, fn)
+
+		body := ctx
+		body.Branch = true
+		walkList(node.Body, body, fn)
+
+	case *Case:
+		branch := ctx
+		branch.Branch = true
+
+		for _, body := range node.Branches {
+			walkList(body, branch, fn)
+		}
+
+	case *Function:
+		body := ctx
+		body.Branch = true
+		walkStatement(node.Body, body, fn)
+	}
+}
+
+// buildStatements converts the statements of a parsed script.
+func buildStatements(stmts []*syntax.Stmt) []*Statement {
+	result := make([]*Statement, 0, len(stmts))
+
+	for _, stmt := range stmts {
+		if converted := buildStatement(stmt); converted != nil {
+			result = append(result, converted)
+		}
+	}
+
+	return result
+}
+
+func buildStatement(stmt *syntax.Stmt) *Statement {
+	if stmt == nil {
+		return nil
+	}
+
+	result := &Statement{
+		Line:       int(stmt.Pos().Line()) - 1,
+		Negated:    stmt.Negated,
+		Background: stmt.Background,
+	}
+
+	var substitutions [][]*Statement
+
+	for _, redirect := range stmt.Redirs {
+		converted := Redirect{Op: redirect.Op.String()}
+		if redirect.N != nil {
+			converted.Fd = redirect.N.Value
+		}
+
+		if redirect.Word != nil {
+			converted.Target = wordToString(redirect.Word)
+			substitutions = append(substitutions, wordSubstitutions(redirect.Word)...)
+		}
+
+		result.Redirects = append(result.Redirects, converted)
+	}
+
+	result.Node = buildNode(stmt.Cmd)
+
+	if simple, ok := result.Node.(*Simple); ok {
+		simple.Substitutions = append(simple.Substitutions, substitutions...)
+	}
+
+	return result
+}
+
+// Loop keywords for loops without a condition.
+const (
+	keywordFor    = "for"
+	keywordSelect = "select"
+)
+
+//nolint:cyclop,funlen // one case per node type of the parser.
+func buildNode(cmd syntax.Command) Node {
+	switch cmd := cmd.(type) {
+	case nil:
+		// A statement made of redirections only.
+		return &Simple{}
+
+	case *syntax.CallExpr:
+		return buildSimple(cmd)
+
+	case *syntax.DeclClause:
+		return buildDecl(cmd)
+
+	case *syntax.BinaryCmd:
+		if cmd.Op == syntax.Pipe || cmd.Op == syntax.PipeAll {
+			pipeline := &Pipeline{Stderr: cmd.Op == syntax.PipeAll}
+			pipeline.Commands = append(pipelineCommands(cmd.X), pipelineCommands(cmd.Y)...)
+
+			return pipeline
+		}
+
+		op := OpAnd
+		if cmd.Op == syntax.OrStmt {
+			op = OpOr
+		}
+
+		return &Binary{Op: op, Left: buildStatement(cmd.X), Right: buildStatement(cmd.Y)}
+
+	case *syntax.Subshell:
+		return &Subshell{Body: buildStatements(cmd.Stmts)}
+
+	case *syntax.Block:
+		return &Block{Body: buildStatements(cmd.Stmts)}
+
+	case *syntax.IfClause:
+		return buildConditional(cmd)
+
+	case *syntax.WhileClause:
+		keyword := "while"
+		if cmd.Until {
+			keyword = "until"
+		}
+
+		return &Loop{Keyword: keyword, Cond: buildStatements(cmd.Cond), Body: buildStatements(cmd.Do)}
+
+	case *syntax.ForClause:
+		keyword := keywordFor
+		if cmd.Select {
+			keyword = keywordSelect
+		}
+
+		return &Loop{Keyword: keyword, Body: buildStatements(cmd.Do)}
+
+	case *syntax.CaseClause:
+		node := &Case{Word: wordToString(cmd.Word)}
+		for _, item := range cmd.Items {
+			node.Branches = append(node.Branches, buildStatements(item.Stmts))
+		}
+
+		return node
+
+	case *syntax.FuncDecl:
+		return &Function{Name: cmd.Name.Value, Body: buildStatement(cmd.Body)}
+
+	case *syntax.TimeClause:
+		if inner := buildStatement(cmd.Stmt); inner != nil {
+			return inner.Node
+		}
+
+		return &Other{Kind: "time"}
+
+	case *syntax.TestClause:
+		return &Other{Kind: "[["}
+	case *syntax.ArithmCmd:
+		return &Other{Kind: "(("}
+	case *syntax.LetClause:
+		return &Other{Kind: "let"}
+	case *syntax.CoprocClause:
+		return &Other{Kind: "coproc"}
+	default:
+		return &Other{Kind: "unknown"}
+	}
+}
+
+// pipelineCommands flattens one side of a pipe: a | b | c parses as
+// (a | b) | c.
+func pipelineCommands(stmt *syntax.Stmt) []*Statement {
+	if bin, ok := stmt.Cmd.(*syntax.BinaryCmd); ok && !stmt.Negated && len(stmt.Redirs) == 0 &&
+		(bin.Op == syntax.Pipe || bin.Op == syntax.PipeAll) {
+		return append(pipelineCommands(bin.X), pipelineCommands(bin.Y)...)
+	}
+
+	return []*Statement{buildStatement(stmt)}
+}
+
+func buildConditional(clause *syntax.IfClause) *Conditional {
+	node := &Conditional{Cond: buildStatements(clause.Cond), Then: buildStatements(clause.Then)}
+
+	switch {
+	case clause.Else == nil:
+	case len(clause.Else.Cond) > 0:
+		// elif
+		node.Else = []*Statement{{
+			Node: buildConditional(clause.Else),
+			Line: int(clause.Else.Pos().Line()) - 1,
+		}}
+	default:
+		node.Else = buildStatements(clause.Else.Then)
+	}
+
+	return node
+}
+
+func buildSimple(call *syntax.CallExpr) *Simple {
+	simple := &Simple{Command: extractCommand(call)}
+
+	for _, assign := range call.Assigns {
+		simple.Assignments = append(simple.Assignments, buildAssignment(assign))
+		simple.Substitutions = append(simple.Substitutions, assignSubstitutions(assign)...)
+	}
+
+	for _, arg := range call.Args {
+		simple.Substitutions = append(simple.Substitutions, wordSubstitutions(arg)...)
+	}
+
+	return simple
+}
+
+// buildDecl models a declaration as a command with its arguments, as
+// hadolint sees `export FOO=bar`, plus the assignments it makes.
+func buildDecl(decl *syntax.DeclClause) *Simple {
+	command := &Command{Name: decl.Variant.Value}
+	simple := &Simple{Command: command}
+
+	for i, assign := range decl.Args {
+		var arg string
+
+		switch {
+		case assign.Naked && assign.Name != nil:
+			arg = assign.Name.Value
+		case assign.Naked && assign.Value != nil:
+			arg = wordToString(assign.Value)
+		default:
+			converted := buildAssignment(assign)
+			simple.Assignments = append(simple.Assignments, converted)
+			arg = converted.Name + "=" + converted.Value
+		}
+
+		command.Arguments = append(command.Arguments, CmdPart{Arg: arg, ID: i})
+		simple.Substitutions = append(simple.Substitutions, assignSubstitutions(assign)...)
+	}
+
+	command.Flags = extractFlags(command.Arguments)
+
+	return simple
+}
+
+func buildAssignment(assign *syntax.Assign) Assignment {
+	converted := Assignment{Append: assign.Append}
+	if assign.Name != nil {
+		converted.Name = assign.Name.Value
+	}
+
+	if assign.Value != nil {
+		converted.Value = wordToString(assign.Value)
+	}
+
+	return converted
+}
+
+func assignSubstitutions(assign *syntax.Assign) [][]*Statement {
+	if assign.Value == nil {
+		return nil
+	}
+
+	return wordSubstitutions(assign.Value)
+}
+
+// wordSubstitutions returns the scripts of the command and process
+// substitutions in a word, outermost first.
+func wordSubstitutions(word *syntax.Word) [][]*Statement {
+	var scripts [][]*Statement
+
+	syntax.Walk(word, func(node syntax.Node) bool {
+		switch node := node.(type) {
+		case *syntax.CmdSubst:
+			scripts = append(scripts, buildStatements(node.Stmts))
+
+			return false
+		case *syntax.ProcSubst:
+			scripts = append(scripts, buildStatements(node.Stmts))
+
+			return false
+		}
+
+		return true
+	})
+
+	return scripts
+}
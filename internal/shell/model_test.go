@@ -0,0 +1,114 @@
+package shell_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+func TestStatements_Structure(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := shell.ParseShell(`DEBIAN_FRONTEND=noninteractive apt-get install -y curl 2>&1 >/dev/null
+curl -fsSL https://example.com | tar -xz -C /opt || exit 1
+(cd /src && make)
+export PATH="/opt/bin:$PATH" X=$(uname -m)`)
+	if err != nil {
+		t.Fatalf("ParseShell() error = %v", err)
+	}
+
+	if len(parsed.Statements) != 4 {
+		t.Fatalf("got %d statements, want 4", len(parsed.Statements))
+	}
+
+	install, ok := parsed.Statements[0].Node.(*shell.Simple)
+	if !ok || install.Command.Name != "apt-get" {
+		t.Fatalf("statement 0 = %#v, want the apt-get command", parsed.Statements[0].Node)
+	}
+
+	if want := []shell.Assignment{{Name: "DEBIAN_FRONTEND", Value: "noninteractive"}}; !slices.Equal(install.Assignments, want) {
+		t.Errorf("assignments = %+v, want %+v", install.Assignments, want)
+	}
+
+	want := []shell.Redirect{{Fd: "2", Op: ">&", Target: "1"}, {Op: ">", Target: "/dev/null"}}
+	if !slices.Equal(parsed.Statements[0].Redirects, want) {
+		t.Errorf("redirects = %+v, want %+v", parsed.Statements[0].Redirects, want)
+	}
+
+	list, ok := parsed.Statements[1].Node.(*shell.Binary)
+	if !ok || list.Op != shell.OpOr || parsed.Statements[1].Line != 1 {
+		t.Fatalf("statement 1 = %#v on line %d, want an || list on line 1", parsed.Statements[1].Node, parsed.Statements[1].Line)
+	}
+
+	if pipeline, ok := list.Left.Node.(*shell.Pipeline); !ok || len(pipeline.Commands) != 2 {
+		t.Errorf("left of || = %#v, want a two-command pipeline", list.Left.Node)
+	}
+
+	if _, ok := parsed.Statements[2].Node.(*shell.Subshell); !ok {
+		t.Errorf("statement 2 = %#v, want a subshell", parsed.Statements[2].Node)
+	}
+
+	export, ok := parsed.Statements[3].Node.(*shell.Simple)
+	if !ok || export.Command.Name != "export" || len(export.Assignments) != 2 || len(export.Substitutions) != 1 {
+		t.Errorf("statement 3 = %#v, want export with two assignments and a substitution", parsed.Statements[3].Node)
+	}
+}
+
+func TestWalk_Context(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := shell.ParseShell(`a && b || c
+if d; then e; else f; fi
+(g) | h
+i $(j) &
+for x in 1; do k; done`)
+	if err != nil {
+		t.Fatalf("ParseShell() error = %v", err)
+	}
+
+	contexts := map[string]shell.Context{}
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, ctx shell.Context) bool {
+		if cmd := stmt.Command(); cmd != nil {
+			contexts[cmd.Name] = ctx
+		}
+
+		return true
+	})
+
+	tests := map[string]shell.Context{
+		"a": {Condition: true},
+		"b": {Condition: true},
+		"c": {OnFailure: true},
+		"d": {Condition: true},
+		"e": {Branch: true},
+		"f": {Branch: true, OnFailure: true},
+		"g": {Subshell: true},
+		"h": {Subshell: true},
+		"i": {Subshell: true},
+		"j": {Subshell: true},
+		"k": {Branch: true},
+	}
+
+	for name, want := range tests {
+		if got, ok := contexts[name]; !ok || got != want {
+			t.Errorf("context of %s = %+v (visited %v), want %+v", name, got, ok, want)
+		}
+	}
+
+	var order []string
+
+	shell.Walk(parsed.Statements, func(stmt *shell.Statement, _ shell.Context) bool {
+		if cmd := stmt.Command(); cmd != nil {
+			order = append(order, cmd.Name)
+		}
+
+		return true
+	})
+
+	if got := strings.Join(order, ""); got != "abcdefghijk" {
+		t.Errorf("Walk() order = %s, want abcdefghijk", got)
+	}
+}
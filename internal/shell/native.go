@@ -0,0 +1,355 @@
+// This file provides a pure-Go shellchecker, used when the shellcheck binary
+// is not available. The package godoc lives in parser.go.
+
+package shell
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+// NativeShellchecker implements the most valuable shellcheck checks on the
+// mvdan.cc/sh AST, with the same SC codes so that pragmas keep working:
+//
+//   - SC2086: unquoted variable expansion
+//   - SC2046: unquoted command substitution
+//   - SC2164: cd/pushd/popd whose failure is not handled
+//   - SC2155: declaration masking the return value of its assignment
+//   - SC2035: glob that may expand to an option
+//   - SC2181: exit code checked indirectly through $?
+//   - SC1091: sourced file that cannot be followed
+//
+// It is a subset of shellcheck, not a replacement: other codes are never
+// reported, and the rcfile, optional checks and external sources do not
+// apply. The severity floor and the include and exclude lists do.
+type NativeShellchecker struct {
+	settings Settings
+}
+
+// NewNativeShellchecker creates a pure-Go shellchecker.
+func NewNativeShellchecker() *NativeShellchecker {
+	return &NativeShellchecker{}
+}
+
+// NewShellchecker returns a BatchingShellchecker using the settings when the
+// shellcheck binary is on PATH, with one worker per CPU, and a
+// NativeShellchecker otherwise, in which case native is true. The settings
+// are validated, and the binary's version detected once, here: an option it
+// does not support is an ErrUnsupportedVersion error, rather than every
+// check failing later.
+func NewShellchecker(settings Settings) (checker Shellchecker, native bool, err error) {
+	if err := settings.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	if _, err := exec.LookPath("shellcheck"); err != nil {
+		return &NativeShellchecker{settings: settings}, true, nil
+	}
+
+	binary := NewBinaryShellchecker()
+	binary.Settings = settings
+	// An unrecognized version stays unknown, which passes every option.
+	binary.Version, _ = DetectVersion()
+
+	if err := binary.checkOptions(); err != nil {
+		return nil, false, err
+	}
+
+	return NewBatchingShellchecker(binary, runtime.NumCPU()), false, nil
+}
+
+// Messages match shellcheck's wording.
+const (
+	msgSC2086 = "Double quote to prevent globbing and word splitting."
+	msgSC2046 = "Quote this to prevent word splitting."
+	msgSC2164 = "Use '%s ... || exit' or '%s ... || return' in case %s fails."
+	msgSC2155 = "Declare and assign separately to avoid masking return values."
+	msgSC2035 = "Use ./*glob* or -- *glob* so names with dashes won't become options."
+	msgSC2181 = "Check exit code directly with e.g. 'if mycmd;', not indirectly with $?."
+	msgSC1091 = "Not following: %s was not specified as input (see shellcheck -x)."
+)
+
+// Check analyzes the script, with the same skipping rules and line offsets as
+// BinaryShellchecker.
+func (c *NativeShellchecker) Check(script string, opts Opts) ([]rule.CheckFailure, error) {
+	if skipScript(script, opts) {
+		return nil, nil
+	}
+
+	// POSIX sh is analyzed as bash: mvdan's POSIX variant does not build the
+	// declaration and test clauses the checks rely on, and the bashisms it
+	// would reject are GD1001's business.
+	dialect := opts.dialect()
+	if dialect.IsPOSIX() {
+		dialect = DialectBash
+	}
+
+	file, err := parseDialect(script, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &nativeAnalysis{tested: make(map[*syntax.CallExpr]bool)}
+	analysis.run(file)
+
+	var failures []rule.CheckFailure
+
+	for _, failure := range analysis.failures {
+		if c.settings.keep(failure) {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures, nil
+}
+
+// nativeAnalysis accumulates the failures of one script.
+type nativeAnalysis struct {
+	failures []rule.CheckFailure
+	// tested holds the commands whose exit status is checked: conditions
+	// and the left-hand side of && and ||.
+	tested map[*syntax.CallExpr]bool
+	// errexit is set when the script runs `set -e`, which handles every
+	// failing cd.
+	errexit bool
+}
+
+func (a *nativeAnalysis) run(file *syntax.File) {
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch typed := node.(type) {
+		case *syntax.BinaryCmd:
+			if typed.Op == syntax.AndStmt || typed.Op == syntax.OrStmt {
+				a.markTested(typed.X)
+			}
+		case *syntax.IfClause:
+			a.markTestedList(typed.Cond)
+		case *syntax.WhileClause:
+			a.markTestedList(typed.Cond)
+		case *syntax.Stmt:
+			if typed.Negated {
+				a.markTested(typed)
+			}
+		case *syntax.CallExpr:
+			if isSetErrexit(typed) {
+				a.errexit = true
+			}
+		}
+
+		return true
+	})
+
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch typed := node.(type) {
+		case *syntax.CallExpr:
+			a.checkCall(typed)
+		case *syntax.DeclClause:
+			a.checkDecl(typed)
+		case *syntax.TestClause:
+			a.checkTest(typed.X)
+		}
+
+		return true
+	})
+}
+
+// markTested records the command whose exit status the statement returns.
+func (a *nativeAnalysis) markTested(stmt *syntax.Stmt) {
+	switch cmd := stmt.Cmd.(type) {
+	case *syntax.CallExpr:
+		a.tested[cmd] = true
+	case *syntax.BinaryCmd:
+		if cmd.Op == syntax.AndStmt || cmd.Op == syntax.OrStmt {
+			a.markTested(cmd.Y)
+		}
+	case *syntax.Block:
+		a.markTestedList(cmd.Stmts)
+	case *syntax.Subshell:
+		a.markTestedList(cmd.Stmts)
+	}
+}
+
+func (a *nativeAnalysis) markTestedList(stmts []*syntax.Stmt) {
+	if len(stmts) > 0 {
+		a.markTested(stmts[len(stmts)-1])
+	}
+}
+
+func (a *nativeAnalysis) add(code rule.Code, severity rule.Severity, message string, pos syntax.Pos) {
+	// Same anchoring as BinaryShellchecker: the first line's column is
+	// meaningless once the instruction is collapsed, so it stays 1.
+	offset := int(pos.Line()) - 1
+	column := 1
+
+	if offset > 0 {
+		column = int(pos.Col())
+	}
+
+	a.failures = append(a.failures, rule.CheckFailure{
+		Code:     code,
+		Severity: severity,
+		Message:  message,
+		Line:     max(offset, 0),
+		Column:   column,
+	})
+}
+
+func (a *nativeAnalysis) checkCall(call *syntax.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	name := call.Args[0].Lit()
+	args := call.Args[1:]
+
+	switch name {
+	case "cd", "pushd", "popd":
+		if !a.errexit && !a.tested[call] {
+			a.add("SC2164", rule.Warning, fmt.Sprintf(msgSC2164, name, name, name), call.Pos())
+		}
+	case "source", ".":
+		if len(args) > 0 {
+			a.add("SC1091", rule.Info, fmt.Sprintf(msgSC1091, wordToString(args[0])), args[0].Pos())
+		}
+	case "[", "test":
+		a.checkTestArgs(args)
+	}
+
+	afterDoubleDash := false
+
+	for _, arg := range args {
+		for _, part := range arg.Parts {
+			switch typed := part.(type) {
+			case *syntax.ParamExp:
+				if !isSafeParam(typed) {
+					a.add("SC2086", rule.Info, msgSC2086, typed.Pos())
+				}
+			case *syntax.CmdSubst:
+				a.add("SC2046", rule.Warning, msgSC2046, typed.Pos())
+			}
+		}
+
+		if !afterDoubleDash && name != "echo" && name != "printf" && isLeadingGlob(arg) {
+			a.add("SC2035", rule.Info, msgSC2035, arg.Pos())
+		}
+
+		if arg.Lit() == "--" {
+			afterDoubleDash = true
+		}
+	}
+}
+
+// checkDecl flags `export VAR=$(cmd)` and friends: the declaration's exit
+// status replaces the substitution's.
+func (a *nativeAnalysis) checkDecl(decl *syntax.DeclClause) {
+	for _, assign := range decl.Args {
+		if assign.Value == nil {
+			continue
+		}
+
+		hasSubst := false
+
+		syntax.Walk(assign.Value, func(node syntax.Node) bool {
+			if _, ok := node.(*syntax.CmdSubst); ok {
+				hasSubst = true
+			}
+
+			return !hasSubst
+		})
+
+		if hasSubst {
+			a.add("SC2155", rule.Warning, msgSC2155, assign.Pos())
+		}
+	}
+}
+
+// checkTestArgs flags `[ $? -eq 0 ]` and `test $? != 0`.
+func (a *nativeAnalysis) checkTestArgs(args []*syntax.Word) {
+	for _, arg := range args {
+		if isExitStatus(arg) {
+			a.add("SC2181", rule.Style, msgSC2181, arg.Pos())
+
+			return
+		}
+	}
+}
+
+// checkTest flags `[[ $? -ne 0 ]]`.
+func (a *nativeAnalysis) checkTest(expr syntax.TestExpr) {
+	binary, ok := expr.(*syntax.BinaryTest)
+	if !ok {
+		return
+	}
+
+	for _, side := range []syntax.TestExpr{binary.X, binary.Y} {
+		if word, isWord := side.(*syntax.Word); isWord && isExitStatus(word) {
+			a.add("SC2181", rule.Style, msgSC2181, word.Pos())
+
+			return
+		}
+	}
+}
+
+// isSetErrexit recognizes `set -e`, `set -eux`, `set -o errexit`.
+func isSetErrexit(call *syntax.CallExpr) bool {
+	if len(call.Args) < 2 || call.Args[0].Lit() != "set" {
+		return false
+	}
+
+	for i, arg := range call.Args[1:] {
+		flag := arg.Lit()
+		if strings.HasPrefix(flag, "-") && !strings.HasPrefix(flag, "--") && strings.Contains(flag, "e") {
+			return true
+		}
+
+		if flag == "-o" && i+2 < len(call.Args) && call.Args[i+2].Lit() == "errexit" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSafeParam tells expansions that cannot split or glob: the numeric
+// special parameters and lengths.
+func isSafeParam(param *syntax.ParamExp) bool {
+	if param.Length {
+		return true
+	}
+
+	return param.Param != nil && slices.Contains([]string{"#", "?", "$", "!"}, param.Param.Value)
+}
+
+// isExitStatus reports whether the word is exactly $?, quoted or not.
+func isExitStatus(word *syntax.Word) bool {
+	if len(word.Parts) != 1 {
+		return false
+	}
+
+	part := word.Parts[0]
+	if quoted, ok := part.(*syntax.DblQuoted); ok && len(quoted.Parts) == 1 {
+		part = quoted.Parts[0]
+	}
+
+	param, ok := part.(*syntax.ParamExp)
+
+	return ok && !param.Length && param.Param != nil && param.Param.Value == "?" &&
+		param.Exp == nil && param.Repl == nil && param.Slice == nil
+}
+
+// isLeadingGlob reports whether the word starts with an unquoted *.
+func isLeadingGlob(word *syntax.Word) bool {
+	if len(word.Parts) == 0 {
+		return false
+	}
+
+	lit, ok := word.Parts[0].(*syntax.Lit)
+
+	return ok && strings.HasPrefix(lit.Value, "*")
+}
@@ -0,0 +1,85 @@
+package shell_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+func TestNativeShellchecker_Check(t *testing.T) {
+	t.Parallel()
+
+	checker := shell.NewNativeShellchecker()
+
+	tests := []struct {
+		name   string
+		script string
+		want   []rule.Code
+	}{
+		{"clean script", `apt-get install -y "$PKG"`, nil},
+		{"unquoted variable", "echo $FOO", []rule.Code{"SC2086"}},
+		{"numeric special parameters", "echo $# $? ${#FOO}", nil},
+		{"assignment is not split", "FOO=$BAR make", nil},
+		{"unquoted command substitution", "rm $(cat files)", []rule.Code{"SC2046"}},
+		{"quoted command substitution", `rm "$(cat file)"`, nil},
+		{"unchecked cd", "cd /src; make", []rule.Code{"SC2164"}},
+		{"cd guarded by ||", "cd /src || exit; make", nil},
+		{"cd followed by &&", "cd /src && make", nil},
+		{"cd at the end of a chain", "make && cd /src", []rule.Code{"SC2164"}},
+		{"cd under set -e", "set -eux; cd /src; make", nil},
+		{"export masking a substitution", "export FOO=$(date)", []rule.Code{"SC2155"}},
+		{"export of a literal", "export FOO=bar", nil},
+		{"glob as option", "rm *.log", []rule.Code{"SC2035"}},
+		{"glob after --", "rm -- *.log", nil},
+		{"relative glob", "rm ./*.log", nil},
+		{"indirect exit code check", "make; if [ $? -ne 0 ]; then exit 1; fi", []rule.Code{"SC2181"}},
+		{"indirect exit code in [[", `make; [[ "$?" != 0 ]] && exit 1`, []rule.Code{"SC2181"}},
+		{"sourced file", ". /etc/os-release", []rule.Code{"SC1091"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			failures, err := checker.Check(tt.script, shell.DefaultOpts())
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			var got []rule.Code
+			for _, failure := range failures {
+				got = append(got, failure.Code)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Check(%q) = %v, want %v", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNativeShellchecker_LineOffsets(t *testing.T) {
+	t.Parallel()
+
+	failures, err := shell.NewNativeShellchecker().Check("true\n  echo $FOO", shell.DefaultOpts())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if len(failures) != 1 || failures[0].Line != 1 || failures[0].Column != 8 {
+		t.Errorf("Check() = %+v, want one failure at offset 1, column 8", failures)
+	}
+}
+
+func TestNativeShellchecker_SkipsPowerShell(t *testing.T) {
+	t.Parallel()
+
+	opts := shell.Opts{ShellName: "pwsh -c", EnvVars: map[string]string{}}
+
+	failures, err := shell.NewNativeShellchecker().Check("echo $FOO", opts)
+	if err != nil || len(failures) != 0 {
+		t.Errorf("Check() = %v, %v, want nothing for PowerShell", failures, err)
+	}
+}
@@ -29,20 +29,26 @@ type Command struct {
 type ParsedShell struct {
 	Original        string    // Original script text
 	PresentCommands []Command // Extracted commands
+	// Statements is the structured model of the script, for rules that
+	// reason about control flow; see Walk. It is godolint's own.
+	Statements []*Statement
 }
 
-// ParseShell parses a shell script and extracts commands.
+// ParseShell parses a shell script as bash, the most lenient dialect, and
+// extracts commands.
 // Ported from Hadolint.Shell.parseShell.
 func ParseShell(script string) (*ParsedShell, error) {
-	// Add shebang to help parser
-	fullScript := "#!/bin/bash\n" + script
-
-	// Parse the script
-	r := strings.NewReader(fullScript)
+	return ParseShellDialect(script, DialectBash)
+}
 
-	file, err := syntax.NewParser().Parse(r, "")
+// ParseShellDialect parses a shell script written in the given dialect and
+// extracts commands. Syntax the dialect lacks (bashisms under a POSIX sh)
+// does not fail the parse: it is reparsed as bash, and left to the rules
+// that report it.
+func ParseShellDialect(script string, dialect Dialect) (*ParsedShell, error) {
+	file, err := parseDialect(script, dialect)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse shell script: %w", err)
+		return nil, err
 	}
 
 	// Extract commands
@@ -51,9 +57,25 @@ func ParseShell(script string) (*ParsedShell, error) {
 	return &ParsedShell{
 		Original:        script,
 		PresentCommands: commands,
+		Statements:      buildStatements(file.Stmts),
 	}, nil
 }
 
+// parseDialect parses the script with the dialect's parser variant, falling
+// back to bash.
+func parseDialect(script string, dialect Dialect) (*syntax.File, error) {
+	file, err := syntax.NewParser(syntax.Variant(dialect.Lang())).Parse(strings.NewReader(script), "")
+	if err != nil && dialect.Lang() != syntax.LangBash {
+		file, err = syntax.NewParser(syntax.Variant(syntax.LangBash)).Parse(strings.NewReader(script), "")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shell script: %w", err)
+	}
+
+	return file, nil
+}
+
 // extractCommands walks the AST and extracts all commands.
 func extractCommands(file *syntax.File) []Command {
 	var commands []Command
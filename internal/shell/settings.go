@@ -0,0 +1,122 @@
+// This file defines the shellcheck tuning settings. The package godoc lives
+// in parser.go.
+
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/rule"
+)
+
+var (
+	// ErrInvalidSeverity reports a severity shellcheck does not know.
+	ErrInvalidSeverity = errors.New("invalid shellcheck severity")
+	// ErrInvalidCode reports a malformed shellcheck code.
+	ErrInvalidCode = errors.New("invalid shellcheck code")
+)
+
+// codePattern matches shellcheck codes, with or without their SC prefix.
+var codePattern = regexp.MustCompile(`^(?:SC)?(\d{4})$`)
+
+// defaultExcludes are always excluded, like hadolint does:
+//   - SC2187: ash shell not supported warning
+//   - SC1090: can't follow sourced files (requires shell directives)
+//   - SC1091: can't follow sourced files (requires shell directives)
+//
+//nolint:gochecknoglobals // read-only list.
+var defaultExcludes = []string{"SC2187", "SC1090", "SC1091"}
+
+// Settings tune shellcheck, mirroring its command line options.
+type Settings struct {
+	// RCFile, when set, is forwarded to shellcheck as --rcfile so the check
+	// uses that configuration instead of searching for one. Without it the
+	// script runs from a temp dir, so a repository's .shellcheckrc would
+	// never be found. Requires shellcheck >= 0.10.0.
+	RCFile string
+	// Severity is the minimum severity reported: error, warning, info or
+	// style (the default).
+	Severity string
+	// Include, when set, restricts the report to these codes.
+	Include []string
+	// Exclude drops these codes, on top of the ones hadolint excludes.
+	Exclude []string
+	// Enable turns on optional checks (e.g. require-variable-braces, or
+	// all).
+	Enable []string
+	// ExternalSources lets shellcheck follow sourced files (-x). Paths are
+	// resolved from the temp dir the scripts are checked in, so only
+	// absolute ones can be followed.
+	ExternalSources bool
+}
+
+// Validate checks the severity and codes, normalizing codes to their SC
+// form.
+func (s *Settings) Validate() error {
+	if s.Severity != "" && !slices.Contains([]string{"error", "warning", "info", "style"}, s.Severity) {
+		return fmt.Errorf("%w: %q (want error, warning, info or style)", ErrInvalidSeverity, s.Severity)
+	}
+
+	for _, codes := range []*[]string{&s.Include, &s.Exclude} {
+		normalized := make([]string, len(*codes))
+
+		for i, code := range *codes {
+			match := codePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(code)))
+			if match == nil {
+				return fmt.Errorf("%w: %q", ErrInvalidCode, code)
+			}
+
+			normalized[i] = "SC" + match[1]
+		}
+
+		*codes = normalized
+	}
+
+	return nil
+}
+
+// severity returns the minimum severity as a rule severity.
+func (s *Settings) severity() rule.Severity {
+	if s.Severity == "" {
+		return rule.Style
+	}
+
+	return convertSeverity(s.Severity)
+}
+
+// excludes returns the excluded codes, hadolint's included.
+func (s *Settings) excludes() []string {
+	return append(slices.Clone(defaultExcludes), s.Exclude...)
+}
+
+// keep applies the severity floor and the include/exclude lists, for
+// checkers that do not implement them natively.
+func (s *Settings) keep(failure rule.CheckFailure) bool {
+	// Severities are ordered from error (lowest value) to style.
+	if failure.Severity > s.severity() {
+		return false
+	}
+
+	code := string(failure.Code)
+	if len(s.Include) > 0 && !slices.Contains(s.Include, code) {
+		return false
+	}
+
+	return !slices.Contains(s.Exclude, code)
+}
+
+// String describes the settings that change findings, for cache keys.
+func (s *Settings) String() string {
+	return strings.Join([]string{
+		"severity=" + s.severity().String(),
+		"include=" + strings.Join(s.Include, ","),
+		"exclude=" + strings.Join(s.Exclude, ","),
+		"enable=" + strings.Join(s.Enable, ","),
+		"external-sources=" + strconv.FormatBool(s.ExternalSources),
+	}, " ")
+}
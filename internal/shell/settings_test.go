@@ -0,0 +1,155 @@
+package shell_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/rule"
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+func TestSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	settings := shell.Settings{Severity: "warning", Include: []string{"2086", "sc2046"}, Exclude: []string{" SC2164"}}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if !slices.Equal(settings.Include, []string{"SC2086", "SC2046"}) || !slices.Equal(settings.Exclude, []string{"SC2164"}) {
+		t.Errorf("Validate() normalized to include %v, exclude %v", settings.Include, settings.Exclude)
+	}
+
+	bad := shell.Settings{Severity: "fatal"}
+	if err := bad.Validate(); !errors.Is(err, shell.ErrInvalidSeverity) {
+		t.Errorf("Validate(severity fatal) error = %v, want ErrInvalidSeverity", err)
+	}
+
+	bad = shell.Settings{Exclude: []string{"DL3008"}}
+	if err := bad.Validate(); !errors.Is(err, shell.ErrInvalidCode) {
+		t.Errorf("Validate(exclude DL3008) error = %v, want ErrInvalidCode", err)
+	}
+}
+
+func TestBinaryShellchecker_UnsupportedSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings shell.Settings
+	}{
+		{"include", shell.Settings{Include: []string{"SC2086"}}},
+		{"enable", shell.Settings{Enable: []string{"all"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := shell.NewBinaryShellchecker()
+			checker.Settings = tt.settings
+			checker.Version = shell.Version{Major: 0, Minor: 6, Patch: 0}
+
+			if _, err := checker.Check("echo $FOO", shell.DefaultOpts()); !errors.Is(err, shell.ErrUnsupportedVersion) {
+				t.Errorf("Check() error = %v, want ErrUnsupportedVersion", err)
+			}
+		})
+	}
+}
+
+// argsShellcheck logs its arguments and reports nothing.
+const argsShellcheck = `#!/bin/sh
+echo "$@" >> "$FAKE_SHELLCHECK_LOG"
+printf '[]'
+`
+
+// Not parallel: it swaps PATH for a fake shellcheck.
+func TestBinaryShellchecker_SettingsArgs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "shellcheck"), []byte(argsShellcheck), 0o700); err != nil {
+		t.Fatalf("writing fake shellcheck: %v", err)
+	}
+
+	log := filepath.Join(dir, "args")
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_SHELLCHECK_LOG", log)
+
+	checker := shell.NewBinaryShellchecker()
+	checker.Settings = shell.Settings{
+		Severity:        "warning",
+		Include:         []string{"SC2086", "SC2046"},
+		Exclude:         []string{"SC2164"},
+		Enable:          []string{"require-variable-braces"},
+		ExternalSources: true,
+	}
+
+	if _, err := checker.Check("echo $FOO", shell.DefaultOpts()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	args, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatalf("reading args log: %v", err)
+	}
+
+	for _, want := range []string{
+		"--exclude=SC2187,SC1090,SC1091,SC2164",
+		"--severity=warning",
+		"--include=SC2086,SC2046",
+		"--enable=require-variable-braces",
+		"--external-sources",
+		"--shell=sh",
+	} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("shellcheck args = %q, want %s", args, want)
+		}
+	}
+}
+
+// Not parallel: it empties PATH to get the built-in checker.
+func TestNativeShellchecker_Settings(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	script := "cd /src; rm $(cat files) *.log"
+
+	tests := []struct {
+		name     string
+		settings shell.Settings
+		want     []rule.Code
+	}{
+		{"defaults", shell.Settings{}, []rule.Code{"SC2164", "SC2046", "SC2035"}},
+		{"severity floor", shell.Settings{Severity: "warning"}, []rule.Code{"SC2164", "SC2046"}},
+		{"include", shell.Settings{Include: []string{"2035"}}, []rule.Code{"SC2035"}},
+		{"exclude", shell.Settings{Exclude: []string{"SC2046"}}, []rule.Code{"SC2164", "SC2035"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, native, err := shell.NewShellchecker(tt.settings)
+			if err != nil || !native {
+				t.Fatalf("NewShellchecker() = native %v, error %v, want the built-in checker", native, err)
+			}
+
+			failures, err := checker.Check(script, shell.DefaultOpts())
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			var got []rule.Code
+			for _, failure := range failures {
+				got = append(got, failure.Code)
+			}
+
+			slices.Sort(got)
+
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("Check() codes = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,6 +28,8 @@ const shellcheckTimeout = 30 * time.Second
 type Opts struct {
 	ShellName string            // Shell command (e.g., "/bin/sh -c")
 	EnvVars   map[string]string // Environment variables to export
+	// Dialect the script is written in; derived from ShellName when empty.
+	Dialect Dialect
 }
 
 // DefaultOpts returns the default shell options.
@@ -58,11 +61,11 @@ type Shellchecker interface {
 
 // BinaryShellchecker shells out to the shellcheck binary.
 type BinaryShellchecker struct {
-	// RCFile, when set, is forwarded to shellcheck as --rcfile so the check
-	// uses that configuration instead of searching for one. Without it the
-	// script runs from a temp dir, so a repository's .shellcheckrc would
-	// never be found. Requires shellcheck >= 0.10.0.
-	RCFile string
+	// Settings are forwarded to shellcheck as command line options.
+	Settings
+	// Version is the installed shellcheck's, see DetectVersion. Options it
+	// does not support are not passed; the zero Version passes them all.
+	Version Version
 }
 
 // NewBinaryShellchecker creates a shellchecker that uses the shellcheck binary.
@@ -84,21 +87,17 @@ type shellcheckOutput struct {
 // Check runs shellcheck on the given script.
 // Ported from Hadolint.Shell.shellcheck.
 func (c *BinaryShellchecker) Check(script string, opts Opts) ([]rule.CheckFailure, error) {
-	// Skip non-POSIX shells (pwsh, powershell, cmd)
-	shellLower := strings.ToLower(opts.ShellName)
-	if strings.Contains(shellLower, "pwsh") ||
-		strings.Contains(shellLower, "powershell") ||
-		strings.Contains(shellLower, "cmd") {
+	shell := opts.dialect().shellcheckShell(c.Version)
+	if skipScript(script, opts) || shell == "" {
 		return nil, nil
 	}
 
-	// Skip if script has unsupported shebang
-	if hasUnsupportedShebang(script) {
-		return nil, nil
+	if err := c.checkOptions(); err != nil {
+		return nil, err
 	}
 
 	// Build complete script with shebang and exports
-	fullScript := buildScript(script, opts)
+	fullScript := buildScript(script, opts, shell)
 
 	// Write script to temp file
 	tmpFile, err := os.CreateTemp("", "shellcheck-*.sh")
@@ -117,21 +116,82 @@ func (c *BinaryShellchecker) Check(script string, opts Opts) ([]rule.CheckFailur
 		return nil, fmt.Errorf("failed to close temp file: %w", err)
 	}
 
-	// Run shellcheck with JSON output
-	// Exclude codes like hadolint does:
-	// - SC2187: ash shell not supported warning
-	// - SC1090: can't follow sourced files (requires shell directives)
-	// - SC1091: can't follow sourced files (requires shell directives)
+	scResults, err := c.run(shell, tmpFile.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return toFailures(scResults, opts), nil
+}
+
+// checkOptions fails when the configured options need a newer shellcheck.
+// They cannot be dropped silently: the findings would not follow the
+// configuration the user asked for.
+func (c *BinaryShellchecker) checkOptions() error {
+	unsupported := func(option string, required Version) error {
+		return fmt.Errorf("%w: shellcheck %s does not support %s (requires >= %s)",
+			ErrUnsupportedVersion, c.Version, option, required)
+	}
+
+	switch {
+	case c.RCFile != "" && !c.Version.SupportsRCFile():
+		return unsupported("--rcfile", RCFileVersion)
+	case c.Severity != "" && !c.Version.SupportsSeverity():
+		return unsupported("--severity", SeverityVersion)
+	case len(c.Include) > 0 && !c.Version.SupportsInclude():
+		return unsupported("--include", IncludeVersion)
+	case len(c.Enable) > 0 && !c.Version.SupportsEnable():
+		return unsupported("--enable", EnableVersion)
+	}
+
+	return nil
+}
+
+// skipScript reports whether the script cannot be checked: non-POSIX shells
+// (pwsh, powershell, cmd), other interpreters, and unsupported shebangs.
+func skipScript(script string, opts Opts) bool {
+	if !opts.dialect().IsShell() {
+		return true
+	}
+
+	return hasUnsupportedShebang(script)
+}
+
+// run invokes shellcheck once over the given files and decodes its findings.
+// The files are checked as the given shell (--shell) when set; otherwise
+// shellcheck follows each file's shebang.
+func (c *BinaryShellchecker) run(shell string, files ...string) ([]shellcheckOutput, error) {
+	// Run shellcheck with JSON output, excluding codes like hadolint does
 	args := []string{
 		"--format=json",
-		"--exclude=SC2187,SC1090,SC1091",
-		"--severity=style", // Minimum severity (matches hadolint)
+		"--exclude=" + strings.Join(c.excludes(), ","),
+	}
+	if c.Version.SupportsSeverity() {
+		// Minimum severity, style by default (matches hadolint)
+		args = append(args, "--severity="+c.severity().String())
 	}
+
+	if len(c.Include) > 0 {
+		args = append(args, "--include="+strings.Join(c.Include, ","))
+	}
+
+	if len(c.Enable) > 0 {
+		args = append(args, "--enable="+strings.Join(c.Enable, ","))
+	}
+
+	if c.ExternalSources {
+		args = append(args, "--external-sources")
+	}
+
+	if shell != "" {
+		args = append(args, "--shell="+shell)
+	}
+
 	if c.RCFile != "" {
 		args = append(args, "--rcfile="+c.RCFile)
 	}
 
-	args = append(args, tmpFile.Name())
+	args = append(args, files...)
 
 	// No caller context reaches this point (the rule fold carries none), but
 	// a wedged shellcheck must not hang the whole lint run: bound it. A kill
@@ -161,12 +221,17 @@ func (c *BinaryShellchecker) Check(script string, opts Opts) ([]rule.CheckFailur
 		}
 	}
 
-	// Convert to CheckFailures. shellcheck reports positions within the
-	// synthesized script; subtract the header (shebang + exports) to get the
-	// 0-based offset within the original script, which the rule anchors to
-	// the instruction's Dockerfile line. Today the parser collapses a RUN
-	// command onto one line, so the offset is 0 in practice — but any
-	// multi-line command (e.g. future heredoc support) maps correctly.
+	return scResults, nil
+}
+
+// toFailures converts the findings of one script to CheckFailures.
+// shellcheck reports positions within the synthesized script; subtract the
+// header (shebang + exports) to get the 0-based offset within the original
+// script, which the rule anchors to the instruction's Dockerfile line. Today
+// the parser collapses a RUN command onto one line, so the offset is 0 in
+// practice — but any multi-line command (e.g. future heredoc support) maps
+// correctly.
+func toFailures(scResults []shellcheckOutput, opts Opts) []rule.CheckFailure {
 	headerLines := 1 + len(opts.EnvVars)
 
 	var failures []rule.CheckFailure
@@ -195,18 +260,20 @@ func (c *BinaryShellchecker) Check(script string, opts Opts) ([]rule.CheckFailur
 		})
 	}
 
-	return failures, nil
+	return failures
 }
 
-// buildScript constructs the complete script to pass to shellcheck.
+// buildScript constructs the complete script to pass to shellcheck, with a
+// shebang naming the shellcheck shell, so that files of a mixed batch are
+// each checked in their dialect.
 // Ported from the script construction in Hadolint.Shell.shellcheck.
-func buildScript(runCommand string, opts Opts) string {
+func buildScript(runCommand string, opts Opts, shell string) string {
 	var build strings.Builder
 
-	// Add shebang from shell option
-	shebang := extractShell(opts.ShellName)
-	if shebang == "" {
-		shebang = "/bin/sh"
+	// Add shebang from the script dialect
+	shebang := "/bin/" + shell
+	if shell == "busybox" {
+		shebang = "/bin/busybox sh"
 	}
 
 	_, _ = build.WriteString("#!")
@@ -226,19 +293,6 @@ func buildScript(runCommand string, opts Opts) string {
 	return build.String()
 }
 
-// extractShell extracts the shell path from shell command.
-// Ported from extractShell in Hadolint.Shell.
-func extractShell(shellCmd string) string {
-	// Take first word from shell command
-	// E.g., "/bin/bash -c" -> "/bin/bash"
-	parts := strings.Fields(shellCmd)
-	if len(parts) > 0 {
-		return parts[0]
-	}
-
-	return ""
-}
-
 // hasUnsupportedShebang checks if script starts with an unsupported shebang.
 // Ported from Hadolint.Shell.hasUnsupportedShebang.
 func hasUnsupportedShebang(script string) bool {
@@ -290,6 +344,19 @@ type ShellcheckRule struct {
 type shellState struct {
 	opts        Opts
 	defaultOpts Opts
+	// stages tracks the dialect of each stage's RUN instructions.
+	stages StageDialects
+	// pending holds the RUN scripts deferred to Finalize when the checker
+	// is a BatchShellchecker.
+	pending []pendingScript
+}
+
+// pendingScript is a deferred RUN script, the line of its instruction, and
+// the offset of the script from it (1 for a here-document).
+type pendingScript struct {
+	line   int
+	offset int
+	script Script
 }
 
 // NewShellcheckRule creates a new shellcheck rule.
@@ -299,6 +366,16 @@ func NewShellcheckRule(checker Shellchecker) *ShellcheckRule {
 	}
 }
 
+// Checker returns the shellchecker the rule runs.
+func (r *ShellcheckRule) Checker() Shellchecker {
+	return r.checker
+}
+
+// Identity is the checker's, so that cached lint results change with it.
+func (r *ShellcheckRule) Identity() string {
+	return Identity(r.checker)
+}
+
 // Code returns the rule code.
 func (*ShellcheckRule) Code() rule.Code {
 	return "SHELLCHECK"
@@ -333,80 +410,85 @@ func (r *ShellcheckRule) Check(line int, state rule.State, instruction syntax.In
 	shState := rule.Data[shellState](state)
 
 	switch instr := instruction.(type) {
+	case *syntax.Directive:
+		shState.stages = shState.stages.Directive(instr)
+
+		return state.ReplaceData(shState)
+
 	case *syntax.From:
 		// New stage - reset to default options
-		return state.ReplaceData(shellState{
-			opts:        shState.defaultOpts,
-			defaultOpts: shState.defaultOpts,
-		})
+		shState.opts = shState.defaultOpts
+		shState.stages = shState.stages.From(instr)
+
+		return state.ReplaceData(shState)
 
 	case *syntax.Arg:
-		// Add ARG to environment variables
-		newOpts := shState.opts
-		if newOpts.EnvVars == nil {
-			newOpts.EnvVars = make(map[string]string)
+		// Add ARG to environment variables, on a copy
+		envCopy := maps.Clone(shState.opts.EnvVars)
+		if envCopy == nil {
+			envCopy = make(map[string]string)
 		}
-		// Copy existing vars
-		envCopy := make(map[string]string)
-		maps.Copy(envCopy, shState.opts.EnvVars)
 
 		envCopy[instr.ArgName] = "1"
-		newOpts.EnvVars = envCopy
+		shState.opts.EnvVars = envCopy
 
-		return state.ReplaceData(shellState{
-			opts:        newOpts,
-			defaultOpts: shState.defaultOpts,
-		})
+		return state.ReplaceData(shState)
 
 	case *syntax.Env:
-		// Add ENV variables
-		newOpts := shState.opts
-		if newOpts.EnvVars == nil {
-			newOpts.EnvVars = make(map[string]string)
+		// Add ENV variables, on a copy
+		envCopy := maps.Clone(shState.opts.EnvVars)
+		if envCopy == nil {
+			envCopy = make(map[string]string)
 		}
-		// Copy existing vars
-		envCopy := make(map[string]string)
-		maps.Copy(envCopy, shState.opts.EnvVars)
 
 		for _, pair := range instr.Pairs {
 			envCopy[pair.Key] = "1"
 		}
 
-		newOpts.EnvVars = envCopy
+		shState.opts.EnvVars = envCopy
 
-		return state.ReplaceData(shellState{
-			opts:        newOpts,
-			defaultOpts: shState.defaultOpts,
-		})
+		return state.ReplaceData(shState)
 
 	case *syntax.Shell:
 		// Update shell command
 		if len(instr.Arguments) > 0 {
-			shellCmd := strings.Join(instr.Arguments, " ")
-			newOpts := shState.opts
-			newOpts.ShellName = shellCmd
+			shState.opts.ShellName = strings.Join(instr.Arguments, " ")
+			shState.stages = shState.stages.Shell(instr)
 
-			return state.ReplaceData(shellState{
-				opts:        newOpts,
-				defaultOpts: shState.defaultOpts,
-			})
+			return state.ReplaceData(shState)
 		}
 
 	case *syntax.Run:
+		script, dialect, offset := RunScript(instr, shState.stages.Dialect())
+		opts := shState.opts
+		opts.Dialect = dialect
+
+		// A batching checker sees every RUN of the Dockerfile at once, in
+		// Finalize.
+		if _, ok := r.checker.(BatchShellchecker); ok {
+			shState.pending = append(slices.Clip(shState.pending), pendingScript{
+				line:   line,
+				offset: offset,
+				script: Script{Text: script, Opts: opts},
+			})
+
+			return state.ReplaceData(shState)
+		}
+
 		// Run shellcheck on the command
-		violations, err := r.checker.Check(instr.Command, shState.opts)
+		violations, err := r.checker.Check(script, opts)
 		if err != nil {
-			// Log error but don't fail the rule
-			// (matching hadolint behavior - shellcheck failures are not fatal)
-			return state
+			// Not fatal to the lint run (matching hadolint), but reported:
+			// the RUN is unchecked, not clean.
+			return state.AddError(line, err)
 		}
 
-		// Add all shellcheck violations to state, anchored to the RUN's line
-		// (each violation carries its 0-based offset within the command).
+		// Add all shellcheck violations to state, anchored to the script's
+		// first line (each violation carries its 0-based offset within it).
 		newState := state
 
 		for _, v := range violations {
-			v.Line += line
+			v.Line += line + offset
 			newState = newState.AddFailure(v)
 		}
 
@@ -419,9 +501,42 @@ func (r *ShellcheckRule) Check(line int, state rule.State, instruction syntax.In
 	return state
 }
 
-// Finalize performs final checks after processing all instructions.
-func (*ShellcheckRule) Finalize(state rule.State) rule.State {
-	return state // No finalization needed
+// Finalize checks the RUN scripts deferred for a BatchShellchecker.
+func (r *ShellcheckRule) Finalize(state rule.State) rule.State {
+	batcher, ok := r.checker.(BatchShellchecker)
+	if !ok {
+		return state
+	}
+
+	pending := rule.Data[shellState](state).pending
+	if len(pending) == 0 {
+		return state
+	}
+
+	scripts := make([]Script, len(pending))
+	for i, p := range pending {
+		scripts[i] = p.script
+	}
+
+	results, err := batcher.CheckBatch(scripts)
+	if err != nil {
+		// Non-fatal, like a failing per-RUN check, but every deferred RUN
+		// is unchecked.
+		for _, p := range pending {
+			state = state.AddError(p.line, err)
+		}
+
+		return state
+	}
+
+	for i, violations := range results {
+		for _, v := range violations {
+			v.Line += pending[i].line + pending[i].offset
+			state = state.AddFailure(v)
+		}
+	}
+
+	return state
 }
 
 // NoopShellchecker is a no-op implementation for when shellcheck is not available.
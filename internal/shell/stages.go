@@ -0,0 +1,189 @@
+// This file follows the shell dialect of RUN instructions through the stages
+// of a Dockerfile. The package godoc lives in parser.go.
+
+package shell
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/farcloser/godolint/internal/syntax"
+)
+
+// stageShell is what determines the dialect of a stage's RUN instructions.
+type stageShell struct {
+	image   Dialect // /bin/sh of the base image
+	shell   string  // SHELL instruction, inherited by stages built FROM this one
+	windows bool    // Windows base image, or a Windows-only SHELL
+}
+
+// StageDialects tracks the dialect RUN instructions run in, stage by stage:
+// the SHELL instruction when it names a specific shell, the base image's
+// /bin/sh otherwise. A stage built FROM another inherits its image and
+// SHELL. The zero value is ready to use; methods return updated copies, so
+// it can be kept in rule state.
+//
+// It also tells Windows stages apart: Windows base images, stages whose
+// SHELL is powershell or cmd, and, when the Dockerfile sets "# escape=`",
+// stages built from images it cannot place.
+type StageDialects struct {
+	current  stageShell
+	alias    string
+	stages   map[string]stageShell
+	backtick bool
+}
+
+// Directive applies a parser directive: "# escape=`" marks a Dockerfile
+// written for Windows.
+func (s StageDialects) Directive(directive *syntax.Directive) StageDialects {
+	if directive.Key == "escape" {
+		s.backtick = directive.Value == "`"
+	}
+
+	return s
+}
+
+// From starts a new stage.
+func (s StageDialects) From(from *syntax.From) StageDialects {
+	next := StageDialects{stages: s.stages, backtick: s.backtick}
+
+	if parent, ok := s.stages[strings.ToLower(from.Image.Image)]; ok {
+		next.current = parent
+	} else {
+		tag := ""
+		if from.Image.Tag != nil {
+			tag = *from.Image.Tag
+		}
+
+		image := ImageDialect(from.Image.Image, tag)
+		if image == DialectSh && s.backtick {
+			image = DialectCmd
+		}
+
+		next.current = stageShell{image: image, windows: image == DialectCmd}
+	}
+
+	if from.Image.Alias != nil {
+		next.alias = strings.ToLower(*from.Image.Alias)
+		next.record()
+	}
+
+	return next
+}
+
+// Shell applies a SHELL instruction to the current stage.
+func (s StageDialects) Shell(shell *syntax.Shell) StageDialects {
+	if len(shell.Arguments) == 0 {
+		return s
+	}
+
+	s.current.shell = strings.Join(shell.Arguments, " ")
+	s.current.windows = s.current.windows || isWindowsInterpreter(shell.Arguments[0])
+	s.record()
+
+	return s
+}
+
+// record saves the current stage under its alias, copying the map so that
+// earlier copies of s are unaffected.
+func (s *StageDialects) record() {
+	if s.alias == "" {
+		return
+	}
+
+	stages := maps.Clone(s.stages)
+	if stages == nil {
+		stages = make(map[string]stageShell)
+	}
+
+	stages[s.alias] = s.current
+	s.stages = stages
+}
+
+// Dialect returns the dialect of the current stage's RUN instructions.
+func (s StageDialects) Dialect() Dialect {
+	if s.current.shell != "" {
+		// SHELL ["/bin/sh", "-c"] is still the image's sh.
+		if dialect := InterpreterDialect(s.current.shell); dialect != DialectSh {
+			return dialect
+		}
+	}
+
+	if s.current.image == "" {
+		return DialectSh
+	}
+
+	return s.current.image
+}
+
+// Windows reports whether the current stage runs on Windows.
+func (s StageDialects) Windows() bool {
+	return s.current.windows
+}
+
+// Interpreter returns the SHELL command line of the current stage, or "" when
+// it uses the image's default shell.
+func (s StageDialects) Interpreter() string {
+	return s.current.shell
+}
+
+// isWindowsInterpreter reports whether the program only exists on Windows:
+// Windows PowerShell and cmd. PowerShell Core (pwsh) also runs on Linux.
+func isWindowsInterpreter(program string) bool {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(program, `\`, "/")))
+
+	return slices.Contains([]string{"powershell", "powershell.exe", "cmd", "cmd.exe"}, name)
+}
+
+// RunScript returns the script a RUN instruction runs in a stage of the
+// given dialect, its dialect, and the line of the Dockerfile it starts on,
+// relative to the instruction's. That is the command itself, except for a
+// single here-document fed to the shell (RUN <<EOF) or to an interpreter
+// (RUN <<EOF python3): its body, starting on the next line, in the dialect
+// its shebang or interpreter names. The shebang line is blanked out, so that
+// line offsets still hold.
+func RunScript(run *syntax.Run, dialect Dialect) (script string, scriptDialect Dialect, offset int) {
+	if len(run.Heredocs) != 1 {
+		return run.Command, dialect, 0
+	}
+
+	heredoc := run.Heredocs[0]
+
+	fields := strings.Fields(run.Command)
+	if len(fields) == 0 || !isHeredocMarker(fields[0], heredoc.Name) {
+		return run.Command, dialect, 0
+	}
+
+	if len(fields) > 1 {
+		dialect = InterpreterDialect(strings.Join(fields[1:], " "))
+	}
+
+	script = heredoc.Content
+	if shebang, ok := ScriptDialect(script); ok {
+		dialect = shebang
+
+		if _, rest, found := strings.Cut(script, "\n"); found {
+			script = "\n" + rest
+		} else {
+			script = ""
+		}
+	}
+
+	return script, dialect, 1
+}
+
+// isHeredocMarker reports whether word opens the here-document named name:
+// <<EOF, <<-EOF, <<"EOF" or <<'EOF'.
+func isHeredocMarker(word, name string) bool {
+	marker, ok := strings.CutPrefix(word, "<<")
+	if !ok {
+		return false
+	}
+
+	marker = strings.TrimPrefix(marker, "-")
+	marker = strings.Trim(marker, `"'`)
+
+	return marker == name
+}
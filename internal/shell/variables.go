@@ -0,0 +1,165 @@
+package shell
+
+import (
+	"regexp"
+	"slices"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Reference is a variable a script expands.
+type Reference struct {
+	// Name is the variable name.
+	Name string
+	// Line is the 0-based line offset within the script.
+	Line int
+}
+
+// Variables are the variables a script expands, and those it sets itself.
+type Variables struct {
+	// Expanded are the variables expanded with no fallback for an unset
+	// value (${VAR:-default}, ${VAR?} and the like have one), at their first
+	// use. Positional and special parameters are left out.
+	Expanded []Reference
+	// Set are the variables the script assigns: assignments, export, local,
+	// for loops, read, getopts, mapfile, printf -v and arithmetic.
+	Set []string
+}
+
+// identifierPattern matches the names of variables, as opposed to
+// positional ($1) and special ($?, $@) parameters.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// readingCommands are the commands setting the variables they are given as
+// operands.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var readingCommands = []string{"read", "getopts", "mapfile", "readarray"}
+
+// ScriptVariables returns the variables a script expands and sets. Scripts
+// that do not parse even as bash yield an error.
+func ScriptVariables(script string) (Variables, error) {
+	file, err := parseDialect(script, DialectBash)
+	if err != nil {
+		return Variables{}, err
+	}
+
+	var (
+		vars Variables
+		seen = make(map[string]bool)
+	)
+
+	set := func(name string) {
+		if identifierPattern.MatchString(name) && !slices.Contains(vars.Set, name) {
+			vars.Set = append(vars.Set, name)
+		}
+	}
+
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch node := node.(type) {
+		case *syntax.ParamExp:
+			if node.Param == nil || node.Excl || hasFallback(node) ||
+				!identifierPattern.MatchString(node.Param.Value) || seen[node.Param.Value] {
+				return true
+			}
+
+			seen[node.Param.Value] = true
+			vars.Expanded = append(vars.Expanded, Reference{
+				Name: node.Param.Value,
+				Line: int(node.Pos().Line()) - 1,
+			})
+		case *syntax.Assign:
+			if node.Name != nil {
+				set(node.Name.Value)
+			}
+		case *syntax.ForClause:
+			if iter, ok := node.Loop.(*syntax.WordIter); ok {
+				set(iter.Name.Value)
+			}
+		case *syntax.BinaryArithm:
+			if isArithmAssign(node.Op) {
+				set(arithmName(node.X))
+			}
+		case *syntax.UnaryArithm:
+			if node.Op == syntax.Inc || node.Op == syntax.Dec {
+				set(arithmName(node.X))
+			}
+		case *syntax.CallExpr:
+			for _, name := range setOperands(node) {
+				set(name)
+			}
+		}
+
+		return true
+	})
+
+	return vars, nil
+}
+
+// hasFallback reports whether an expansion handles an unset variable.
+func hasFallback(param *syntax.ParamExp) bool {
+	if param.Exp == nil {
+		return false
+	}
+
+	switch param.Exp.Op {
+	case syntax.AlternateUnset, syntax.AlternateUnsetOrNull,
+		syntax.DefaultUnset, syntax.DefaultUnsetOrNull,
+		syntax.ErrorUnset, syntax.ErrorUnsetOrNull,
+		syntax.AssignUnset, syntax.AssignUnsetOrNull:
+		return true
+	default:
+		return false
+	}
+}
+
+// isArithmAssign reports whether an arithmetic operator assigns its left
+// operand (i = 1, i += 1...).
+func isArithmAssign(op syntax.BinAritOperator) bool {
+	switch op {
+	case syntax.Assgn, syntax.AddAssgn, syntax.SubAssgn, syntax.MulAssgn,
+		syntax.QuoAssgn, syntax.RemAssgn, syntax.AndAssgn, syntax.OrAssgn,
+		syntax.XorAssgn, syntax.ShlAssgn, syntax.ShrAssgn:
+		return true
+	default:
+		return false
+	}
+}
+
+// arithmName returns the variable an arithmetic operand names, if any.
+func arithmName(expr syntax.ArithmExpr) string {
+	if word, ok := expr.(*syntax.Word); ok {
+		return word.Lit()
+	}
+
+	return ""
+}
+
+// setOperands returns the names a command sets: the operands of read,
+// getopts, mapfile and readarray, and the name printf -v writes to. Flag
+// values (read -p prompt) are returned too, and dropped by the caller when
+// they are not names; a few extra names cannot hide an expansion.
+func setOperands(call *syntax.CallExpr) []string {
+	if len(call.Args) < 2 {
+		return nil
+	}
+
+	var names []string
+
+	program := call.Args[0].Lit()
+
+	switch {
+	case slices.Contains(readingCommands, program):
+		for _, arg := range call.Args[1:] {
+			names = append(names, arg.Lit())
+		}
+	case program == "printf":
+		for i, arg := range call.Args[1 : len(call.Args)-1] {
+			if arg.Lit() == "-v" {
+				names = append(names, call.Args[i+2].Lit())
+			}
+		}
+	}
+
+	return names
+}
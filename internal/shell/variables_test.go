@@ -0,0 +1,36 @@
+package shell_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+func TestScriptVariables(t *testing.T) {
+	t.Parallel()
+
+	vars, err := shell.ScriptVariables(`set -e
+echo "$APP_DIR" ${VERSION} $1 $? '$QUOTED' ${OPTIONAL:-none} ${REQUIRED?} $APP_DIR
+COUNT=0; export MODE=release; local scope
+for file in *.txt; do cat "$file"; done
+read -r line; getopts ab opt; printf -v out '%s' x; ((total += 1)); ((step++))`)
+	if err != nil {
+		t.Fatalf("ScriptVariables() error = %v", err)
+	}
+
+	want := []shell.Reference{{Name: "APP_DIR", Line: 1}, {Name: "VERSION", Line: 1}, {Name: "file", Line: 3}}
+	if !slices.Equal(vars.Expanded, want) {
+		t.Errorf("Expanded = %v, want %v", vars.Expanded, want)
+	}
+
+	for _, name := range []string{"COUNT", "MODE", "scope", "file", "line", "opt", "out", "total", "step"} {
+		if !slices.Contains(vars.Set, name) {
+			t.Errorf("Set = %v, want %s in it", vars.Set, name)
+		}
+	}
+
+	if _, err := shell.ScriptVariables("echo $("); err == nil {
+		t.Error("ScriptVariables() error = nil, want a parse error")
+	}
+}
@@ -0,0 +1,130 @@
+// This file detects the shellcheck version, to only pass the options the
+// installed binary supports. The package godoc lives in parser.go.
+
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// ErrUnsupportedVersion reports an option the installed shellcheck is
+	// too old for.
+	ErrUnsupportedVersion = errors.New("unsupported shellcheck version")
+	// ErrUnknownVersion reports `shellcheck --version` output without a
+	// recognizable version.
+	ErrUnknownVersion = errors.New("cannot determine shellcheck version")
+)
+
+// versionLine matches the "version: 0.10.0" line of `shellcheck --version`.
+var versionLine = regexp.MustCompile(`(?m)^version:\s*v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// Version is a shellcheck release. The zero Version stands for an unknown
+// one, assumed to support every option.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// Minimum versions of the options godolint passes.
+//
+//nolint:gochecknoglobals // read-only version constants.
+var (
+	// RCFileVersion introduced --rcfile.
+	RCFileVersion = Version{Major: 0, Minor: 10, Patch: 0}
+	// SeverityVersion introduced --severity.
+	SeverityVersion = Version{Major: 0, Minor: 4, Patch: 5}
+	// IncludeVersion introduced --include.
+	IncludeVersion = Version{Major: 0, Minor: 7, Patch: 0}
+	// EnableVersion introduced --enable and the optional checks.
+	EnableVersion = Version{Major: 0, Minor: 7, Patch: 0}
+)
+
+// String formats the version as shellcheck does.
+func (v Version) String() string {
+	if v.IsZero() {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// IsZero reports whether the version is unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// AtLeast reports whether v is other or newer. An unknown version is
+// assumed recent enough.
+func (v Version) AtLeast(other Version) bool {
+	if v.IsZero() {
+		return true
+	}
+
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+
+	return v.Patch >= other.Patch
+}
+
+// SupportsRCFile reports whether --rcfile is available.
+func (v Version) SupportsRCFile() bool {
+	return v.AtLeast(RCFileVersion)
+}
+
+// SupportsSeverity reports whether --severity is available.
+func (v Version) SupportsSeverity() bool {
+	return v.AtLeast(SeverityVersion)
+}
+
+// SupportsInclude reports whether --include is available.
+func (v Version) SupportsInclude() bool {
+	return v.AtLeast(IncludeVersion)
+}
+
+// SupportsEnable reports whether --enable is available.
+func (v Version) SupportsEnable() bool {
+	return v.AtLeast(EnableVersion)
+}
+
+// ParseVersion reads the output of `shellcheck --version`.
+func ParseVersion(output string) (Version, error) {
+	match := versionLine.FindStringSubmatch(output)
+	if match == nil {
+		return Version{}, ErrUnknownVersion
+	}
+
+	version := Version{}
+	version.Major, _ = strconv.Atoi(match[1])
+	version.Minor, _ = strconv.Atoi(match[2])
+
+	if match[3] != "" {
+		version.Patch, _ = strconv.Atoi(match[3])
+	}
+
+	return version, nil
+}
+
+// DetectVersion runs `shellcheck --version` from PATH.
+func DetectVersion() (Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shellcheckTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "shellcheck", "--version").Output()
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to run shellcheck --version: %w", err)
+	}
+
+	return ParseVersion(string(output))
+}
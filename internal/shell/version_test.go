@@ -0,0 +1,70 @@
+package shell_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	output := "ShellCheck - shell script analysis tool\nversion: 0.10.0\nlicense: GNU General Public License, version 3\n"
+
+	version, err := shell.ParseVersion(output)
+	if err != nil {
+		t.Fatalf("ParseVersion() error = %v", err)
+	}
+
+	if version != (shell.Version{Major: 0, Minor: 10, Patch: 0}) {
+		t.Errorf("ParseVersion() = %v, want 0.10.0", version)
+	}
+
+	if _, err := shell.ParseVersion("shellcheck, some fork\n"); !errors.Is(err, shell.ErrUnknownVersion) {
+		t.Errorf("ParseVersion() error = %v, want ErrUnknownVersion", err)
+	}
+}
+
+func TestVersion_Supports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version  shell.Version
+		rcfile   bool
+		severity bool
+	}{
+		{shell.Version{Major: 0, Minor: 10, Patch: 0}, true, true},
+		{shell.Version{Major: 0, Minor: 9, Patch: 0}, false, true},
+		{shell.Version{Major: 0, Minor: 4, Patch: 4}, false, false},
+		{shell.Version{Major: 1, Minor: 0, Patch: 0}, true, true},
+		{shell.Version{}, true, true}, // unknown: assumed recent
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version.String(), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.version.SupportsRCFile(); got != tt.rcfile {
+				t.Errorf("SupportsRCFile() = %v, want %v", got, tt.rcfile)
+			}
+
+			if got := tt.version.SupportsSeverity(); got != tt.severity {
+				t.Errorf("SupportsSeverity() = %v, want %v", got, tt.severity)
+			}
+		})
+	}
+}
+
+func TestBinaryShellchecker_UnsupportedRCFile(t *testing.T) {
+	t.Parallel()
+
+	checker := shell.NewBinaryShellchecker()
+	checker.RCFile = ".shellcheckrc"
+	checker.Version = shell.Version{Major: 0, Minor: 9, Patch: 0}
+
+	// The rcfile must not be dropped silently.
+	if _, err := checker.Check("echo $FOO", shell.DefaultOpts()); !errors.Is(err, shell.ErrUnsupportedVersion) {
+		t.Errorf("Check() error = %v, want ErrUnsupportedVersion", err)
+	}
+}
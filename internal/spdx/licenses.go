@@ -0,0 +1,193 @@
+package spdx
+
+// licenseIDs are the identifiers of the SPDX License List 3.23, deprecated
+// ones included (GPL-2.0, LGPL-2.1+...): Cabal, which hadolint validates
+// with, accepts them.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var licenseIDs = map[string]bool{
+	"0BSD": true, "AAL": true, "Abstyles": true, "AdaCore-doc": true, "Adobe-2006": true,
+	"Adobe-Display-PostScript": true, "Adobe-Glyph": true, "Adobe-Utopia": true, "ADSL": true,
+	"AFL-1.1": true, "AFL-1.2": true, "AFL-2.0": true, "AFL-2.1": true, "AFL-3.0": true,
+	"Afmparse": true, "AGPL-1.0": true, "AGPL-1.0-only": true, "AGPL-1.0-or-later": true,
+	"AGPL-3.0": true, "AGPL-3.0-only": true, "AGPL-3.0-or-later": true, "Aladdin": true,
+	"AMDPLPA": true, "AML": true, "AML-glslang": true, "AMPAS": true, "ANTLR-PD": true,
+	"ANTLR-PD-fallback": true, "Apache-1.0": true, "Apache-1.1": true, "Apache-2.0": true,
+	"APAFML": true, "APL-1.0": true, "App-s2p": true, "APSL-1.0": true, "APSL-1.1": true,
+	"APSL-1.2": true, "APSL-2.0": true, "Arphic-1999": true, "Artistic-1.0": true,
+	"Artistic-1.0-cl8": true, "Artistic-1.0-Perl": true, "Artistic-2.0": true,
+	"ASWF-Digital-Assets-1.0": true, "ASWF-Digital-Assets-1.1": true, "Baekmuk": true, "Bahyph": true,
+	"Barr": true, "bcrypt-Solar-Designer": true, "Beerware": true, "Bitstream-Charter": true,
+	"Bitstream-Vera": true, "BitTorrent-1.0": true, "BitTorrent-1.1": true, "blessing": true,
+	"BlueOak-1.0.0": true, "Boehm-GC": true, "Borceux": true, "Brian-Gladman-2-Clause": true,
+	"Brian-Gladman-3-Clause": true, "BSD-1-Clause": true, "BSD-2-Clause": true,
+	"BSD-2-Clause-Darwin": true, "BSD-2-Clause-FreeBSD": true, "BSD-2-Clause-NetBSD": true,
+	"BSD-2-Clause-Patent": true, "BSD-2-Clause-Views": true, "BSD-3-Clause": true,
+	"BSD-3-Clause-acpica": true, "BSD-3-Clause-Attribution": true, "BSD-3-Clause-Clear": true,
+	"BSD-3-Clause-flex": true, "BSD-3-Clause-HP": true, "BSD-3-Clause-LBNL": true,
+	"BSD-3-Clause-Modification": true, "BSD-3-Clause-No-Military-License": true,
+	"BSD-3-Clause-No-Nuclear-License": true, "BSD-3-Clause-No-Nuclear-License-2014": true,
+	"BSD-3-Clause-No-Nuclear-Warranty": true, "BSD-3-Clause-Open-MPI": true, "BSD-3-Clause-Sun": true,
+	"BSD-4-Clause": true, "BSD-4-Clause-Shortened": true, "BSD-4-Clause-UC": true,
+	"BSD-4.3RENO": true, "BSD-4.3TAHOE": true, "BSD-Advertising-Acknowledgement": true,
+	"BSD-Attribution-HPND-disclaimer": true, "BSD-Inferno-Nettverk": true, "BSD-Protection": true,
+	"BSD-Source-beginning-file": true, "BSD-Source-Code": true, "BSD-Systemics": true,
+	"BSD-Systemics-W3Works": true, "BSL-1.0": true, "BUSL-1.1": true, "bzip2-1.0.5": true,
+	"bzip2-1.0.6": true, "C-UDA-1.0": true, "CAL-1.0": true, "CAL-1.0-Combined-Work-Exception": true,
+	"Caldera": true, "Caldera-no-preamble": true, "CATOSL-1.1": true, "CC-BY-1.0": true,
+	"CC-BY-2.0": true, "CC-BY-2.5": true, "CC-BY-2.5-AU": true, "CC-BY-3.0": true,
+	"CC-BY-3.0-AT": true, "CC-BY-3.0-AU": true, "CC-BY-3.0-DE": true, "CC-BY-3.0-IGO": true,
+	"CC-BY-3.0-NL": true, "CC-BY-3.0-US": true, "CC-BY-4.0": true, "CC-BY-NC-1.0": true,
+	"CC-BY-NC-2.0": true, "CC-BY-NC-2.5": true, "CC-BY-NC-3.0": true, "CC-BY-NC-3.0-DE": true,
+	"CC-BY-NC-4.0": true, "CC-BY-NC-ND-1.0": true, "CC-BY-NC-ND-2.0": true, "CC-BY-NC-ND-2.5": true,
+	"CC-BY-NC-ND-3.0": true, "CC-BY-NC-ND-3.0-DE": true, "CC-BY-NC-ND-3.0-IGO": true,
+	"CC-BY-NC-ND-4.0": true, "CC-BY-NC-SA-1.0": true, "CC-BY-NC-SA-2.0": true,
+	"CC-BY-NC-SA-2.0-DE": true, "CC-BY-NC-SA-2.0-FR": true, "CC-BY-NC-SA-2.0-UK": true,
+	"CC-BY-NC-SA-2.5": true, "CC-BY-NC-SA-3.0": true, "CC-BY-NC-SA-3.0-DE": true,
+	"CC-BY-NC-SA-3.0-IGO": true, "CC-BY-NC-SA-4.0": true, "CC-BY-ND-1.0": true, "CC-BY-ND-2.0": true,
+	"CC-BY-ND-2.5": true, "CC-BY-ND-3.0": true, "CC-BY-ND-3.0-DE": true, "CC-BY-ND-4.0": true,
+	"CC-BY-SA-1.0": true, "CC-BY-SA-2.0": true, "CC-BY-SA-2.0-UK": true, "CC-BY-SA-2.1-JP": true,
+	"CC-BY-SA-2.5": true, "CC-BY-SA-3.0": true, "CC-BY-SA-3.0-AT": true, "CC-BY-SA-3.0-DE": true,
+	"CC-BY-SA-3.0-IGO": true, "CC-BY-SA-4.0": true, "CC-PDDC": true, "CC0-1.0": true,
+	"CDDL-1.0": true, "CDDL-1.1": true, "CDL-1.0": true, "CDLA-Permissive-1.0": true,
+	"CDLA-Permissive-2.0": true, "CDLA-Sharing-1.0": true, "CECILL-1.0": true, "CECILL-1.1": true,
+	"CECILL-2.0": true, "CECILL-2.1": true, "CECILL-B": true, "CECILL-C": true, "CERN-OHL-1.1": true,
+	"CERN-OHL-1.2": true, "CERN-OHL-P-2.0": true, "CERN-OHL-S-2.0": true, "CERN-OHL-W-2.0": true,
+	"CFITSIO": true, "check-cvs": true, "checkmk": true, "ClArtistic": true, "Clips": true,
+	"CMU-Mach": true, "CMU-Mach-nodoc": true, "CNRI-Jython": true, "CNRI-Python": true,
+	"CNRI-Python-GPL-Compatible": true, "COIL-1.0": true, "Community-Spec-1.0": true,
+	"Condor-1.1": true, "copyleft-next-0.3.0": true, "copyleft-next-0.3.1": true,
+	"Cornell-Lossless-JPEG": true, "CPAL-1.0": true, "CPL-1.0": true, "CPOL-1.02": true,
+	"Cronyx": true, "Crossword": true, "CrystalStacker": true, "CUA-OPL-1.0": true, "Cube": true,
+	"curl": true, "D-FSL-1.0": true, "DEC-3-Clause": true, "diffmark": true, "DL-DE-BY-2.0": true,
+	"DL-DE-ZERO-2.0": true, "DOC": true, "Dotseqn": true, "DRL-1.0": true, "DRL-1.1": true,
+	"DSDP": true, "dtoa": true, "dvipdfm": true, "ECL-1.0": true, "ECL-2.0": true, "eCos-2.0": true,
+	"EFL-1.0": true, "EFL-2.0": true, "eGenix": true, "Elastic-2.0": true, "Entessa": true,
+	"EPICS": true, "EPL-1.0": true, "EPL-2.0": true, "ErlPL-1.1": true, "etalab-2.0": true,
+	"EUDatagrid": true, "EUPL-1.0": true, "EUPL-1.1": true, "EUPL-1.2": true, "Eurosym": true,
+	"Fair": true, "FBM": true, "FDK-AAC": true, "Ferguson-Twofish": true, "Frameworx-1.0": true,
+	"FreeBSD-DOC": true, "FreeImage": true, "FSFAP": true, "FSFAP-no-warranty-disclaimer": true,
+	"FSFUL": true, "FSFULLR": true, "FSFULLRWD": true, "FTL": true, "Furuseth": true, "fwlw": true,
+	"GCR-docs": true, "GD": true, "GFDL-1.1": true, "GFDL-1.1-invariants-only": true,
+	"GFDL-1.1-invariants-or-later": true, "GFDL-1.1-no-invariants-only": true,
+	"GFDL-1.1-no-invariants-or-later": true, "GFDL-1.1-only": true, "GFDL-1.1-or-later": true,
+	"GFDL-1.2": true, "GFDL-1.2-invariants-only": true, "GFDL-1.2-invariants-or-later": true,
+	"GFDL-1.2-no-invariants-only": true, "GFDL-1.2-no-invariants-or-later": true,
+	"GFDL-1.2-only": true, "GFDL-1.2-or-later": true, "GFDL-1.3": true,
+	"GFDL-1.3-invariants-only": true, "GFDL-1.3-invariants-or-later": true,
+	"GFDL-1.3-no-invariants-only": true, "GFDL-1.3-no-invariants-or-later": true,
+	"GFDL-1.3-only": true, "GFDL-1.3-or-later": true, "Giftware": true, "GL2PS": true, "Glide": true,
+	"Glulxe": true, "GLWTPL": true, "gnuplot": true, "GPL-1.0": true, "GPL-1.0+": true,
+	"GPL-1.0-only": true, "GPL-1.0-or-later": true, "GPL-2.0": true, "GPL-2.0+": true,
+	"GPL-2.0-only": true, "GPL-2.0-or-later": true, "GPL-2.0-with-autoconf-exception": true,
+	"GPL-2.0-with-bison-exception": true, "GPL-2.0-with-classpath-exception": true,
+	"GPL-2.0-with-font-exception": true, "GPL-2.0-with-GCC-exception": true, "GPL-3.0": true,
+	"GPL-3.0+": true, "GPL-3.0-only": true, "GPL-3.0-or-later": true,
+	"GPL-3.0-with-autoconf-exception": true, "GPL-3.0-with-GCC-exception": true,
+	"Graphics-Gems": true, "gSOAP-1.3b": true, "gtkbook": true, "HaskellReport": true, "hdparm": true,
+	"Hippocratic-2.1": true, "HP-1986": true, "HP-1989": true, "HPND": true, "HPND-DEC": true,
+	"HPND-doc": true, "HPND-doc-sell": true, "HPND-export-US": true, "HPND-export-US-modify": true,
+	"HPND-Fenneberg-Livingston": true, "HPND-INRIA-IMAG": true, "HPND-Kevlin-Henney": true,
+	"HPND-Markus-Kuhn": true, "HPND-MIT-disclaimer": true, "HPND-Pbmplus": true,
+	"HPND-sell-MIT-disclaimer-xserver": true, "HPND-sell-regexpr": true, "HPND-sell-variant": true,
+	"HPND-sell-variant-MIT-disclaimer": true, "HPND-UC": true, "HTMLTIDY": true, "IBM-pibs": true,
+	"ICU": true, "IEC-Code-Components-EULA": true, "IJG": true, "IJG-short": true,
+	"ImageMagick": true, "iMatix": true, "Imlib2": true, "Info-ZIP": true, "Inner-Net-2.0": true,
+	"Intel": true, "Intel-ACPI": true, "Interbase-1.0": true, "IPA": true, "IPL-1.0": true,
+	"ISC": true, "ISC-Veillard": true, "Jam": true, "JasPer-2.0": true, "JPL-image": true,
+	"JPNIC": true, "JSON": true, "Kastrup": true, "Kazlib": true, "Knuth-CTAN": true, "LAL-1.2": true,
+	"LAL-1.3": true, "Latex2e": true, "Latex2e-translated-notice": true, "Leptonica": true,
+	"LGPL-2.0": true, "LGPL-2.0+": true, "LGPL-2.0-only": true, "LGPL-2.0-or-later": true,
+	"LGPL-2.1": true, "LGPL-2.1+": true, "LGPL-2.1-only": true, "LGPL-2.1-or-later": true,
+	"LGPL-3.0": true, "LGPL-3.0+": true, "LGPL-3.0-only": true, "LGPL-3.0-or-later": true,
+	"LGPLLR": true, "Libpng": true, "libpng-2.0": true, "libselinux-1.0": true, "libtiff": true,
+	"libutil-David-Nugent": true, "LiLiQ-P-1.1": true, "LiLiQ-R-1.1": true, "LiLiQ-Rplus-1.1": true,
+	"Linux-man-pages-1-para": true, "Linux-man-pages-copyleft": true,
+	"Linux-man-pages-copyleft-2-para": true, "Linux-man-pages-copyleft-var": true,
+	"Linux-OpenIB": true, "LOOP": true, "LPD-document": true, "LPL-1.0": true, "LPL-1.02": true,
+	"LPPL-1.0": true, "LPPL-1.1": true, "LPPL-1.2": true, "LPPL-1.3a": true, "LPPL-1.3c": true,
+	"lsof": true, "Lucida-Bitmap-Fonts": true, "LZMA-SDK-9.11-to-9.20": true, "LZMA-SDK-9.22": true,
+	"Mackerras-3-Clause": true, "Mackerras-3-Clause-acknowledgment": true, "magaz": true,
+	"mailprio": true, "MakeIndex": true, "Martin-Birgmeier": true, "McPhee-slideshow": true,
+	"metamail": true, "Minpack": true, "MirOS": true, "MIT": true, "MIT-0": true,
+	"MIT-advertising": true, "MIT-CMU": true, "MIT-enna": true, "MIT-feh": true, "MIT-Festival": true,
+	"MIT-Modern-Variant": true, "MIT-open-group": true, "MIT-testregex": true, "MIT-Wu": true,
+	"MITNFA": true, "MMIXware": true, "Motosoto": true, "MPEG-SSG": true, "mpi-permissive": true,
+	"mpich2": true, "MPL-1.0": true, "MPL-1.1": true, "MPL-2.0": true,
+	"MPL-2.0-no-copyleft-exception": true, "mplus": true, "MS-LPL": true, "MS-PL": true,
+	"MS-RL": true, "MTLL": true, "MulanPSL-1.0": true, "MulanPSL-2.0": true, "Multics": true,
+	"Mup": true, "NAIST-2003": true, "NASA-1.3": true, "Naumen": true, "NBPL-1.0": true,
+	"NCGL-UK-2.0": true, "NCSA": true, "Net-SNMP": true, "NetCDF": true, "Newsletr": true,
+	"NGPL": true, "NICTA-1.0": true, "NIST-PD": true, "NIST-PD-fallback": true, "NIST-Software": true,
+	"NLOD-1.0": true, "NLOD-2.0": true, "NLPL": true, "Nokia": true, "NOSL": true, "Noweb": true,
+	"NPL-1.0": true, "NPL-1.1": true, "NPOSL-3.0": true, "NRL": true, "NTP": true, "NTP-0": true,
+	"Nunit": true, "O-UDA-1.0": true, "OCCT-PL": true, "OCLC-2.0": true, "ODbL-1.0": true,
+	"ODC-By-1.0": true, "OFFIS": true, "OFL-1.0": true, "OFL-1.0-no-RFN": true, "OFL-1.0-RFN": true,
+	"OFL-1.1": true, "OFL-1.1-no-RFN": true, "OFL-1.1-RFN": true, "OGC-1.0": true,
+	"OGDL-Taiwan-1.0": true, "OGL-Canada-2.0": true, "OGL-UK-1.0": true, "OGL-UK-2.0": true,
+	"OGL-UK-3.0": true, "OGTSL": true, "OLDAP-1.1": true, "OLDAP-1.2": true, "OLDAP-1.3": true,
+	"OLDAP-1.4": true, "OLDAP-2.0": true, "OLDAP-2.0.1": true, "OLDAP-2.1": true, "OLDAP-2.2": true,
+	"OLDAP-2.2.1": true, "OLDAP-2.2.2": true, "OLDAP-2.3": true, "OLDAP-2.4": true, "OLDAP-2.5": true,
+	"OLDAP-2.6": true, "OLDAP-2.7": true, "OLDAP-2.8": true, "OLFL-1.3": true, "OML": true,
+	"OpenPBS-2.3": true, "OpenSSL": true, "OpenSSL-standalone": true, "OpenVision": true,
+	"OPL-1.0": true, "OPL-UK-3.0": true, "OPUBL-1.0": true, "OSET-PL-2.1": true, "OSL-1.0": true,
+	"OSL-1.1": true, "OSL-2.0": true, "OSL-2.1": true, "OSL-3.0": true, "PADL": true,
+	"Parity-6.0.0": true, "Parity-7.0.0": true, "PDDL-1.0": true, "PHP-3.0": true, "PHP-3.01": true,
+	"Pixar": true, "Plexus": true, "pnmstitch": true, "PolyForm-Noncommercial-1.0.0": true,
+	"PolyForm-Small-Business-1.0.0": true, "PostgreSQL": true, "PSF-2.0": true, "psfrag": true,
+	"psutils": true, "Python-2.0": true, "Python-2.0.1": true, "python-ldap": true, "Qhull": true,
+	"QPL-1.0": true, "QPL-1.0-INRIA-2004": true, "radvd": true, "Rdisc": true, "RHeCos-1.1": true,
+	"RPL-1.1": true, "RPL-1.5": true, "RPSL-1.0": true, "RSA-MD": true, "RSCPL": true, "Ruby": true,
+	"SAX-PD": true, "SAX-PD-2.0": true, "Saxpath": true, "SCEA": true, "SchemeReport": true,
+	"Sendmail": true, "Sendmail-8.23": true, "SGI-B-1.0": true, "SGI-B-1.1": true, "SGI-B-2.0": true,
+	"SGI-OpenGL": true, "SGP4": true, "SHL-0.5": true, "SHL-0.51": true, "SimPL-2.0": true,
+	"SISSL": true, "SISSL-1.2": true, "SL": true, "Sleepycat": true, "SMLNJ": true, "SMPPL": true,
+	"SNIA": true, "snprintf": true, "softSurfer": true, "Soundex": true, "Spencer-86": true,
+	"Spencer-94": true, "Spencer-99": true, "SPL-1.0": true, "ssh-keyscan": true, "SSH-OpenSSH": true,
+	"SSH-short": true, "SSLeay-standalone": true, "SSPL-1.0": true, "StandardML-NJ": true,
+	"SugarCRM-1.1.3": true, "Sun-PPP": true, "SunPro": true, "SWL": true, "swrule": true,
+	"Symlinks": true, "TAPR-OHL-1.0": true, "TCL": true, "TCP-wrappers": true, "TermReadKey": true,
+	"TGPPL-1.0": true, "TMate": true, "TORQUE-1.1": true, "TOSL": true, "TPDL": true, "TPL-1.0": true,
+	"TTWL": true, "TTYP0": true, "TU-Berlin-1.0": true, "TU-Berlin-2.0": true, "UCAR": true,
+	"UCL-1.0": true, "ulem": true, "UMich-Merit": true, "Unicode-3.0": true, "Unicode-DFS-2015": true,
+	"Unicode-DFS-2016": true, "Unicode-TOU": true, "UnixCrypt": true, "Unlicense": true,
+	"UPL-1.0": true, "URT-RLE": true, "Vim": true, "VOSTROM": true, "VSL-1.0": true, "W3C": true,
+	"W3C-19980720": true, "W3C-20150513": true, "w3m": true, "Watcom-1.0": true,
+	"Widget-Workshop": true, "Wsuipa": true, "WTFPL": true, "wxWindows": true, "X11": true,
+	"X11-distribute-modifications-variant": true, "Xdebug-1.03": true, "Xerox": true, "Xfig": true,
+	"XFree86-1.1": true, "xinetd": true, "xkeyboard-config-Zinoviev": true, "xlock": true,
+	"Xnet": true, "xpp": true, "XSkat": true, "YPL-1.0": true, "YPL-1.1": true, "Zed": true,
+	"Zeeff": true, "Zend-2.0": true, "Zimbra-1.3": true, "Zimbra-1.4": true, "Zlib": true,
+	"zlib-acknowledgement": true, "ZPL-1.1": true, "ZPL-2.0": true, "ZPL-2.1": true,
+}
+
+// exceptionIDs are the identifiers of the SPDX License Exceptions List 3.23.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var exceptionIDs = map[string]bool{
+	"389-exception": true, "Asterisk-exception": true, "Autoconf-exception-2.0": true,
+	"Autoconf-exception-3.0": true, "Autoconf-exception-generic": true,
+	"Autoconf-exception-generic-3.0": true, "Autoconf-exception-macro": true,
+	"Bison-exception-1.24": true, "Bison-exception-2.2": true, "Bootloader-exception": true,
+	"Classpath-exception-2.0": true, "CLISP-exception-2.0": true,
+	"cryptsetup-OpenSSL-exception": true, "DigiRule-FOSS-exception": true, "eCos-exception-2.0": true,
+	"Fawkes-Runtime-exception": true, "FLTK-exception": true, "fmt-exception": true,
+	"Font-exception-2.0": true, "freertos-exception-2.0": true, "GCC-exception-2.0": true,
+	"GCC-exception-2.0-note": true, "GCC-exception-3.1": true, "Gmsh-exception": true,
+	"GNAT-exception": true, "GNOME-examples-exception": true, "GNU-compiler-exception": true,
+	"gnu-javamail-exception": true, "GPL-3.0-interface-exception": true,
+	"GPL-3.0-linking-exception": true, "GPL-3.0-linking-source-exception": true, "GPL-CC-1.0": true,
+	"GStreamer-exception-2005": true, "GStreamer-exception-2008": true,
+	"i2p-gpl-java-exception": true, "KiCad-libraries-exception": true,
+	"LGPL-3.0-linking-exception": true, "libpri-OpenH323-exception": true, "Libtool-exception": true,
+	"Linux-syscall-note": true, "LLGPL": true, "LLVM-exception": true, "LZMA-exception": true,
+	"mif-exception": true, "Nokia-Qt-exception-1.1": true, "OCaml-LGPL-linking-exception": true,
+	"OCCT-exception-1.0": true, "OpenJDK-assembly-exception-1.0": true,
+	"openvpn-openssl-exception": true, "PS-or-PDF-font-exception-20170817": true,
+	"QPL-1.0-INRIA-2004-exception": true, "Qt-GPL-exception-1.0": true, "Qt-LGPL-exception-1.1": true,
+	"Qwt-exception-1.0": true, "SANE-exception": true, "SHL-2.0": true, "SHL-2.1": true,
+	"stunnel-exception": true, "SWI-exception": true, "Swift-exception": true,
+	"Texinfo-exception": true, "u-boot-exception-2.0": true, "UBDL-exception": true,
+	"Universal-FOSS-exception-1.0": true, "vsftpd-openssl-exception": true,
+	"WxWindows-exception-3.1": true, "x11vnc-openssl-exception": true,
+}
@@ -0,0 +1,197 @@
+// Package spdx parses SPDX license expressions (MIT, Apache-2.0 OR MIT,
+// GPL-2.0-or-later WITH Classpath-exception-2.0...), as specified by annex D
+// of the SPDX specification, against the SPDX License List.
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidExpression reports a license expression that does not parse,
+// or names a license or exception the SPDX License List does not have.
+var ErrInvalidExpression = errors.New("invalid SPDX license expression")
+
+// idstring matches the characters of license and exception identifiers.
+var idstring = regexp.MustCompile(`^[A-Za-z0-9.-]+$`)
+
+// Parse checks a license expression. Like Cabal, which hadolint validates
+// labels with, identifiers and operators are case-sensitive, and NONE is a
+// valid expression on its own.
+func Parse(expression string) error {
+	if expression == "NONE" {
+		return nil
+	}
+
+	parser := &parser{tokens: tokenize(expression)}
+	if err := parser.compound(); err != nil {
+		return err
+	}
+
+	if !parser.done() {
+		return parser.fail("unexpected %q", parser.peek())
+	}
+
+	return nil
+}
+
+// Valid reports whether a license expression parses.
+func Valid(expression string) bool {
+	return Parse(expression) == nil
+}
+
+// tokenize splits an expression on whitespace and parentheses.
+func tokenize(expression string) []string {
+	var tokens []string
+
+	for _, field := range strings.Fields(expression) {
+		for field != "" {
+			index := strings.IndexAny(field, "()")
+
+			switch {
+			case index < 0:
+				tokens, field = append(tokens, field), ""
+			case index > 0:
+				tokens, field = append(tokens, field[:index]), field[index:]
+			default:
+				tokens, field = append(tokens, field[:1]), field[1:]
+			}
+		}
+	}
+
+	return tokens
+}
+
+// parser is a recursive descent parser of the expression grammar:
+//
+//	compound = and { "OR" and }
+//	and      = term { "AND" term }
+//	term     = "(" compound ")" | simple [ "WITH" exception ]
+type parser struct {
+	tokens []string
+	next   int
+}
+
+func (p *parser) done() bool {
+	return p.next == len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() string {
+	token := p.peek()
+	p.next++
+
+	return token
+}
+
+func (p *parser) fail(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidExpression, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) compound() error {
+	if err := p.and(); err != nil {
+		return err
+	}
+
+	for p.peek() == "OR" {
+		p.take()
+
+		if err := p.and(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) and() error {
+	if err := p.term(); err != nil {
+		return err
+	}
+
+	for p.peek() == "AND" {
+		p.take()
+
+		if err := p.term(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) term() error {
+	if p.peek() == "(" {
+		p.take()
+
+		if err := p.compound(); err != nil {
+			return err
+		}
+
+		if p.take() != ")" {
+			return p.fail("missing )")
+		}
+
+		return nil
+	}
+
+	if err := p.simple(p.take()); err != nil {
+		return err
+	}
+
+	if p.peek() != "WITH" {
+		return nil
+	}
+
+	p.take()
+
+	if exception := p.take(); !exceptionIDs[exception] {
+		return p.fail("unknown license exception %q", exception)
+	}
+
+	return nil
+}
+
+// simple checks a license: a listed identifier, optionally followed by +
+// (or later), or a user-defined LicenseRef-, possibly from another SPDX
+// document (DocumentRef-...:LicenseRef-...).
+func (p *parser) simple(license string) error {
+	if license == "" || license == ")" {
+		return p.fail("missing license")
+	}
+
+	if document, ref, ok := strings.Cut(license, ":"); ok {
+		if !isUserDefined(document, "DocumentRef-") || !isUserDefined(ref, "LicenseRef-") {
+			return p.fail("invalid license reference %q", license)
+		}
+
+		return nil
+	}
+
+	if isUserDefined(license, "LicenseRef-") {
+		return nil
+	}
+
+	if !licenseIDs[strings.TrimSuffix(license, "+")] {
+		return p.fail("unknown license %q", license)
+	}
+
+	return nil
+}
+
+// isUserDefined reports whether an identifier is prefix followed by an
+// idstring.
+func isUserDefined(identifier, prefix string) bool {
+	name, ok := strings.CutPrefix(identifier, prefix)
+
+	return ok && idstring.MatchString(name)
+}
@@ -0,0 +1,57 @@
+package spdx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/farcloser/godolint/internal/spdx"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"MIT",
+		"BSD-3-Clause",
+		"NONE",
+		"GPL-2.0",
+		"GPL-2.0+",
+		"LGPL-2.1-or-later",
+		"Apache-2.0 OR MIT",
+		"MIT AND (LGPL-2.1-or-later OR BSD-3-Clause)",
+		"(MIT)",
+		"GPL-2.0-or-later WITH Classpath-exception-2.0",
+		"Apache-2.0 WITH LLVM-exception OR MIT",
+		"LicenseRef-acme-proprietary",
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2",
+		"MIT  AND\tISC",
+	}
+	for _, expression := range valid {
+		if err := spdx.Parse(expression); err != nil {
+			t.Errorf("Parse(%q) error = %v, want nil", expression, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"not-spdx",
+		"mit",
+		"MIT and ISC",
+		"MIT AND",
+		"OR MIT",
+		"(MIT",
+		"MIT)",
+		"MIT ISC",
+		"MIT WITH",
+		"MIT WITH not-an-exception",
+		"(MIT OR ISC) WITH LLVM-exception",
+		"LicenseRef-",
+		"DocumentRef-doc:MIT",
+		"NONE OR MIT",
+	}
+	for _, expression := range invalid {
+		if err := spdx.Parse(expression); !errors.Is(err, spdx.ErrInvalidExpression) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidExpression", expression, err)
+		}
+	}
+}
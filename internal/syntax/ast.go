@@ -8,12 +8,44 @@ package syntax
 type Instruction interface {
 	// Name returns the instruction name (FROM, RUN, COPY, etc.)
 	Name() string
+	// Raw returns the instruction as written in the Dockerfile.
+	Raw() *RawSource
+}
+
+// RawSource is an instruction as written in the Dockerfile, before the
+// normalization of its fields: what BuildKit's own checks look at. It is
+// godolint's own, and left zero for instructions not read by the parser,
+// and for those ONBUILD wraps.
+type RawSource struct {
+	Keyword           string   // Keyword in its original casing (e.g., "from", "Run")
+	Original          string   // Instruction text, continuation lines joined
+	Args              []string // Arguments as buildkit splits them, flags excluded (e.g., "ENV a b" is a, b, "")
+	Flags             []string // Flags (e.g., "--platform=linux/amd64")
+	Comments          []string // Comment lines right above the instruction, without blank lines between
+	EmptyContinuation bool     // A continuation line is empty
+}
+
+// Raw returns the raw source.
+func (r *RawSource) Raw() *RawSource {
+	return r
+}
+
+// SetRaw records the raw source, as the parser reads it.
+func (r *RawSource) SetRaw(raw RawSource) {
+	*r = raw
 }
 
 // InstructionPos is ported from InstructionPos in Language.Docker.Syntax.
 type InstructionPos struct {
 	Instruction Instruction
 	LineNumber  int
+	EndLine     int // Last line of the instruction (continuations, heredocs); 0 when unknown
+}
+
+// Span returns the first and last line of the instruction. An unknown end
+// line (0) means the instruction fits on its first line.
+func (p InstructionPos) Span() (start, end int) {
+	return p.LineNumber, max(p.EndLine, p.LineNumber)
 }
 
 // BaseImage field names match Haskell: image, tag, digest, alias, platform (all lowercase).
@@ -27,6 +59,8 @@ type BaseImage struct {
 
 // From is ported from From in Language.Docker.Syntax.
 type From struct {
+	RawSource
+
 	Image BaseImage
 }
 
@@ -37,8 +71,17 @@ func (*From) Name() string {
 
 // Run is ported from Run in Language.Docker.Syntax.
 type Run struct {
-	Command string   // The shell command to execute
-	Flags   []string // RUN instruction flags (e.g., --mount)
+	RawSource
+
+	Command  string    // The shell command to execute
+	Flags    []string  // RUN instruction flags (e.g., --mount)
+	Heredocs []Heredoc // Here-documents fed to the command (RUN <<EOF)
+}
+
+// Heredoc is a here-document of a RUN instruction.
+type Heredoc struct {
+	Name    string // Delimiter (e.g., "EOF")
+	Content string // Body, up to the delimiter line
 }
 
 // Name returns the instruction name.
@@ -48,6 +91,8 @@ func (*Run) Name() string {
 
 // Copy is ported from Copy in Language.Docker.Syntax.
 type Copy struct {
+	RawSource
+
 	Source      []string // Source paths
 	Destination string   // Destination path
 	From        *string  // Optional --from flag for multi-stage
@@ -60,8 +105,11 @@ func (*Copy) Name() string {
 
 // Add is ported from Add in Language.Docker.Syntax.
 type Add struct {
+	RawSource
+
 	Source      []string // Source paths or URLs
 	Destination string   // Destination path
+	Checksum    *string  // Optional --checksum flag, verifying a remote source
 }
 
 // Name returns the instruction name.
@@ -71,6 +119,8 @@ func (*Add) Name() string {
 
 // Env is ported from Env in Language.Docker.Syntax.
 type Env struct {
+	RawSource
+
 	Pairs []EnvPair // Environment variable key-value pairs
 }
 
@@ -87,6 +137,8 @@ func (*Env) Name() string {
 
 // Label is ported from Label in Language.Docker.Syntax.
 type Label struct {
+	RawSource
+
 	Pairs []LabelPair // Label key-value pairs
 }
 
@@ -103,6 +155,8 @@ func (*Label) Name() string {
 
 // Workdir is ported from Workdir in Language.Docker.Syntax.
 type Workdir struct {
+	RawSource
+
 	Directory string
 }
 
@@ -113,6 +167,8 @@ func (*Workdir) Name() string {
 
 // User is ported from User in Language.Docker.Syntax.
 type User struct {
+	RawSource
+
 	User string
 }
 
@@ -123,6 +179,8 @@ func (*User) Name() string {
 
 // Expose is ported from Expose in Language.Docker.Syntax.
 type Expose struct {
+	RawSource
+
 	Ports []string // Port specifications
 }
 
@@ -133,6 +191,8 @@ func (*Expose) Name() string {
 
 // Volume is ported from Volume in Language.Docker.Syntax.
 type Volume struct {
+	RawSource
+
 	Volumes []string // Volume mount points
 }
 
@@ -143,6 +203,8 @@ func (*Volume) Name() string {
 
 // Cmd is ported from Cmd in Language.Docker.Syntax.
 type Cmd struct {
+	RawSource
+
 	Arguments []string // Command arguments
 	IsJSON    bool     // true if using JSON/exec form, false if shell form
 }
@@ -154,6 +216,8 @@ func (*Cmd) Name() string {
 
 // Entrypoint is ported from Entrypoint in Language.Docker.Syntax.
 type Entrypoint struct {
+	RawSource
+
 	Arguments []string // Entrypoint arguments
 	IsJSON    bool     // true if using JSON/exec form, false if shell form
 }
@@ -165,6 +229,8 @@ func (*Entrypoint) Name() string {
 
 // Healthcheck is ported from Healthcheck in Language.Docker.Syntax.
 type Healthcheck struct {
+	RawSource
+
 	Command string // Health check command
 }
 
@@ -176,6 +242,8 @@ func (*Healthcheck) Name() string {
 // Maintainer represents the MAINTAINER instruction.
 // Haskell: Maintainer !Text (single unnamed field).
 type Maintainer struct {
+	RawSource
+
 	MaintainerName string // Maintainer name/email
 }
 
@@ -187,6 +255,8 @@ func (*Maintainer) Name() string {
 // Arg represents the ARG instruction.
 // Haskell: Arg !Text !(Maybe Text) (two unnamed fields: name and optional default value).
 type Arg struct {
+	RawSource
+
 	ArgName string
 	Value   *string // Optional default value
 }
@@ -198,6 +268,8 @@ func (*Arg) Name() string {
 
 // StopSignal is ported from StopSignal in Language.Docker.Syntax.
 type StopSignal struct {
+	RawSource
+
 	Signal string
 }
 
@@ -208,6 +280,8 @@ func (*StopSignal) Name() string {
 
 // Shell is ported from Shell in Language.Docker.Syntax.
 type Shell struct {
+	RawSource
+
 	Arguments []string
 }
 
@@ -218,6 +292,8 @@ func (*Shell) Name() string {
 
 // OnBuild is ported from OnBuild in Language.Docker.Syntax.
 type OnBuild struct {
+	RawSource
+
 	Inner Instruction // The wrapped instruction
 }
 
@@ -228,6 +304,8 @@ func (*OnBuild) Name() string {
 
 // Comment is ported from Comment in Language.Docker.Syntax.
 type Comment struct {
+	RawSource
+
 	Text string // Comment text (without # prefix)
 }
 
@@ -235,3 +313,18 @@ type Comment struct {
 func (*Comment) Name() string {
 	return "COMMENT"
 }
+
+// Directive is a parser directive at the top of a Dockerfile, such as
+// "# escape=`" or "# syntax=docker/dockerfile:1". It is godolint's own:
+// Language.Docker reads directives without keeping them in the AST.
+type Directive struct {
+	RawSource
+
+	Key   string // Lowercase directive name (escape, syntax, check)
+	Value string
+}
+
+// Name returns the instruction name.
+func (*Directive) Name() string {
+	return "DIRECTIVE"
+}
@@ -49,3 +49,18 @@ func AssertNoViolation(t *testing.T, violations []rule.CheckFailure, ruleCode st
 		}
 	}
 }
+
+// AssertViolation asserts that violations contains a failure with the given rule code, on the
+// given line, with the given message.
+func AssertViolation(t *testing.T, violations []rule.CheckFailure, ruleCode string, line int, message string) {
+	t.Helper()
+
+	for _, v := range violations {
+		if string(v.Code) == ruleCode && v.Line == line && v.Message == message {
+			return
+		}
+	}
+
+	t.Errorf("Expected violation %s on line %d with message %q not found. Got violations: %v",
+		ruleCode, line, message, violations)
+}
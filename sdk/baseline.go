@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"io"
+
+	"github.com/farcloser/godolint/internal/baseline"
+)
+
+// Baseline records known violations, so that only new ones are reported.
+// Violations are matched on file, rule code and the fingerprint of the
+// offending instruction, which survives line shifts.
+type Baseline struct {
+	inner *baseline.Baseline
+}
+
+// NewBaseline creates an empty baseline.
+func NewBaseline() *Baseline {
+	return &Baseline{inner: baseline.New()}
+}
+
+// LoadBaseline reads a baseline file, as written by Baseline.Write or
+// `godolint baseline create`.
+func LoadBaseline(path string) (*Baseline, error) {
+	inner, err := baseline.Load(path)
+	if err != nil {
+		//nolint:wrapcheck // baseline.Load already names the file in its errors.
+		return nil, err
+	}
+
+	return &Baseline{inner: inner}, nil
+}
+
+// ReadBaseline decodes a baseline.
+func ReadBaseline(reader io.Reader) (*Baseline, error) {
+	inner, err := baseline.Read(reader)
+	if err != nil {
+		//nolint:wrapcheck // already descriptive, see baseline.Read.
+		return nil, err
+	}
+
+	return &Baseline{inner: inner}, nil
+}
+
+// Add records the violations of a result for the given file name.
+func (b *Baseline) Add(file string, result *Result) {
+	for _, v := range result.Violations {
+		b.inner.Add(toFinding(file, v))
+	}
+}
+
+// Write encodes the baseline as JSON.
+func (b *Baseline) Write(writer io.Writer) error {
+	//nolint:wrapcheck // already descriptive, see baseline.Baseline.Write.
+	return b.inner.Write(writer)
+}
+
+// Diff returns a new result holding only the violations of file that the
+// baseline does not record. Each recorded violation absorbs one match, so a
+// second identical violation on the same instruction is reported as new.
+func (r *Result) Diff(file string, base *Baseline) *Result {
+	matcher := base.inner.Matcher()
+	violations := []Violation{}
+
+	for _, v := range r.Violations {
+		if !matcher.Match(toFinding(file, v)) {
+			violations = append(violations, v)
+		}
+	}
+
+	return &Result{
+		Violations: violations,
+		Errors:     r.Errors,
+		Passed:     len(violations) == 0 && len(r.Errors) == 0,
+	}
+}
+
+func toFinding(file string, v Violation) baseline.Finding {
+	return baseline.Finding{
+		File:        file,
+		Code:        v.Code,
+		Fingerprint: v.Fingerprint,
+		Line:        v.Line,
+		Message:     v.Message,
+	}
+}
@@ -0,0 +1,52 @@
+package sdk_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/farcloser/godolint/sdk"
+)
+
+// INTENTION: Result.Diff should only keep violations missing from the
+// baseline, even after the recorded instructions moved.
+func TestResult_Diff(t *testing.T) {
+	t.Parallel()
+
+	linter := sdk.New()
+
+	before, err := linter.Lint(t.Context(), []byte("FROM debian:latest\nWORKDIR app\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	base := sdk.NewBaseline()
+	base.Add("Dockerfile", before)
+
+	var buf bytes.Buffer
+	if err := base.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	loaded, err := sdk.ReadBaseline(&buf)
+	if err != nil {
+		t.Fatalf("ReadBaseline() error = %v", err)
+	}
+
+	after, err := linter.Lint(t.Context(), []byte("# moved down\nFROM debian:latest\n\nWORKDIR app\nWORKDIR tmp\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	diff := after.Diff("Dockerfile", loaded)
+	if len(diff.Violations) != 1 || diff.Violations[0].Code != "DL3000" || diff.Violations[0].Line != 5 {
+		t.Fatalf("Diff() = %+v, want only the new DL3000 on line 5", diff.Violations)
+	}
+
+	if diff.Passed {
+		t.Error("Diff() passed = true with a new violation")
+	}
+
+	if other := after.Diff("other/Dockerfile", loaded); len(other.Violations) != len(after.Violations) {
+		t.Errorf("Diff() for another file dropped violations: %+v", other.Violations)
+	}
+}
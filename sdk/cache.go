@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+// Cache stores lint and shellcheck results, keyed on the hash of everything
+// that determines them. Implementations must be safe for concurrent use; a
+// failed Put only costs recomputing the value. Back it with anything
+// persistent (a directory restored by CI, a shared store...) to make repeat
+// runs near-instant.
+type Cache interface {
+	// Get returns the value stored under key, if any.
+	Get(key string) ([]byte, bool)
+	// Put stores value under key.
+	Put(key string, value []byte) error
+}
+
+// NewDirCache returns a Cache storing each entry as a file under dir.
+func NewDirCache(dir string) Cache {
+	return cache.NewDir(dir)
+}
+
+// DefaultCacheDir returns godolint's directory in the user cache directory:
+// $XDG_CACHE_HOME/godolint, or the platform's equivalent.
+func DefaultCacheDir() (string, error) {
+	//nolint:wrapcheck // already descriptive, see cache.DefaultDir.
+	return cache.DefaultDir()
+}
+
+// WithCache serves results from the cache: whole lint results, keyed on the
+// Dockerfile content, the rule set and the configuration applied with
+// WithConfig, and shellcheck results, keyed on each RUN script, its shell
+// options, the shellcheck version and the rcfile content. Rules passed with
+// WithRules are identified by their codes only: use distinct caches for
+// differently parameterized rules sharing codes.
+func WithCache(store Cache) Option {
+	return func(l *Linter) {
+		l.cache = store
+	}
+}
+
+// setupCache wraps the shellcheck rules' checkers with the cache, and keys
+// lint results on the final rule set. Called once all options are applied.
+func (l *Linter) setupCache() {
+	if l.cache == nil {
+		return
+	}
+
+	// Never mutate a slice handed over by WithRules.
+	l.rules = slices.Clone(l.rules)
+
+	for i, current := range l.rules {
+		if scRule, ok := current.(*shell.ShellcheckRule); ok {
+			l.rules[i] = shell.NewShellcheckRule(shell.NewCachingShellchecker(scRule.Checker(), l.cache))
+		}
+	}
+
+	l.results = cache.NewResults(l.cache,
+		cache.RulesKey(l.rules), l.configHash, fmt.Sprintf("%T", l.parser),
+		strconv.FormatBool(l.disableIgnorePragmas))
+}
@@ -0,0 +1,78 @@
+package sdk_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/farcloser/godolint/sdk"
+)
+
+// memoryCache is a Cache counting its hits.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	hits    int
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.entries[key]
+	if ok {
+		c.hits++
+	}
+
+	return value, ok
+}
+
+func (c *memoryCache) Put(key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = value
+
+	return nil
+}
+
+// INTENTION: WithCache should serve repeat lints of the same content from
+// the cache, with the same violations.
+func TestWithCache(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryCache{entries: map[string][]byte{}}
+	dockerfile := []byte("FROM debian:latest\nWORKDIR app\n")
+
+	first, err := sdk.New(sdk.WithCache(store)).Lint(t.Context(), dockerfile)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	second, err := sdk.New(sdk.WithCache(store)).Lint(t.Context(), dockerfile)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	if store.hits != 1 {
+		t.Errorf("cache hits = %d, want 1", store.hits)
+	}
+
+	if len(first.Violations) == 0 || len(first.Violations) != len(second.Violations) {
+		t.Fatalf("cached violations = %+v, want %+v", second.Violations, first.Violations)
+	}
+
+	for i := range first.Violations {
+		if first.Violations[i] != second.Violations[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, second.Violations[i], first.Violations[i])
+		}
+	}
+
+	// A different rule set must not share results.
+	if _, err := sdk.New(sdk.WithCache(store), sdk.WithDisabledRules("DL3000")).Lint(t.Context(), dockerfile); err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	if store.hits != 1 {
+		t.Error("results served to a different rule set")
+	}
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"io"
+
+	"github.com/farcloser/godolint/internal/changes"
+)
+
+// Changes is the set of lines touched by a unified diff, used to only report
+// the violations a change introduces.
+type Changes struct {
+	inner *changes.Set
+}
+
+// LoadChanges reads a unified diff file, as produced by `git diff`. Its
+// paths are relative to the top level of the git repository of the working
+// directory, else to the directory of the diff file.
+func LoadChanges(path string) (*Changes, error) {
+	inner, err := changes.Load(path)
+	if err != nil {
+		//nolint:wrapcheck // changes.Load already names the file in its errors.
+		return nil, err
+	}
+
+	return &Changes{inner: inner}, nil
+}
+
+// ParseChanges decodes a unified diff, whose paths are relative to the
+// working directory.
+func ParseChanges(reader io.Reader) (*Changes, error) {
+	inner, err := changes.Parse(reader)
+	if err != nil {
+		//nolint:wrapcheck // already descriptive, see changes.Parse.
+		return nil, err
+	}
+
+	return &Changes{inner: inner}, nil
+}
+
+// Changed returns a new result holding only the violations of file whose
+// instruction the diff touches. Violations of stage-scoped rules (DL3057,
+// GD6007...) count as changed when any line of their stage changes, and
+// violations without a line when any line of the file does. file, absolute
+// or relative to the working directory, is resolved against the directory
+// the diff's paths are relative to; a file the diff does not have keeps no
+// violation.
+func (r *Result) Changed(file string, diff *Changes) *Result {
+	violations := []Violation{}
+
+	for _, v := range r.Violations {
+		if diff.inner.Touches(file, v.scope) {
+			violations = append(violations, v)
+		}
+	}
+
+	return &Result{
+		Violations: violations,
+		Errors:     r.Errors,
+		Passed:     len(violations) == 0 && len(r.Errors) == 0,
+	}
+}
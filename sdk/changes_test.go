@@ -0,0 +1,88 @@
+package sdk_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farcloser/godolint/sdk"
+)
+
+// INTENTION: Result.Changed should only keep violations on instructions the
+// diff touches.
+func TestResult_Changed(t *testing.T) {
+	t.Parallel()
+
+	result, err := sdk.New().Lint(t.Context(), []byte("FROM debian:12\nWORKDIR app\nWORKDIR tmp\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	diff, err := sdk.ParseChanges(strings.NewReader(
+		"--- a/Dockerfile\n+++ b/Dockerfile\n@@ -2,1 +2,2 @@\n WORKDIR app\n+WORKDIR tmp\n",
+	))
+	if err != nil {
+		t.Fatalf("ParseChanges() error = %v", err)
+	}
+
+	changed := result.Changed("Dockerfile", diff)
+	if len(changed.Violations) != 1 || changed.Violations[0].Line != 3 {
+		t.Fatalf("Changed() = %+v, want only the violation on line 3", changed.Violations)
+	}
+
+	if other := result.Changed("other/Dockerfile", diff); !other.Passed {
+		t.Errorf("Changed() for a file outside the diff = %+v, want none", other.Violations)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if abs := result.Changed(filepath.Join(wd, "Dockerfile"), diff); len(abs.Violations) != 1 {
+		t.Errorf("Changed() for an absolute path = %+v, want the violation on line 3", abs.Violations)
+	}
+
+	if wrong := result.Changed(filepath.Join(wd, "docker", "Dockerfile"), diff); !wrong.Passed {
+		t.Errorf("Changed() for another file of the same name = %+v, want none", wrong.Violations)
+	}
+}
+
+// INTENTION: a stage-scoped GD rule should count as changed when another line
+// of its stage does.
+func TestResult_Changed_StageScoped(t *testing.T) {
+	t.Parallel()
+
+	if !sdk.StageScoped()["GD6005"] {
+		t.Fatal("StageScoped() does not have GD6005")
+	}
+
+	path := filepath.Join(t.TempDir(), ".hadolint.yaml")
+	if err := os.WriteFile(path, []byte("rule-families: [privileges]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := sdk.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	result, err := sdk.New(sdk.WithConfig(cfg)).Lint(t.Context(), []byte("FROM debian:12\nUSER 100\nRUN true\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	diff, err := sdk.ParseChanges(strings.NewReader(
+		"--- a/Dockerfile\n+++ b/Dockerfile\n@@ -3,1 +3,1 @@\n-RUN false\n+RUN true\n",
+	))
+	if err != nil {
+		t.Fatalf("ParseChanges() error = %v", err)
+	}
+
+	changed := result.Changed("Dockerfile", diff)
+	if !slices.ContainsFunc(changed.Violations, func(v sdk.Violation) bool { return v.Code == "GD6005" }) {
+		t.Errorf("Changed() = %+v, want GD6005 of the changed stage", changed.Violations)
+	}
+}
@@ -16,14 +16,23 @@ func (e *ParseError) Unwrap() error {
 	return e.Err
 }
 
-// RuleError indicates a rule execution failure.
+// RuleError indicates a rule execution failure. Lint returns it for a rule
+// that cannot run at all (e.g. a shellcheck too old for its options), and
+// lists in Result.Errors the instructions a rule could not check (e.g. a
+// shellcheck crash or timeout).
 type RuleError struct {
 	RuleCode string
-	Err      error
+	// Line is the instruction the rule could not check (1-indexed), or 0.
+	Line int
+	Err  error
 }
 
 func (e *RuleError) Error() string {
-	return fmt.Sprintf("rule %s failed: %v", e.RuleCode, e.Err)
+	if e.Line == 0 {
+		return fmt.Sprintf("rule %s failed: %v", e.RuleCode, e.Err)
+	}
+
+	return fmt.Sprintf("rule %s failed on line %d: %v", e.RuleCode, e.Line, e.Err)
 }
 
 func (e *RuleError) Unwrap() error {
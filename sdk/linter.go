@@ -3,6 +3,10 @@ package sdk
 import (
 	"context"
 
+	"github.com/farcloser/godolint/internal/baseline"
+	"github.com/farcloser/godolint/internal/cache"
+	"github.com/farcloser/godolint/internal/changes"
+	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/parser"
 	"github.com/farcloser/godolint/internal/process"
 	"github.com/farcloser/godolint/internal/rule"
@@ -11,8 +15,15 @@ import (
 
 // Linter performs Dockerfile linting.
 type Linter struct {
-	parser parser.Parser
-	rules  []rule.Rule
+	parser               parser.Parser
+	rules                []rule.Rule
+	disableIgnorePragmas bool
+	cache                Cache
+	// configHash identifies the configuration applied with WithConfig.
+	configHash string
+	results    *cache.Results
+	// err is a configuration error detected by an option, returned by Lint.
+	err error
 }
 
 // Option configures a Linter.
@@ -49,9 +60,41 @@ func WithDisabledRules(codes ...string) Option {
 	}
 }
 
+// WithConfig applies a configuration, typically loaded with LoadConfig: the
+// rule set is rebuilt from AllRulesWithConfig, minus the ignored rules, and
+// inline ignore pragmas are disabled if the configuration says so.
+// Options applied after it (WithDisabledRules, WithShellcheck) compose with it.
+func WithConfig(cfg *config.Config) Option {
+	return func(l *Linter) {
+		l.rules = FilterRules(AllRulesWithConfig(cfg), cfg.Ignored)
+		l.disableIgnorePragmas = cfg.DisableIgnorePragma
+		l.configHash = cfg.Hash()
+	}
+}
+
+// WithDisableIgnorePragmas makes the linter report failures even when an
+// inline `# hadolint ignore=` pragma suppresses them.
+func WithDisableIgnorePragmas(disable bool) Option {
+	return func(l *Linter) {
+		l.disableIgnorePragmas = disable
+	}
+}
+
+// LoadConfig reads a hadolint-compatible YAML configuration file.
+func LoadConfig(path string) (*config.Config, error) {
+	//nolint:wrapcheck // config.Load already names the file in its errors.
+	return config.Load(path)
+}
+
+// FindConfig looks for a configuration file (.hadolint.yaml and friends) in
+// dir and its parents. ok is false when there is none.
+func FindConfig(dir string) (path string, ok bool) {
+	return config.Find(dir)
+}
+
 // shellcheckConfig collects the shellcheck integration settings.
 type shellcheckConfig struct {
-	rcFile string
+	settings shell.Settings
 }
 
 // ShellcheckOption configures the shellcheck integration enabled by WithShellcheck.
@@ -63,12 +106,62 @@ type ShellcheckOption func(*shellcheckConfig)
 // from a temp dir, outside the rcfile search path.
 func WithShellcheckRCFile(path string) ShellcheckOption {
 	return func(c *shellcheckConfig) {
-		c.rcFile = path
+		c.settings.RCFile = path
+	}
+}
+
+// WithShellcheckSeverity sets the minimum severity shellcheck reports
+// (forwarded as --severity). The default is SeverityStyle, reporting
+// everything.
+func WithShellcheckSeverity(severity Severity) ShellcheckOption {
+	return func(c *shellcheckConfig) {
+		c.settings.Severity = string(severity)
+	}
+}
+
+// WithShellcheckInclude restricts shellcheck to the given codes, as "SC2086"
+// or "2086" (forwarded as --include, requires shellcheck >= 0.7.0).
+func WithShellcheckInclude(codes ...string) ShellcheckOption {
+	return func(c *shellcheckConfig) {
+		c.settings.Include = append(c.settings.Include, codes...)
+	}
+}
+
+// WithShellcheckExclude drops the given codes, on top of the ones hadolint
+// excludes (SC2187, SC1090, SC1091).
+func WithShellcheckExclude(codes ...string) ShellcheckOption {
+	return func(c *shellcheckConfig) {
+		c.settings.Exclude = append(c.settings.Exclude, codes...)
+	}
+}
+
+// WithShellcheckEnable turns on optional checks, such as
+// "require-variable-braces" or "all" (forwarded as --enable, requires
+// shellcheck >= 0.7.0). `shellcheck --list-optional` names them.
+func WithShellcheckEnable(checks ...string) ShellcheckOption {
+	return func(c *shellcheckConfig) {
+		c.settings.Enable = append(c.settings.Enable, checks...)
+	}
+}
+
+// WithShellcheckExternalSources lets shellcheck follow sourced files
+// (forwarded as --external-sources). Scripts are checked from a temp dir, so
+// only absolute paths can be followed.
+func WithShellcheckExternalSources(enabled bool) ShellcheckOption {
+	return func(c *shellcheckConfig) {
+		c.settings.ExternalSources = enabled
 	}
 }
 
 // WithShellcheck enables shellcheck integration for RUN instruction validation.
-// Requires the shellcheck binary to be available in PATH.
+// When the shellcheck binary is not in PATH, a built-in analysis reports the
+// most common checks (SC2086, SC2046, SC2164, SC2155, SC2035, SC2181, SC1091)
+// under the same codes; the severity, include and exclude options apply to it,
+// the others do not.
+// The binary's version is detected once: if it cannot honor the options
+// (e.g. an rcfile before shellcheck 0.10.0), Lint fails with a *RuleError
+// wrapping ErrUnsupportedShellcheck. Invalid options (an unknown severity, a
+// malformed code) fail Lint the same way.
 func WithShellcheck(scOpts ...ShellcheckOption) Option {
 	return func(linter *Linter) {
 		cfg := shellcheckConfig{}
@@ -76,8 +169,13 @@ func WithShellcheck(scOpts ...ShellcheckOption) Option {
 			opt(&cfg)
 		}
 
-		checker := shell.NewBinaryShellchecker()
-		checker.RCFile = cfg.rcFile
+		checker, _, err := shell.NewShellchecker(cfg.settings)
+		if err != nil {
+			linter.err = &RuleError{RuleCode: "SHELLCHECK", Err: err}
+
+			return
+		}
+
 		scRule := shell.NewShellcheckRule(checker)
 		linter.rules = append(linter.rules, scRule)
 	}
@@ -87,20 +185,27 @@ func WithShellcheck(scOpts ...ShellcheckOption) Option {
 // By default, uses all implemented rules and the buildkit parser.
 func New(opts ...Option) *Linter {
 	lint := &Linter{
-		parser: parser.NewBuildkitParser(),
-		rules:  AllRules(),
+		parser:     parser.NewBuildkitParser(),
+		rules:      AllRules(),
+		configHash: config.Default().Hash(),
 	}
 
 	for _, opt := range opts {
 		opt(lint)
 	}
 
+	lint.setupCache()
+
 	return lint
 }
 
 // Lint lints the given Dockerfile content.
 // The context can be used for cancellation.
 func (l *Linter) Lint(ctx context.Context, dockerfile []byte) (*Result, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
 	// Check context cancellation before parsing
 	select {
 	case <-ctx.Done():
@@ -126,23 +231,37 @@ func (l *Linter) Lint(ctx context.Context, dockerfile []byte) (*Result, error) {
 	}
 
 	// Run rules
-	processor := process.NewProcessor(l.rules)
-	failures := processor.Run(instructions)
+	processor := process.NewProcessor(l.rules).
+		WithDisableIgnorePragmas(l.disableIgnorePragmas)
+	failures, execErrs := l.results.Run(processor, dockerfile, instructions)
 
 	// Convert to SDK violations
+	scoped := stageScoped(l.rules)
 	violations := make([]Violation, len(failures))
 	for i, f := range failures {
 		violations[i] = Violation{
-			Code:     string(f.Code),
-			Severity: convertSeverity(f.Severity),
-			Message:  f.Message,
-			Line:     f.Line,
+			Code:        string(f.Code),
+			Severity:    convertSeverity(f.Severity),
+			Message:     f.Message,
+			Line:        f.Line,
+			Fingerprint: baseline.Fingerprint(dockerfile, instructions, f.Line),
+			scope:       changes.Scope(instructions, f, scoped[f.Code]),
 		}
 	}
 
+	var ruleErrs []RuleError
+	for _, execErr := range execErrs {
+		ruleErrs = append(ruleErrs, RuleError{
+			RuleCode: string(execErr.Code),
+			Line:     execErr.Line,
+			Err:      execErr.Err,
+		})
+	}
+
 	result := &Result{
 		Violations: violations,
-		Passed:     len(violations) == 0,
+		Errors:     ruleErrs,
+		Passed:     len(violations) == 0 && len(ruleErrs) == 0,
 	}
 
 	return result, nil
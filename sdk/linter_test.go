@@ -3,8 +3,12 @@ package sdk_test
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/farcloser/godolint/internal/rule"
 	"github.com/farcloser/godolint/sdk"
 )
 
@@ -359,6 +363,111 @@ func TestAllRules(t *testing.T) {
 	}
 }
 
+// INTENTION: godolint's own rules should only run once their family is
+// enabled in the configuration.
+func TestAllRulesWithConfig_RuleFamilies(t *testing.T) {
+	t.Parallel()
+
+	has := func(rules []rule.Rule, code string) bool {
+		for _, r := range rules {
+			if string(r.Code()) == code {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if rules := sdk.AllRules(); has(rules, "GD1001") || has(rules, "BK1001") {
+		t.Error("AllRules() contains rules of families that are off by default")
+	}
+
+	path := filepath.Join(t.TempDir(), ".hadolint.yaml")
+	if err := os.WriteFile(path, []byte("rule-families: [dialects]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := sdk.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if rules := sdk.AllRulesWithConfig(cfg); !has(rules, "GD1001") || !has(rules, "DL3000") {
+		t.Error("AllRulesWithConfig() lacks the dialects family or the hadolint rules")
+	}
+}
+
+// INTENTION: the BuildKit checks duplicating hadolint rules should only run
+// when one of those is ignored, so that an issue is reported once.
+func TestAllRulesWithConfig_BuildKitTwins(t *testing.T) {
+	t.Parallel()
+
+	codes := func(t *testing.T, content string) []string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), ".hadolint.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := sdk.LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+
+		var codes []string
+		for _, r := range sdk.AllRulesWithConfig(cfg) {
+			codes = append(codes, string(r.Code()))
+		}
+
+		return codes
+	}
+
+	t.Run("twins enabled", func(t *testing.T) {
+		t.Parallel()
+
+		got := codes(t, "rule-families: [buildkit]\n")
+		for _, code := range []string{"BK1009", "BK1013", "BK1014"} {
+			if slices.Contains(got, code) {
+				t.Errorf("AllRulesWithConfig() contains %s alongside its hadolint twin", code)
+			}
+		}
+
+		if !slices.Contains(got, "BK1001") {
+			t.Error("AllRulesWithConfig() lacks BK1001")
+		}
+	})
+
+	t.Run("twin ignored", func(t *testing.T) {
+		t.Parallel()
+
+		got := codes(t, "rule-families: [buildkit]\nignored: [DL3000, DL4004]\n")
+		if !slices.Contains(got, "BK1009") || !slices.Contains(got, "BK1013") || slices.Contains(got, "BK1014") {
+			t.Errorf("AllRulesWithConfig() = %v, want BK1009 and BK1013 but not BK1014", got)
+		}
+	})
+}
+
+// INTENTION: References() should map the rules citing guidelines, enabled
+// or not, to them.
+func TestReferences(t *testing.T) {
+	t.Parallel()
+
+	references := sdk.References()
+
+	if got := references["GD6002"]; len(got) != 1 || got[0] != "CIS Docker Benchmark 4.8" {
+		t.Errorf("References()[GD6002] = %v, want [CIS Docker Benchmark 4.8]", got)
+	}
+
+	if got := references["BK1009"]; len(got) != 1 {
+		t.Errorf("References()[BK1009] = %v, want its BuildKit documentation", got)
+	}
+
+	if got, ok := references["DL3000"]; ok {
+		t.Errorf("References()[DL3000] = %v, want none", got)
+	}
+}
+
 // INTENTION: FilterRules() should correctly filter out disabled rules.
 func TestFilterRules(t *testing.T) {
 	t.Parallel()
@@ -398,3 +507,51 @@ func AsError(err error, target any) bool {
 
 	return false
 }
+
+// INTENTION: invalid shellcheck options should fail Lint with a RuleError.
+func TestWithShellcheck_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	linter := sdk.New(sdk.WithShellcheck(sdk.WithShellcheckExclude("DL3008")))
+
+	_, err := linter.Lint(context.Background(), []byte("FROM alpine:3.20\nRUN echo $FOO\n"))
+
+	var ruleErr *sdk.RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.RuleCode != "SHELLCHECK" {
+		t.Errorf("Lint() error = %v, want a SHELLCHECK *RuleError", err)
+	}
+}
+
+// crashingShellcheck reports its version, then fails every check with
+// output that is not JSON.
+const crashingShellcheck = `#!/bin/sh
+if [ "$1" = "--version" ]; then echo "version: 0.10.0"; exit 0; fi
+echo "segmentation fault" >&2
+exit 139
+`
+
+// INTENTION: a shellcheck that cannot run must surface in Result.Errors,
+// not as a clean result. Not parallel: it swaps PATH for a fake shellcheck.
+func TestLinter_Lint_RuleErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "shellcheck"), []byte(crashingShellcheck), 0o700); err != nil {
+		t.Fatalf("writing fake shellcheck: %v", err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	linter := sdk.New(sdk.WithShellcheck())
+
+	result, err := linter.Lint(context.Background(), []byte("FROM alpine:3.20\nRUN echo hello\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	if result.Passed {
+		t.Error("Passed = true despite an unchecked RUN")
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].RuleCode != "SHELLCHECK" || result.Errors[0].Line != 2 {
+		t.Errorf("Errors = %+v, want one SHELLCHECK error on line 2", result.Errors)
+	}
+}
@@ -1,14 +1,112 @@
 package sdk
 
 import (
+	"slices"
+
+	"github.com/farcloser/godolint/internal/config"
 	"github.com/farcloser/godolint/internal/rule"
 	"github.com/farcloser/godolint/internal/rules"
 )
 
-// AllRules returns all 65 implemented hadolint DL#### rules (pure Go).
+// AllRules returns all 66 implemented hadolint DL#### rules (pure Go).
+// godolint's own GD#### rules and the BK#### ports of BuildKit's checks are
+// grouped in families, off unless enabled in the configuration (see
+// AllRulesWithConfig).
 // Shellcheck integration (validates RUN instruction shell scripts via external binary)
 // is opt-in via WithShellcheck() and adds SC#### violations.
 func AllRules() []rule.Rule {
+	return AllRulesWithConfig(config.Default())
+}
+
+// AllRulesWithConfig returns the same rules as AllRules, with the
+// configurable ones (allowed registries, label schema, secrets, .dockerignore,
+// image policy) built from cfg, plus the rules of the families cfg enables.
+func AllRulesWithConfig(cfg *config.Config) []rule.Rule {
+	all := hadolintRules(cfg)
+
+	for _, family := range config.RuleFamilies() {
+		if cfg.FamilyEnabled(family) {
+			all = append(all, familyRules(family, cfg)...)
+		}
+	}
+
+	// Report each issue once: the BuildKit checks duplicating hadolint rules
+	// only run when one of those is ignored.
+	return slices.DeleteFunc(all, func(r rule.Rule) bool {
+		twins, ok := buildKitTwins[r.Code()]
+
+		return ok && !slices.ContainsFunc(twins, func(twin string) bool {
+			return slices.Contains(cfg.Ignored, twin)
+		})
+	})
+}
+
+// buildKitTwins maps the BuildKit checks reporting the same issues as
+// hadolint rules to those rules.
+//
+//nolint:gochecknoglobals // read-only lookup table, effectively constant
+var buildKitTwins = map[rule.Code][]string{
+	"BK1009": {"DL3000"},                     // WorkdirRelativePath
+	"BK1013": {"DL4003", "DL4004", "DL3012"}, // MultipleInstructionsDisallowed: CMD, ENTRYPOINT, HEALTHCHECK
+	"BK1014": {"DL3029"},                     // FromPlatformFlagConstDisallowed
+}
+
+// EveryRule returns every rule godolint implements, whatever the
+// configuration enables, e.g. to document them.
+func EveryRule() []rule.Rule {
+	cfg := config.Default()
+
+	every := hadolintRules(cfg)
+	for _, family := range config.RuleFamilies() {
+		every = append(every, familyRules(family, cfg)...)
+	}
+
+	return every
+}
+
+// References maps the codes of the rules citing external guidelines, such as
+// the CIS Docker Benchmark items of the privilege rules, to them. It covers
+// every rule, enabled or not.
+func References() map[string][]string {
+	references := make(map[string][]string)
+
+	for _, r := range EveryRule() {
+		if cited := rule.References(r); len(cited) > 0 {
+			references[string(r.Code())] = cited
+		}
+	}
+
+	return references
+}
+
+// StageScoped lists the codes of the rules whose findings concern a whole
+// stage rather than the instruction they are reported on, e.g. to match them
+// against the changes of a diff. It covers every rule, enabled or not.
+func StageScoped() map[string]bool {
+	scoped := make(map[string]bool)
+
+	for code := range stageScoped(EveryRule()) {
+		scoped[string(code)] = true
+	}
+
+	return scoped
+}
+
+// stageScoped returns the codes of the stage-scoped rules among rules.
+func stageScoped(rules []rule.Rule) map[rule.Code]bool {
+	scoped := make(map[rule.Code]bool)
+
+	for _, r := range rules {
+		if rule.StageScoped(r) {
+			scoped[r.Code()] = true
+		}
+	}
+
+	return scoped
+}
+
+// hadolintRules returns the DL#### rules.
+func hadolintRules(cfg *config.Config) []rule.Rule {
 	return []rule.Rule{
 		// DL1xxx - Miscellaneous
 		rules.DL1001(),
@@ -37,7 +135,7 @@ func AllRules() []rule.Rule {
 		rules.DL3023(),
 		rules.DL3024(),
 		rules.DL3025(),
-		rules.DL3026(),
+		rules.DL3026WithConfig(cfg),
 		rules.DL3027(),
 		rules.DL3028(),
 		rules.DL3029(),
@@ -58,15 +156,16 @@ func AllRules() []rule.Rule {
 		rules.DL3046(),
 		rules.DL3047(),
 		rules.DL3048(),
-		rules.DL3049(),
-		rules.DL3050(),
-		rules.DL3051(),
-		rules.DL3052(),
-		rules.DL3053(),
-		rules.DL3054(),
-		rules.DL3055(),
+		rules.DL3049WithConfig(cfg),
+		rules.DL3050WithConfig(cfg),
+		rules.DL3051WithConfig(cfg),
+		rules.DL3052WithConfig(cfg),
+		rules.DL3053WithConfig(cfg),
+		rules.DL3054WithConfig(cfg),
+		rules.DL3055WithConfig(cfg),
+		rules.DL3056WithConfig(cfg),
 		rules.DL3057(),
-		rules.DL3058(),
+		rules.DL3058WithConfig(cfg),
 		rules.DL3059(),
 		rules.DL3060(),
 		rules.DL3061(),
@@ -81,6 +180,112 @@ func AllRules() []rule.Rule {
 	}
 }
 
+// familyRules returns the rules of a family.
+func familyRules(family config.RuleFamily, cfg *config.Config) []rule.Rule {
+	switch family {
+	case config.FamilyDialects:
+		return []rule.Rule{
+			rules.GD1001(),
+		}
+	case config.FamilyWindows:
+		return []rule.Rule{
+			rules.GD1101(),
+			rules.GD1102(),
+			rules.GD1103(),
+		}
+	case config.FamilyLayers:
+		return []rule.Rule{
+			rules.GD2001(),
+			rules.GD2002(),
+			rules.GD2101(),
+			rules.GD2102(),
+			rules.GD2103(),
+			rules.GD2104(),
+			rules.GD2105(),
+			rules.GD2106(),
+		}
+	case config.FamilyPinning:
+		return []rule.Rule{
+			rules.GD3001(),
+			rules.GD3002(),
+			rules.GD3003(),
+			rules.GD3004(),
+			rules.GD3005(),
+			rules.GD3006(),
+			rules.GD3007(),
+			rules.GD3008(),
+			rules.GD3009(),
+		}
+	case config.FamilySecrets:
+		return []rule.Rule{
+			rules.GD4001WithConfig(cfg),
+			rules.GD4002WithConfig(cfg),
+			rules.GD4003(),
+			rules.GD4004WithConfig(cfg),
+		}
+	case config.FamilyDownloads:
+		return []rule.Rule{
+			rules.GD5001(),
+			rules.GD5002(),
+			rules.GD5003(),
+			rules.GD5004(),
+			rules.GD5005(),
+		}
+	case config.FamilyPrivileges:
+		return []rule.Rule{
+			rules.GD6001(),
+			rules.GD6002(),
+			rules.GD6003(),
+			rules.GD6004(),
+			rules.GD6005(),
+			rules.GD6006(),
+			rules.GD6007(),
+		}
+	case config.FamilyVariables:
+		return []rule.Rule{
+			rules.GD7001(),
+			rules.GD7002(),
+			rules.GD7003(),
+			rules.GD7004(),
+			rules.GD7005(),
+		}
+	case config.FamilyImages:
+		return []rule.Rule{
+			rules.GD8001WithConfig(cfg),
+			rules.GD8002WithConfig(cfg),
+			rules.GD8003WithConfig(cfg),
+			rules.GD8004WithConfig(cfg),
+			rules.GD8005WithConfig(cfg),
+			rules.GD8006WithConfig(cfg),
+		}
+	case config.FamilyLabels:
+		return []rule.Rule{
+			rules.GD9001WithConfig(cfg),
+		}
+	case config.FamilyBuildKit:
+		return []rule.Rule{
+			rules.BK1001(),
+			rules.BK1002(),
+			rules.BK1003(),
+			rules.BK1004(),
+			rules.BK1005(),
+			rules.BK1006(),
+			rules.BK1007(),
+			rules.BK1008(),
+			rules.BK1009(),
+			rules.BK1010(),
+			rules.BK1011(),
+			rules.BK1012(),
+			rules.BK1013(),
+			rules.BK1014(),
+			rules.BK1015WithConfig(cfg),
+			rules.BK1016(),
+		}
+	default:
+		return nil
+	}
+}
+
 // RuleSet represents a predefined set of rules.
 type RuleSet string
 
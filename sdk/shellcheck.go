@@ -0,0 +1,21 @@
+package sdk
+
+import (
+	"github.com/farcloser/godolint/internal/shell"
+)
+
+// ErrUnsupportedShellcheck reports a shellcheck binary too old for the
+// requested options.
+var ErrUnsupportedShellcheck = shell.ErrUnsupportedVersion
+
+// ShellcheckVersion returns the version of the shellcheck binary in PATH,
+// e.g. "0.10.0".
+func ShellcheckVersion() (string, error) {
+	version, err := shell.DetectVersion()
+	if err != nil {
+		//nolint:wrapcheck // already descriptive, see shell.DetectVersion.
+		return "", err
+	}
+
+	return version.String(), nil
+}
@@ -1,5 +1,7 @@
 package sdk
 
+import "github.com/farcloser/godolint/internal/changes"
+
 // Severity represents the severity level of a violation.
 type Severity string
 
@@ -24,13 +26,23 @@ type Violation struct {
 	Message string `json:"message"`
 	// Line is the line number in the Dockerfile (1-indexed).
 	Line int `json:"line"`
+	// Fingerprint identifies the offending instruction by its text, so that
+	// a violation can be matched against a Baseline across line shifts.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// scope is the span of lines the violation is about, see Result.Changed.
+	scope changes.Span
 }
 
 // Result contains the linting results.
 type Result struct {
 	// Violations contains all detected violations.
 	Violations []Violation
-	// Passed indicates whether the Dockerfile passed linting (no violations).
+	// Errors lists the instructions rules could not check: their lack of
+	// violations proves nothing. Ignore pragmas do not apply to them.
+	Errors []RuleError
+	// Passed indicates whether the Dockerfile passed linting (no violations
+	// and no errors).
 	Passed bool
 }
 